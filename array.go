@@ -299,7 +299,15 @@ func (a *Array) NotHasValue(index int, value interface{}) *Array {
 	return a
 }
 
-// Deprecated: use Value or HasValue instead.
+// First returns a new Value instance with the first element of array.
+//
+// If array is empty, First reports failure and returns empty (but non-nil)
+// instance.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{"foo", 123})
+//	array.First().String().IsEqual("foo")
 func (a *Array) First() *Value {
 	opChain := a.chain.enter("First()")
 	defer opChain.leave()
@@ -314,6 +322,7 @@ func (a *Array) First() *Value {
 			Actual: &AssertionValue{a.value},
 			Errors: []error{
 				errors.New("expected: non-empty array"),
+				errors.New("cannot get first element of empty array"),
 			},
 		})
 		return newValue(opChain, nil)
@@ -322,7 +331,15 @@ func (a *Array) First() *Value {
 	return newValue(opChain, a.value[0])
 }
 
-// Deprecated: use Value or HasValue instead.
+// Last returns a new Value instance with the last element of array.
+//
+// If array is empty, Last reports failure and returns empty (but non-nil)
+// instance.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{"foo", 123})
+//	array.Last().Number().IsEqual(123)
 func (a *Array) Last() *Value {
 	opChain := a.chain.enter("Last()")
 	defer opChain.leave()
@@ -337,6 +354,7 @@ func (a *Array) Last() *Value {
 			Actual: &AssertionValue{a.value},
 			Errors: []error{
 				errors.New("expected: non-empty array"),
+				errors.New("cannot get last element of empty array"),
 			},
 		})
 		return newValue(opChain, nil)
@@ -429,16 +447,16 @@ func (a *Array) Every(fn func(index int, value *Value)) *Array {
 // is returned.
 //
 // If there are any failed assertions in the filtering function, the
-// element is omitted without causing test failure.
+// element is omitted and the failure is reported, just like in Every.
+// To check elements without failing the test, use Find or FindAll.
 //
 // Example:
 //
-//	array := NewArray(t, []interface{}{1, 2, "foo", "bar"})
+//	array := NewArray(t, []interface{}{"foo", "bar", "baz"})
 //	filteredArray := array.Filter(func(index int, value *httpexpect.Value) bool {
-//		value.String().NotEmpty()		//fails on 1 and 2
-//		return value.Raw() != "bar"		//fails on "bar"
+//		return value.String().Raw() != "bar"
 //	})
-//	filteredArray.IsEqual([]interface{}{"foo"})	//succeeds
+//	filteredArray.IsEqual([]interface{}{"foo", "baz"})
 func (a *Array) Filter(fn func(index int, value *Value) bool) *Array {
 	opChain := a.chain.enter("Filter()")
 	defer opChain.leave()
@@ -464,9 +482,6 @@ func (a *Array) Filter(fn func(index int, value *Value) bool) *Array {
 			valueChain := opChain.replace("Filter[%d]", index)
 			defer valueChain.leave()
 
-			valueChain.setRoot()
-			valueChain.setSeverity(SeverityLog)
-
 			if fn(index, newValue(valueChain, element)) && !valueChain.treeFailed() {
 				filteredArray = append(filteredArray, element)
 			}
@@ -514,6 +529,142 @@ func (a *Array) Transform(fn func(index int, value interface{}) interface{}) *Ar
 	return newArray(opChain, transformedArray)
 }
 
+// Map runs the passed function on all the elements in the array and
+// returns a new Array instance with the values returned by the function.
+//
+// Unlike Transform, the function receives element wrapped into Value, so
+// it can run assertions on it. If assertion inside function fails, both
+// the original Array and the returned Array are marked failed.
+//
+// Returned values are converted to canonical form. If a value can't be
+// converted, failure is reported.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{
+//		map[string]interface{}{"id": 1, "name": "foo"},
+//		map[string]interface{}{"id": 2, "name": "bar"},
+//	})
+//
+//	ids := array.Map(func(index int, value *httpexpect.Value) interface{} {
+//		return value.Object().Value("id").Raw()
+//	})
+//	ids.IsEqual([]interface{}{1, 2})
+func (a *Array) Map(fn func(index int, value *Value) interface{}) *Array {
+	opChain := a.chain.enter("Map()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	mappedArray := []interface{}{}
+
+	for index, element := range a.value {
+		func() {
+			valueChain := opChain.replace("Map[%d]", index)
+			defer valueChain.leave()
+
+			result := fn(index, newValue(valueChain, element))
+
+			if mapped, ok := canonValue(valueChain, result); ok {
+				mappedArray = append(mappedArray, mapped)
+			}
+		}()
+	}
+
+	return newArray(opChain, mappedArray)
+}
+
+// Flatten returns a new Array instance with nested arrays flattened
+// by one level.
+//
+// Every element of the array should be an array itself, otherwise failure
+// is reported, naming index of the first offending element. Elements of
+// nested arrays are not flattened further; use FlattenDeep for that.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{
+//		[]interface{}{1, 2},
+//		[]interface{}{3},
+//	})
+//	array.Flatten().IsEqual([]interface{}{1, 2, 3})
+func (a *Array) Flatten() *Array {
+	opChain := a.chain.enter("Flatten()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	flattenedArray := []interface{}{}
+
+	for index, element := range a.value {
+		nested, ok := element.([]interface{})
+		if !ok {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{element},
+				Errors: []error{
+					fmt.Errorf("expected: element %v is array", index),
+				},
+			})
+			return newArray(opChain, nil)
+		}
+
+		flattenedArray = append(flattenedArray, nested...)
+	}
+
+	return newArray(opChain, flattenedArray)
+}
+
+// FlattenDeep returns a new Array instance with nested arrays flattened
+// recursively, at any depth.
+//
+// Unlike Flatten, non-array elements are allowed at any level and are
+// copied to the resulting array as is.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{
+//		1,
+//		[]interface{}{2, []interface{}{3, 4}},
+//	})
+//	array.FlattenDeep().IsEqual([]interface{}{1, 2, 3, 4})
+func (a *Array) FlattenDeep() *Array {
+	opChain := a.chain.enter("FlattenDeep()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	return newArray(opChain, flattenDeep([]interface{}{}, a.value))
+}
+
+func flattenDeep(out, in []interface{}) []interface{} {
+	for _, element := range in {
+		if nested, ok := element.([]interface{}); ok {
+			out = flattenDeep(out, nested)
+		} else {
+			out = append(out, element)
+		}
+	}
+
+	return out
+}
+
 // Find accepts a function that returns a boolean, runs it over the array
 // elements, and returns the first element on which it returned true.
 //
@@ -1547,9 +1698,12 @@ func (a *Array) NotContainsOnly(values ...interface{}) *Array {
 // IsOrdered succeeds if every element is not less than the previous element
 // as defined on the given `less` comparator function.
 // For default, it will use built-in comparator function for each data type.
-// Built-in comparator requires all elements in the array to have same data type.
+// Built-in comparator requires all elements in the array to have same data type,
+// otherwise usage failure is reported.
 // Array with 0 or 1 element will always succeed
 //
+// On failure, the first pair of out-of-order elements is reported.
+//
 // Example:
 //
 //	array := NewArray(t, []interface{}{100, 101, 102})
@@ -1626,8 +1780,8 @@ func (a *Array) IsOrdered(less ...func(x, y *Value) bool) *Array {
 				Reference: &AssertionValue{a.value},
 				Errors: []error{
 					errors.New("expected: reference array is ordered"),
-					fmt.Errorf("element %v must not be less than element %v",
-						i+1, i),
+					fmt.Errorf("element %v (%v) must not be less than element %v (%v)",
+						i+1, a.value[i+1], i, a.value[i]),
 				},
 			})
 			return a
@@ -1731,6 +1885,49 @@ func (a *Array) NotOrdered(less ...func(x, y *Value) bool) *Array {
 	return a
 }
 
+// IsUnique succeeds if array doesn't contain two deeply equal elements.
+//
+// Before comparison, elements are converted to canonical form, the same way
+// as in IsEqual. Objects and arrays are considered equal if their whole
+// content is equal.
+//
+// On failure, the first duplicated element and its two indices are reported.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{1, 2, 3})
+//	array.IsUnique() // succeeds
+//
+//	array := NewArray(t, []interface{}{1, 2, 1})
+//	array.IsUnique() // fails
+func (a *Array) IsUnique() *Array {
+	opChain := a.chain.enter("IsUnique()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return a
+	}
+
+	for i := 1; i < len(a.value); i++ {
+		for j := 0; j < i; j++ {
+			if reflect.DeepEqual(a.value[j], a.value[i]) {
+				opChain.fail(AssertionFailure{
+					Type:   AssertValid,
+					Actual: &AssertionValue{a.value},
+					Errors: []error{
+						errors.New("expected: array elements are unique"),
+						fmt.Errorf("element %v (%v) is equal to element %v",
+							i, a.value[i], j),
+					},
+				})
+				return a
+			}
+		}
+	}
+
+	return a
+}
+
 func countElement(array []interface{}, element interface{}) int {
 	count := 0
 	for _, e := range array {
@@ -1774,19 +1971,10 @@ func builtinComparator(opChain *chain, array []interface{}) func(x, y *Value) bo
 
 		if index > 0 && fmt.Sprintf("%T", curr) != fmt.Sprintf("%T", prev) {
 			opChain.fail(AssertionFailure{
-				Type: AssertEqual,
-				Actual: &AssertionValue{
-					unquotedType(fmt.Sprintf("%T (type of element %v)", curr, index)),
-				},
-				Expected: &AssertionValue{
-					unquotedType(fmt.Sprintf("%T (type of element %v)", prev, index-1)),
-				},
-				Reference: &AssertionValue{
-					array,
-				},
+				Type: AssertUsage,
 				Errors: []error{
-					errors.New("expected:" +
-						" types of all elements of reference array are the same"),
+					errors.New("unexpected mixed element types" +
+						" (custom comparator is required)"),
 					fmt.Errorf("element %v has type %T, but element %v has type %T",
 						index-1, prev, index, curr),
 				},
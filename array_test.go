@@ -1,10 +1,13 @@
 package httpexpect
 
 import (
+	"errors"
+	"fmt"
 	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestArray_FailedChain(t *testing.T) {
@@ -43,6 +46,7 @@ func TestArray_FailedChain(t *testing.T) {
 		value.NotContainsOnly("foo")
 		value.HasValue(0, nil)
 		value.NotHasValue(0, nil)
+		value.IsUnique()
 
 		assert.NotNil(t, value.Iter())
 		assert.Equal(t, 0, len(value.Iter()))
@@ -57,6 +61,11 @@ func TestArray_FailedChain(t *testing.T) {
 		value.Transform(func(index int, value interface{}) interface{} {
 			return nil
 		})
+		value.Map(func(index int, value *Value) interface{} {
+			return nil
+		}).chain.assert(t, failure)
+		value.Flatten().chain.assert(t, failure)
+		value.FlattenDeep().chain.assert(t, failure)
 		value.Find(func(index int, value *Value) bool {
 			value.String().NotEmpty()
 			return true
@@ -268,6 +277,20 @@ func TestArray_Getters(t *testing.T) {
 		value.chain.assert(t, failure)
 		value.chain.clear()
 
+		handler := &mockAssertionHandler{}
+		NewArrayC(Config{AssertionHandler: handler}, data).First()
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertNotEmpty, handler.failure.Type)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("cannot get first element of empty array"))
+
+		handler = &mockAssertionHandler{}
+		NewArrayC(Config{AssertionHandler: handler}, data).Last()
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertNotEmpty, handler.failure.Type)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("cannot get last element of empty array"))
+
 		assert.NotNil(t, value.Iter())
 		value.chain.assert(t, success)
 		value.chain.clear()
@@ -1560,6 +1583,22 @@ func TestArray_Every(t *testing.T) {
 		array.chain.assert(t, failure)
 	})
 
+	t.Run("element path", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{"foo", "bar"})
+
+		paths := [][]string{}
+		array.Every(func(_ int, val *Value) {
+			paths = append(paths, val.chain.context.Path)
+		})
+
+		assert.Equal(t, [][]string{
+			{"Array()", "Every[0]"},
+			{"Array()", "Every[1]"},
+		}, paths)
+		array.chain.assert(t, success)
+	})
+
 	t.Run("invalid argument", func(t *testing.T) {
 		reporter := newMockReporter(t)
 		array := NewArray(reporter, []interface{}{1, 2, 3})
@@ -1642,6 +1681,183 @@ func TestArray_Transform(t *testing.T) {
 	})
 }
 
+func TestArray_Map(t *testing.T) {
+	t.Run("map objects", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{
+			map[string]interface{}{"id": 1, "name": "foo"},
+			map[string]interface{}{"id": 2, "name": "bar"},
+		})
+
+		newArray := array.Map(func(_ int, val *Value) interface{} {
+			return val.Object().Value("id").Raw()
+		})
+
+		assert.Equal(t, []interface{}{1.0, 2.0}, newArray.Raw())
+		newArray.chain.assert(t, success)
+		array.chain.assert(t, success)
+
+		newArray.IsEqual([]interface{}{1, 2})
+		newArray.chain.assert(t, success)
+	})
+
+	t.Run("check index", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{"a", "b", "c"})
+
+		newArray := array.Map(func(idx int, val *Value) interface{} {
+			return idx
+		})
+
+		assert.Equal(t, []interface{}{0.0, 1.0, 2.0}, newArray.Raw())
+		newArray.chain.assert(t, success)
+	})
+
+	t.Run("empty array", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{})
+
+		newArray := array.Map(func(_ int, _ *Value) interface{} {
+			t.Errorf("unexpected call")
+			return nil
+		})
+
+		assert.Equal(t, []interface{}{}, newArray.Raw())
+		newArray.chain.assert(t, success)
+	})
+
+	t.Run("assertion failure", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{
+			map[string]interface{}{"id": 1},
+			map[string]interface{}{"name": "bar"},
+		})
+
+		newArray := array.Map(func(_ int, val *Value) interface{} {
+			return val.Object().Value("id").Raw()
+		})
+
+		newArray.chain.assert(t, failure)
+		array.chain.assert(t, failure)
+	})
+
+	t.Run("canonization", func(t *testing.T) {
+		type (
+			myInt int
+		)
+
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{2, 4})
+
+		newArray := array.Map(func(_ int, val *Value) interface{} {
+			return myInt(val.Number().Raw() * 2)
+		})
+
+		assert.Equal(t, []interface{}{4.0, 8.0}, newArray.Raw())
+		newArray.chain.assert(t, success)
+	})
+
+	t.Run("invalid result", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{2, 4})
+
+		newArray := array.Map(func(_ int, _ *Value) interface{} {
+			return func() {}
+		})
+
+		newArray.chain.assert(t, failure)
+		array.chain.assert(t, failure)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{2, 4, 6})
+
+		newArray := array.Map(nil)
+
+		newArray.chain.assert(t, failure)
+		array.chain.assert(t, failure)
+	})
+}
+
+func TestArray_Flatten(t *testing.T) {
+	t.Run("nested arrays", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{
+			[]interface{}{1, 2},
+			[]interface{}{},
+			[]interface{}{3, []interface{}{4}},
+		})
+
+		newArray := array.Flatten()
+
+		assert.Equal(t,
+			[]interface{}{1.0, 2.0, 3.0, []interface{}{4.0}}, newArray.Raw())
+		newArray.chain.assert(t, success)
+		array.chain.assert(t, success)
+	})
+
+	t.Run("empty array", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{})
+
+		newArray := array.Flatten()
+
+		assert.Equal(t, []interface{}{}, newArray.Raw())
+		newArray.chain.assert(t, success)
+	})
+
+	t.Run("non-array element", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		array := NewArrayC(Config{
+			AssertionHandler: handler,
+		}, []interface{}{
+			[]interface{}{1, 2},
+			3,
+		})
+
+		newArray := array.Flatten()
+
+		newArray.chain.assert(t, failure)
+		array.chain.assert(t, failure)
+
+		assert.NotNil(t, handler.failure)
+		assert.Contains(t, handler.failure.Errors[0].Error(), "element 1")
+	})
+}
+
+func TestArray_FlattenDeep(t *testing.T) {
+	t.Run("nested arrays", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{
+			1,
+			[]interface{}{2, []interface{}{3, []interface{}{4}}},
+			[]interface{}{},
+			"foo",
+			map[string]interface{}{"bar": []interface{}{5}},
+		})
+
+		newArray := array.FlattenDeep()
+
+		assert.Equal(t, []interface{}{
+			1.0, 2.0, 3.0, 4.0, "foo",
+			map[string]interface{}{"bar": []interface{}{5.0}},
+		}, newArray.Raw())
+		newArray.chain.assert(t, success)
+		array.chain.assert(t, success)
+	})
+
+	t.Run("empty array", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{})
+
+		newArray := array.FlattenDeep()
+
+		assert.Equal(t, []interface{}{}, newArray.Raw())
+		newArray.chain.assert(t, success)
+	})
+}
+
 func TestArray_Filter(t *testing.T) {
 	t.Run("elements of same type", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -1707,16 +1923,59 @@ func TestArray_Filter(t *testing.T) {
 		reporter := newMockReporter(t)
 		array := NewArray(reporter, []interface{}{1.0, "foo", "bar", 4.0, "baz", 6.0})
 
+		invoked := 0
 		filteredArray := array.Filter(func(index int, value *Value) bool {
+			invoked++
 			stringifiedValue := value.String().NotEmpty().Raw()
 			return stringifiedValue != "bar"
 		})
 
-		assert.Equal(t, []interface{}{"foo", "baz"}, filteredArray.Raw())
+		assert.Equal(t, 6, invoked)
+		assert.Equal(t, []interface{}{}, filteredArray.Raw())
 		assert.Equal(t, array.Raw(), []interface{}{1.0, "foo", "bar", 4.0, "baz", 6.0})
 
-		array.chain.assert(t, success)
-		filteredArray.chain.assert(t, success)
+		// Should propagate failure from predicate
+		assert.True(t, reporter.reported)
+		array.chain.assert(t, failure)
+		filteredArray.chain.assert(t, failure)
+	})
+
+	t.Run("assertion fails, failure handler", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		array := NewArrayC(Config{
+			AssertionHandler: handler,
+		}, []interface{}{"foo", 2.0})
+
+		array.Filter(func(index int, value *Value) bool {
+			value.String()
+			return true
+		})
+
+		if assert.NotNil(t, handler.failure) {
+			assert.Equal(t, SeverityError, handler.failure.Severity)
+		}
+		if assert.NotNil(t, handler.ctx) {
+			assert.Contains(t, handler.ctx.Path, "Filter[1]")
+		}
+	})
+
+	t.Run("failed array", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFlags(flagFailed)
+
+		array := newArray(chain, []interface{}{"foo", "bar"})
+
+		invoked := 0
+		filteredArray := array.Filter(func(index int, value *Value) bool {
+			invoked++
+			return true
+		})
+
+		assert.Equal(t, 0, invoked)
+		assert.Nil(t, filteredArray.Raw())
+
+		array.chain.assert(t, failure)
+		filteredArray.chain.assert(t, failure)
 	})
 
 	t.Run("invalid argument", func(t *testing.T) {
@@ -2359,6 +2618,107 @@ func TestArray_IsOrdered(t *testing.T) {
 	}
 }
 
+func TestArray_IsOrderedFailure(t *testing.T) {
+	t.Run("out of order pair", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		NewArrayC(Config{AssertionHandler: handler}, []interface{}{1, 3, 2, 0}).
+			IsOrdered()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertLt, handler.failure.Type)
+		assert.Equal(t, "element 2 (2) must not be less than element 1 (3)",
+			handler.failure.Errors[1].Error())
+	})
+
+	t.Run("mixed types", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		NewArrayC(Config{AssertionHandler: handler}, []interface{}{1, "2", 3}).
+			IsOrdered()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertUsage, handler.failure.Type)
+	})
+
+	t.Run("mixed types with comparator", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewArray(reporter, []interface{}{1, "2", 3}).
+			IsOrdered(func(x, y *Value) bool {
+				return fmt.Sprint(x.Raw()) < fmt.Sprint(y.Raw())
+			}).
+			chain.assert(t, success)
+	})
+}
+
+func TestArray_IsUnique(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cases := []struct {
+			name  string
+			value []interface{}
+		}{
+			{"empty", []interface{}{}},
+			{"single", []interface{}{1}},
+			{"numbers", []interface{}{1, 2, 3}},
+			{"mixed types", []interface{}{1, "1", true, nil}},
+			{"objects", []interface{}{
+				map[string]interface{}{"id": 1},
+				map[string]interface{}{"id": 2},
+			}},
+			{"arrays", []interface{}{
+				[]interface{}{1, 2},
+				[]interface{}{2, 1},
+			}},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				NewArray(reporter, tc.value).IsUnique().
+					chain.assert(t, success)
+			})
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		cases := []struct {
+			name  string
+			value []interface{}
+		}{
+			{"numbers", []interface{}{1, 2, 1}},
+			{"int and float", []interface{}{1, 1.0}},
+			{"nulls", []interface{}{nil, nil}},
+			{"objects", []interface{}{
+				map[string]interface{}{"id": 1, "tags": []interface{}{"a"}},
+				map[string]interface{}{"id": 1.0, "tags": []interface{}{"a"}},
+			}},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				NewArray(reporter, tc.value).IsUnique().
+					chain.assert(t, failure)
+			})
+		}
+	})
+
+	t.Run("first duplicate", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		NewArrayC(Config{AssertionHandler: handler}, []interface{}{1, 2, 3, 2, 1}).
+			IsUnique()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertValid, handler.failure.Type)
+		assert.Equal(t, "element 3 (2) is equal to element 1",
+			handler.failure.Errors[1].Error())
+	})
+}
+
 func TestArray_ComparatorErrors(t *testing.T) {
 	t.Run("nil slice", func(t *testing.T) {
 		chain := newMockChain(t).enter("test")
@@ -1,5 +1,7 @@
 package httpexpect
 
+import "time"
+
 // AssertionType defines type of performed assertion.
 type AssertionType uint
 
@@ -138,6 +140,13 @@ type AssertionContext struct {
 	// Whether reporter is known to output to testing.TB
 	// For example, true when reporter is testing.T or testify-based reporter.
 	TestingTB bool
+
+	// Time spent in assertion, including nested assertions
+	// For Expect(), includes time spent sending request and receiving
+	// response; network round-trip time alone is Response.RoundTripTime()
+	// Measured only if AssertionHandler may use it, i.e. it is not
+	// DefaultAssertionHandler with DefaultFormatter; otherwise zero
+	Duration time.Duration
 }
 
 // AssertionFailure provides detailed information about failed assertion.
@@ -227,6 +236,23 @@ type AssertionHandler interface {
 	Failure(*AssertionContext, *AssertionFailure)
 }
 
+// Invokes multiple handlers in order; used for Config.AssertionHandlers
+type assertionHandlerList []AssertionHandler
+
+func (l assertionHandlerList) Success(ctx *AssertionContext) {
+	for _, handler := range l {
+		handler.Success(ctx)
+	}
+}
+
+func (l assertionHandlerList) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	for _, handler := range l {
+		handler.Failure(ctx, failure)
+	}
+}
+
 // DefaultAssertionHandler is default implementation for AssertionHandler.
 //
 //   - Formatter is used to format success and failure messages
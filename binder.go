@@ -66,11 +66,14 @@ func (binder Binder) RoundTrip(origReq *http.Request) (*http.Response, error) {
 
 	binder.Handler.ServeHTTP(recorder, &req)
 
+	result := recorder.Result()
+
 	resp := http.Response{
 		Request:    &req,
 		StatusCode: recorder.Code,
 		Status:     http.StatusText(recorder.Code),
-		Header:     recorder.Result().Header,
+		Header:     result.Header,
+		Trailer:    result.Trailer,
 	}
 
 	if recorder.Flushed {
@@ -0,0 +1,74 @@
+package httpexpect
+
+import (
+	"compress/gzip"
+	"io"
+	"sync"
+)
+
+// Request body reader that is produced on the fly
+// Calls writeFn in a separate goroutine on first read and pipes its output
+// to the reader, so that the body is never fully stored in memory
+// If body is never read, writeFn is never called
+type bodyStream struct {
+	pipeReader *io.PipeReader
+	pipeWriter *io.PipeWriter
+
+	writeFn func(w io.Writer) error
+
+	once sync.Once
+}
+
+func newBodyStream(writeFn func(w io.Writer) error) *bodyStream {
+	pr, pw := io.Pipe()
+
+	return &bodyStream{
+		pipeReader: pr,
+		pipeWriter: pw,
+		writeFn:    writeFn,
+	}
+}
+
+// Read body contents
+func (bs *bodyStream) Read(p []byte) (int, error) {
+	bs.once.Do(func() {
+		go func() {
+			_ = bs.pipeWriter.CloseWithError(bs.writeFn(bs.pipeWriter))
+		}()
+	})
+
+	return bs.pipeReader.Read(p)
+}
+
+// Close body
+// If writeFn is still running, its next write will fail
+func (bs *bodyStream) Close() error {
+	bs.once.Do(func() {})
+
+	return bs.pipeReader.Close()
+}
+
+// Returns writeFn for bodyStream that compresses src
+// Closes src when done, so that if src is itself a stream, it's stopped too
+func gzipWriteFn(src io.ReadCloser) func(w io.Writer) error {
+	return func(w io.Writer) error {
+		defer src.Close()
+
+		zw := gzip.NewWriter(w)
+
+		if _, err := io.Copy(zw, src); err != nil {
+			return err
+		}
+
+		return zw.Close()
+	}
+}
+
+// Writer that forwards writes to a replaceable destination
+type switchWriter struct {
+	w io.Writer
+}
+
+func (sw *switchWriter) Write(p []byte) (int, error) {
+	return sw.w.Write(p)
+}
@@ -0,0 +1,120 @@
+package httpexpect
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyStream_Read(t *testing.T) {
+	callCount := 0
+
+	bs := newBodyStream(func(w io.Writer) error {
+		callCount++
+		_, err := w.Write([]byte("test_body"))
+		return err
+	})
+
+	assert.Equal(t, 0, callCount)
+
+	b, err := ioutil.ReadAll(bs)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_body", string(b))
+	assert.Equal(t, 1, callCount)
+
+	err = bs.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, callCount)
+}
+
+func TestBodyStream_Error(t *testing.T) {
+	bs := newBodyStream(func(w io.Writer) error {
+		_, _ = w.Write([]byte("test_"))
+		return errors.New("test_error")
+	})
+
+	b, err := ioutil.ReadAll(bs)
+	assert.Equal(t, errors.New("test_error"), err)
+	assert.Equal(t, "test_", string(b))
+}
+
+func TestBodyStream_Close(t *testing.T) {
+	t.Run("before read", func(t *testing.T) {
+		callCount := 0
+
+		bs := newBodyStream(func(w io.Writer) error {
+			callCount++
+			return nil
+		})
+
+		err := bs.Close()
+		assert.NoError(t, err)
+
+		_, err = bs.Read(make([]byte, 1))
+		assert.Equal(t, io.ErrClosedPipe, err)
+		assert.Equal(t, 0, callCount)
+	})
+
+	t.Run("during read", func(t *testing.T) {
+		done := make(chan error, 1)
+
+		bs := newBodyStream(func(w io.Writer) error {
+			for {
+				if _, err := w.Write([]byte("test_body")); err != nil {
+					done <- err
+					return err
+				}
+			}
+		})
+
+		_, err := bs.Read(make([]byte, 1))
+		assert.NoError(t, err)
+
+		err = bs.Close()
+		assert.NoError(t, err)
+
+		assert.Equal(t, io.ErrClosedPipe, <-done)
+	})
+}
+
+func TestBodyStream_Gzip(t *testing.T) {
+	t.Run("read", func(t *testing.T) {
+		src := newMockBody("test_body")
+
+		bs := newBodyStream(gzipWriteFn(src))
+
+		// Should not read source until body is read
+		assert.Equal(t, 0, src.readCount)
+
+		b, err := ioutil.ReadAll(bs)
+		assert.NoError(t, err)
+
+		zr, err := gzip.NewReader(bytes.NewReader(b))
+		assert.NoError(t, err)
+		plain, err := ioutil.ReadAll(zr)
+		assert.NoError(t, err)
+		assert.Equal(t, "test_body", string(plain))
+
+		// Should close source when done
+		assert.Equal(t, 1, src.closeCount)
+
+		assert.NoError(t, bs.Close())
+		assert.Equal(t, 1, src.closeCount)
+	})
+
+	t.Run("source error", func(t *testing.T) {
+		src := newMockBody("test_body")
+		src.readErr = errors.New("test_error")
+
+		bs := newBodyStream(gzipWriteFn(src))
+
+		_, err := ioutil.ReadAll(bs)
+		assert.Equal(t, errors.New("test_error"), err)
+		assert.Equal(t, 1, src.closeCount)
+	})
+}
@@ -3,6 +3,7 @@ package httpexpect
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"runtime"
@@ -167,3 +168,106 @@ func (bw *bodyWrapper) closeAndCancel() error {
 
 	return bw.closeErr
 }
+
+// Wrapper for response body reader used when buffering is disabled
+// Reads directly from original reader; closes it and cancels request
+// context on Close or when garbage collected
+type unbufferedBody struct {
+	reader     io.ReadCloser
+	cancelFunc context.CancelFunc
+
+	isClosed bool
+	closeErr error
+
+	mu sync.Mutex
+}
+
+func newUnbufferedBody(
+	reader io.ReadCloser, cancelFunc context.CancelFunc,
+) *unbufferedBody {
+	ub := &unbufferedBody{
+		reader:     reader,
+		cancelFunc: cancelFunc,
+	}
+
+	runtime.SetFinalizer(ub, (*unbufferedBody).Close)
+
+	return ub
+}
+
+func (ub *unbufferedBody) Read(p []byte) (int, error) {
+	return ub.reader.Read(p)
+}
+
+func (ub *unbufferedBody) Close() error {
+	ub.mu.Lock()
+	defer ub.mu.Unlock()
+
+	if ub.isClosed {
+		return ub.closeErr
+	}
+	ub.isClosed = true
+
+	ub.closeErr = ub.reader.Close()
+
+	if ub.cancelFunc != nil {
+		ub.cancelFunc()
+		ub.cancelFunc = nil
+	}
+
+	runtime.SetFinalizer(ub, nil)
+
+	return ub.closeErr
+}
+
+// Wrapper for response body reader that fails if body is larger than
+// given limit; if Content-Length is known to exceed limit, fails without
+// reading anything
+type limitedBody struct {
+	reader io.ReadCloser
+	limit  int64
+	count  int64
+	err    error
+}
+
+func newLimitedBody(reader io.ReadCloser, length, limit int64) *limitedBody {
+	lb := &limitedBody{
+		reader: reader,
+		limit:  limit,
+	}
+
+	if length > limit {
+		lb.err = lb.limitError()
+	}
+
+	return lb
+}
+
+func (lb *limitedBody) Read(p []byte) (int, error) {
+	if lb.err != nil {
+		return 0, lb.err
+	}
+
+	// read one byte more than limit to detect overflow
+	if remain := lb.limit - lb.count + 1; int64(len(p)) > remain {
+		p = p[:remain]
+	}
+
+	n, err := lb.reader.Read(p)
+	lb.count += int64(n)
+
+	if lb.count > lb.limit {
+		lb.err = lb.limitError()
+		return n - int(lb.count-lb.limit), lb.err
+	}
+
+	return n, err
+}
+
+func (lb *limitedBody) Close() error {
+	return lb.reader.Close()
+}
+
+func (lb *limitedBody) limitError() error {
+	return fmt.Errorf("response body exceeds configured limit of %d bytes", lb.limit)
+}
@@ -265,3 +265,34 @@ func TestBodyWrapper_ErrorRewind(t *testing.T) {
 		assert.NotNil(t, err)
 	}
 }
+
+func TestBodyWrapper_Unbuffered(t *testing.T) {
+	body := newMockBody("test_body")
+
+	cancelCount := 0
+	cancelFn := func() {
+		cancelCount++
+	}
+
+	ub := newUnbufferedBody(body, cancelFn)
+
+	b, err := ioutil.ReadAll(ub)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_body", string(b))
+
+	b, err = ioutil.ReadAll(ub)
+	assert.NoError(t, err)
+	assert.Equal(t, "", string(b))
+
+	assert.Equal(t, 0, body.closeCount)
+	assert.Equal(t, 0, cancelCount)
+
+	err = ub.Close()
+	assert.NoError(t, err)
+
+	err = ub.Close()
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, body.closeCount)
+	assert.Equal(t, 1, cancelCount)
+}
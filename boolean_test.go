@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBoolean_FailedChain(t *testing.T) {
@@ -175,6 +176,46 @@ func TestBoolean_IsValue(t *testing.T) {
 	}
 }
 
+func TestBoolean_IsValueFailure(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    bool
+		fn       func(*Boolean)
+		expected bool
+	}{
+		{
+			name:     "IsTrue",
+			value:    false,
+			fn:       func(b *Boolean) { b.IsTrue() },
+			expected: true,
+		},
+		{
+			name:     "IsFalse",
+			value:    true,
+			fn:       func(b *Boolean) { b.IsFalse() },
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &mockAssertionHandler{}
+
+			value := NewBooleanC(Config{
+				AssertionHandler: handler,
+			}, tc.value)
+
+			tc.fn(value)
+			value.chain.assert(t, failure)
+
+			require.NotNil(t, handler.failure)
+			assert.Equal(t, AssertEqual, handler.failure.Type)
+			assert.Equal(t, &AssertionValue{tc.value}, handler.failure.Actual)
+			assert.Equal(t, &AssertionValue{tc.expected}, handler.failure.Expected)
+		})
+	}
+}
+
 func TestBoolean_InList(t *testing.T) {
 	t.Run("basic", func(t *testing.T) {
 		for _, value := range []bool{true, false} {
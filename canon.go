@@ -103,6 +103,28 @@ func canonDecode(opChain *chain, value interface{}, target interface{}) {
 		return
 	}
 
+	rv := reflect.ValueOf(target)
+
+	if rv.Kind() != reflect.Ptr {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-pointer target argument of type %T", target),
+			},
+		})
+		return
+	}
+
+	if rv.IsNil() {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected nil pointer target argument of type %T", target),
+			},
+		})
+		return
+	}
+
 	b, err := json.Marshal(value)
 	if err != nil {
 		opChain.fail(AssertionFailure{
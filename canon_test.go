@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCanon_Number(t *testing.T) {
@@ -175,8 +176,63 @@ func TestCannon_Decode(t *testing.T) {
 		defer chain.leave()
 
 		var target int
-		canonDecode(chain, true, target)
+		canonDecode(chain, true, &target)
 
 		chain.assertFailed(t)
 	})
+
+	t.Run("target is not a pointer", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults()).enter("test")
+
+		var target int
+		canonDecode(chain, 123, target)
+		chain.leave()
+
+		chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertUsage, handler.failure.Type)
+		assert.Contains(t, handler.failure.Errors[0].Error(), "non-pointer")
+	})
+
+	t.Run("target is a nil pointer", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults()).enter("test")
+
+		var target *int
+		canonDecode(chain, 123, target)
+		chain.leave()
+
+		chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertUsage, handler.failure.Type)
+		assert.Contains(t, handler.failure.Errors[0].Error(), "nil pointer")
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults()).enter("test")
+
+		var target struct {
+			Foo int `json:"foo"`
+		}
+		canonDecode(chain, map[string]interface{}{"foo": "bar"}, &target)
+		chain.leave()
+
+		chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertValid, handler.failure.Type)
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -79,6 +80,16 @@ type chain struct {
 	handler  AssertionHandler
 	severity AssertionSeverity
 	failure  *AssertionFailure
+
+	// if set, only hard failures block subsequent operations on chain
+	// (see Config.SoftAssertions)
+	soft bool
+
+	// if set, enter() records enterTime (see isTimed)
+	timed bool
+
+	// set by enter(), used to fill AssertionContext.Duration
+	enterTime time.Time
 }
 
 // If enabled, chain will panic if used incorrectly or gets illformed AssertionFailure.
@@ -98,6 +109,7 @@ type chainFlags int
 const (
 	flagFailed         chainFlags = (1 << iota) // fail() was called on this chain
 	flagFailedChildren                          // fail() was called on any child
+	flagBlocked                                 // hard failure, used in soft mode
 )
 
 type chainResult bool
@@ -119,6 +131,8 @@ func newChainWithConfig(name string, config Config) *chain {
 
 	c.context.TestName = config.TestName
 
+	c.soft = config.SoftAssertions
+
 	if name != "" {
 		c.context.Path = []string{name}
 		c.context.AliasedPath = []string{name}
@@ -135,6 +149,8 @@ func newChainWithConfig(name string, config Config) *chain {
 
 	c.context.TestingTB = isTestingTB(c.handler)
 
+	c.timed = isTimed(c.handler)
+
 	return c
 }
 
@@ -165,6 +181,8 @@ func newChainWithDefaults(name string, reporter Reporter) *chain {
 
 	c.context.TestingTB = isTestingTB(c.handler)
 
+	c.timed = isTimed(c.handler)
+
 	return c
 }
 
@@ -221,6 +239,23 @@ func (c *chain) setAlias(name string) {
 	}
 }
 
+// Replace last element of path and aliased path with newName,
+// if that element is equal to oldName.
+func (c *chain) rename(oldName, newName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	for _, path := range [][]string{c.context.Path, c.context.AliasedPath} {
+		if len(path) != 0 && path[len(path)-1] == oldName {
+			path[len(path)-1] = newName
+		}
+	}
+}
+
 // Store request name in AssertionContext.
 // Child chains inherit context from parent.
 func (c *chain) setRequestName(name string) {
@@ -282,18 +317,32 @@ func (c *chain) clone() *chain {
 	contextCopy.Path = append(([]string)(nil), contextCopy.Path...)
 	contextCopy.AliasedPath = append(([]string)(nil), c.context.AliasedPath...)
 
+	// flagFailedChildren is not inherited because the newly created clone
+	// doesn't have children
+	flags := c.flags & ^flagFailedChildren
+
+	if c.soft {
+		// in soft mode, clone is failed only if it's created by failed
+		// operation or its parent is blocked by hard failure
+		if c.failure != nil || flags&flagBlocked != 0 {
+			flags |= flagFailed | flagBlocked
+		} else {
+			flags &= ^flagFailed
+		}
+	}
+
 	return &chain{
-		parent: c,
-		state:  stateCloned,
-		// flagFailedChildren is not inherited because the newly created clone
-		// doesn't have children
-		flags:    (c.flags & ^flagFailedChildren),
+		parent:   c,
+		state:    stateCloned,
+		flags:    flags,
 		context:  contextCopy,
 		handler:  c.handler,
 		severity: c.severity,
 		// failure is not inherited because it should be reported only once
 		// by the chain where it happened
 		failure: nil,
+		soft:    c.soft,
+		timed:   c.timed,
 	}
 }
 
@@ -304,6 +353,10 @@ func (c *chain) enter(name string, args ...interface{}) *chain {
 	chainCopy := c.clone()
 
 	chainCopy.state = stateEntered
+	if chainCopy.timed {
+		chainCopy.enterTime = time.Now()
+	}
+	chainCopy.context.Duration = 0
 	if name != "" {
 		chainCopy.context.Path = append(chainCopy.context.Path, fmt.Sprintf(name, args...))
 		chainCopy.context.AliasedPath =
@@ -336,6 +389,10 @@ func (c *chain) replace(name string, args ...interface{}) *chain {
 	chainCopy := c.clone()
 
 	chainCopy.state = stateEntered
+	if chainCopy.timed {
+		chainCopy.enterTime = time.Now()
+	}
+	chainCopy.context.Duration = 0
 	if len(chainCopy.context.Path) != 0 {
 		last := len(chainCopy.context.Path) - 1
 		chainCopy.context.Path[last] = fmt.Sprintf(name, args...)
@@ -361,6 +418,7 @@ func (c *chain) leave() {
 		context AssertionContext
 		handler AssertionHandler
 		failure *AssertionFailure
+		soft    bool
 	)
 	func() {
 		c.mu.Lock()
@@ -377,7 +435,11 @@ func (c *chain) leave() {
 		context = c.context
 		handler = c.handler
 		failure = c.failure
+		soft = c.soft
 
+		if !c.enterTime.IsZero() {
+			context.Duration = time.Since(c.enterTime)
+		}
 	}()
 
 	if flags&(flagFailed|flagFailedChildren) == 0 {
@@ -397,6 +459,9 @@ func (c *chain) leave() {
 	if flags&(flagFailed|flagFailedChildren) != 0 && parent != nil {
 		parent.mu.Lock()
 		parent.flags |= flagFailed
+		if soft && failure != nil && isHardFailure(failure.Type) {
+			parent.flags |= flagBlocked
+		}
 		p := parent.parent
 		parent.mu.Unlock()
 
@@ -435,10 +500,16 @@ func (c *chain) fail(failure AssertionFailure) {
 }
 
 // Check if chain failed.
+// In soft mode, only failures that block subsequent operations are taken
+// into account, as well as failure reported on this very chain.
 func (c *chain) failed() bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.soft {
+		return c.flags&flagBlocked != 0 || c.failure != nil
+	}
+
 	return c.flags&flagFailed != 0
 }
 
@@ -472,7 +543,7 @@ func (c *chain) clearFailed() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.flags &= ^(flagFailed | flagFailedChildren)
+	c.flags &= ^(flagFailed | flagFailedChildren | flagBlocked)
 }
 
 // Clear failure flags.
@@ -481,7 +552,7 @@ func (c *chain) clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.flags &= ^(flagFailed | flagFailedChildren)
+	c.flags &= ^(flagFailed | flagFailedChildren | flagBlocked)
 }
 
 // DEPRECATED: use assert
@@ -537,6 +608,14 @@ func (c *chain) assertFlags(t testing.TB, flags chainFlags) {
 
 // Whether handler outputs to testing.TB
 func isTestingTB(in AssertionHandler) bool {
+	if list, ok := in.(assertionHandlerList); ok {
+		for _, handler := range list {
+			if isTestingTB(handler) {
+				return true
+			}
+		}
+		return false
+	}
 	h, ok := in.(*DefaultAssertionHandler)
 	if !ok {
 		return false
@@ -547,3 +626,36 @@ func isTestingTB(in AssertionHandler) bool {
 	}
 	return false
 }
+
+// Whether handler may use AssertionContext.Duration
+// DefaultFormatter doesn't print duration, so there is no need to measure
+// it when DefaultAssertionHandler is used with DefaultFormatter.
+func isTimed(in AssertionHandler) bool {
+	if list, ok := in.(assertionHandlerList); ok {
+		for _, handler := range list {
+			if isTimed(handler) {
+				return true
+			}
+		}
+		return false
+	}
+	h, ok := in.(*DefaultAssertionHandler)
+	if !ok {
+		return true
+	}
+	_, ok = h.Formatter.(*DefaultFormatter)
+	return !ok
+}
+
+// Check if failure of given type makes subsequent operations on chain
+// meaningless, and thus should stop chain even in soft mode.
+func isHardFailure(typ AssertionType) bool {
+	switch typ {
+	case AssertUsage, AssertOperation,
+		AssertType, AssertNotType,
+		AssertValid, AssertNotValid,
+		AssertNil, AssertNotNil:
+		return true
+	}
+	return false
+}
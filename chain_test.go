@@ -4,8 +4,10 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func testFailure() AssertionFailure {
@@ -623,6 +625,135 @@ func TestChain_Handler(t *testing.T) {
 	})
 }
 
+func TestChain_Duration(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		opChain := chain.enter("test")
+		time.Sleep(10 * time.Millisecond)
+		opChain.leave()
+
+		require.NotNil(t, handler.ctx)
+		assert.True(t, handler.ctx.Duration >= 10*time.Millisecond)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		opChain := chain.enter("test")
+		time.Sleep(10 * time.Millisecond)
+		opChain.fail(testFailure())
+		opChain.leave()
+
+		require.NotNil(t, handler.ctx)
+		require.NotNil(t, handler.failure)
+		assert.True(t, handler.ctx.Duration >= 10*time.Millisecond)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		outerChain := chain.enter("outer")
+		time.Sleep(10 * time.Millisecond)
+
+		innerChain := outerChain.enter("inner")
+		innerChain.leave()
+
+		require.NotNil(t, handler.ctx)
+		innerDuration := handler.ctx.Duration
+		assert.True(t, innerDuration < 10*time.Millisecond)
+
+		outerChain.leave()
+
+		require.NotNil(t, handler.ctx)
+		assert.True(t, handler.ctx.Duration >= 10*time.Millisecond)
+	})
+
+	t.Run("default formatter", func(t *testing.T) {
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: &DefaultAssertionHandler{
+				Formatter: &DefaultFormatter{},
+				Reporter:  newMockReporter(t),
+			},
+		}.withDefaults())
+
+		opChain := chain.enter("test")
+		assert.True(t, opChain.enterTime.IsZero())
+
+		childChain := opChain.replace("child")
+		assert.True(t, childChain.enterTime.IsZero())
+
+		childChain.leave()
+		opChain.leave()
+	})
+
+	t.Run("custom formatter", func(t *testing.T) {
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: &DefaultAssertionHandler{
+				Formatter: newMockFormatter(t),
+				Reporter:  newMockReporter(t),
+			},
+		}.withDefaults())
+
+		opChain := chain.enter("test")
+		assert.False(t, opChain.enterTime.IsZero())
+
+		childChain := opChain.replace("child")
+		assert.False(t, childChain.enterTime.IsZero())
+
+		childChain.leave()
+		opChain.leave()
+	})
+
+	t.Run("handler list", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: assertionHandlerList{
+				&DefaultAssertionHandler{
+					Formatter: &DefaultFormatter{},
+					Reporter:  newMockReporter(t),
+				},
+				handler,
+			},
+		}.withDefaults())
+
+		opChain := chain.enter("test")
+		time.Sleep(10 * time.Millisecond)
+		opChain.leave()
+
+		require.NotNil(t, handler.ctx)
+		assert.True(t, handler.ctx.Duration >= 10*time.Millisecond)
+	})
+
+	t.Run("handler list with default formatter", func(t *testing.T) {
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: assertionHandlerList{
+				&DefaultAssertionHandler{
+					Formatter: &DefaultFormatter{},
+					Reporter:  newMockReporter(t),
+				},
+			},
+		}.withDefaults())
+
+		opChain := chain.enter("test")
+		assert.True(t, opChain.enterTime.IsZero())
+		opChain.leave()
+	})
+}
+
 func TestChain_Severity(t *testing.T) {
 	t.Run("default", func(t *testing.T) {
 		handler := &mockAssertionHandler{}
@@ -711,6 +842,141 @@ func TestChain_Reporting(t *testing.T) {
 	assert.Equal(t, failure, *handler.failure)
 }
 
+func TestChain_Soft(t *testing.T) {
+	softFailure := AssertionFailure{
+		Type:     AssertEqual,
+		Actual:   &AssertionValue{"foo"},
+		Expected: &AssertionValue{"bar"},
+		Errors: []error{
+			errors.New("test_error"),
+		},
+	}
+
+	t.Run("default mode", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		opChain := chain.enter("test")
+		opChain.fail(softFailure)
+		opChain.leave()
+
+		assert.True(t, chain.failed())
+
+		opChain = chain.enter("test")
+		assert.True(t, opChain.failed())
+		opChain.leave()
+
+		assert.Equal(t, 1, handler.failureCount)
+	})
+
+	t.Run("soft failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+			SoftAssertions:   true,
+		}.withDefaults())
+
+		opChain := chain.enter("test")
+		opChain.fail(softFailure)
+		assert.True(t, opChain.failed())
+		opChain.leave()
+
+		assert.False(t, chain.failed())
+
+		opChain = chain.enter("test")
+		assert.False(t, opChain.failed())
+		opChain.fail(softFailure)
+		opChain.leave()
+
+		assert.False(t, chain.failed())
+		assert.Equal(t, 2, handler.failureCount)
+	})
+
+	t.Run("hard failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+			SoftAssertions:   true,
+		}.withDefaults())
+
+		opChain := chain.enter("test")
+		opChain.fail(testFailure())
+		opChain.leave()
+
+		assert.True(t, chain.failed())
+
+		opChain = chain.enter("test")
+		assert.True(t, opChain.failed())
+		opChain.leave()
+
+		assert.Equal(t, 1, handler.failureCount)
+	})
+
+	t.Run("child of failed operation", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+			SoftAssertions:   true,
+		}.withDefaults())
+
+		opChain := chain.enter("test")
+		opChain.fail(softFailure)
+		child := opChain.clone()
+		opChain.leave()
+
+		assert.True(t, child.failed())
+		assert.False(t, chain.failed())
+
+		opChain = chain.enter("test")
+		child = opChain.clone()
+		opChain.leave()
+
+		assert.False(t, child.failed())
+	})
+
+	t.Run("matchers", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			AssertionHandler: handler,
+			SoftAssertions:   true,
+		}
+
+		NewStringC(config, "foo").
+			IsEqual("bar").
+			HasPrefix("b").
+			IsEqual("foo")
+
+		assert.Equal(t, 2, handler.failureCount)
+
+		handler.failureCount = 0
+
+		object := NewObjectC(config, map[string]interface{}{
+			"foo": 123,
+			"bar": "baz",
+		})
+		object.Value("foo").Number().IsEqual(456)
+		object.Value("bar").String().IsEqual("qux")
+		object.Value("baz").String().IsEqual("qux")
+
+		assert.Equal(t, 3, handler.failureCount)
+
+		handler.failureCount = 0
+
+		value := NewValueC(config, "foo")
+		value.Number().IsEqual(123) // type failure blocks value
+		value.String().IsEqual("bar")
+
+		assert.Equal(t, 1, handler.failureCount)
+	})
+}
+
 func TestChain_TestingTB(t *testing.T) {
 	type args struct {
 		handler  AssertionHandler
@@ -801,6 +1067,34 @@ func TestChain_TestingTB(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "handler list with testing.T",
+			args: args{
+				handler: assertionHandlerList{
+					&mockAssertionHandler{},
+					&DefaultAssertionHandler{
+						Formatter: newMockFormatter(t),
+						Reporter:  t,
+					},
+				},
+				reporter: t,
+			},
+			want: true,
+		},
+		{
+			name: "handler list without testing.T",
+			args: args{
+				handler: assertionHandlerList{
+					&mockAssertionHandler{},
+					&DefaultAssertionHandler{
+						Formatter: newMockFormatter(t),
+						Reporter:  newMockReporter(t),
+					},
+				},
+				reporter: newMockReporter(t),
+			},
+			want: false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
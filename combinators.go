@@ -0,0 +1,124 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AllOf returns a matcher that runs all given matchers on value.
+//
+// The returned matcher succeeds if every given matcher succeeds.
+// Failures of individual matchers are reported as usual.
+//
+// Example:
+//
+//	value := NewValue(t, "hello")
+//	AllOf(
+//		func(v *Value) { v.IsString() },
+//		func(v *Value) { v.String().NotEmpty() },
+//	)(value)
+func AllOf(matchers ...func(*Value)) func(*Value) {
+	return func(value *Value) {
+		opChain := value.chain.enter("AllOf()")
+		defer opChain.leave()
+
+		if opChain.failed() {
+			return
+		}
+
+		if !checkMatchers(opChain, matchers) {
+			return
+		}
+
+		for i, matcher := range matchers {
+			func() {
+				valueChain := opChain.replace("AllOf[%d]", i)
+				defer valueChain.leave()
+
+				matcher(newValue(valueChain, value.value))
+			}()
+		}
+	}
+}
+
+// AnyOf returns a matcher that runs given matchers on value until
+// one of them succeeds.
+//
+// The returned matcher succeeds if at least one given matcher succeeds.
+// Failures of individual matchers are not reported (they are only
+// logged); if all matchers fail, a single combined failure is reported.
+//
+// Example:
+//
+//	value := NewValue(t, nil)
+//	AnyOf(
+//		func(v *Value) { v.String().NotEmpty() },
+//		func(v *Value) { v.IsNull() },
+//	)(value)
+func AnyOf(matchers ...func(*Value)) func(*Value) {
+	return func(value *Value) {
+		opChain := value.chain.enter("AnyOf()")
+		defer opChain.leave()
+
+		if opChain.failed() {
+			return
+		}
+
+		if !checkMatchers(opChain, matchers) {
+			return
+		}
+
+		for i, matcher := range matchers {
+			matched := func() bool {
+				valueChain := opChain.replace("AnyOf[%d]", i)
+				defer valueChain.leave()
+
+				valueChain.setRoot()
+				valueChain.setSeverity(SeverityLog)
+
+				matcher(newValue(valueChain, value.value))
+
+				return !valueChain.treeFailed()
+			}()
+
+			if matched {
+				return
+			}
+		}
+
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value.value},
+			Errors: []error{
+				errors.New("expected: value matches at least one matcher"),
+				fmt.Errorf("all %d matchers failed", len(matchers)),
+			},
+		})
+	}
+}
+
+func checkMatchers(opChain *chain, matchers []func(*Value)) bool {
+	if len(matchers) == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty matchers list"),
+			},
+		})
+		return false
+	}
+
+	for _, matcher := range matchers {
+		if matcher == nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected nil matcher"),
+				},
+			})
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,200 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombinators_AllOf(t *testing.T) {
+	isString := func(v *Value) { v.IsString() }
+	notEmpty := func(v *Value) { v.String().NotEmpty() }
+	isNull := func(v *Value) { v.IsNull() }
+
+	cases := []struct {
+		name       string
+		value      interface{}
+		matchers   []func(*Value)
+		wantResult chainResult
+	}{
+		{
+			name:       "all succeed",
+			value:      "foo",
+			matchers:   []func(*Value){isString, notEmpty},
+			wantResult: success,
+		},
+		{
+			name:       "one fails",
+			value:      "",
+			matchers:   []func(*Value){isString, notEmpty},
+			wantResult: failure,
+		},
+		{
+			name:       "all fail",
+			value:      123,
+			matchers:   []func(*Value){isString, isNull},
+			wantResult: failure,
+		},
+		{
+			name:       "no matchers",
+			value:      "foo",
+			matchers:   []func(*Value){},
+			wantResult: failure,
+		},
+		{
+			name:       "nil matcher",
+			value:      "foo",
+			matchers:   []func(*Value){isString, nil},
+			wantResult: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewValue(reporter, tc.value)
+			AllOf(tc.matchers...)(value)
+
+			value.chain.assert(t, tc.wantResult)
+			assert.Equal(t, tc.wantResult == failure, reporter.reported)
+		})
+	}
+
+	t.Run("runs all matchers", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		var calls []string
+
+		value := NewValue(reporter, "foo")
+		AllOf(
+			func(v *Value) {
+				calls = append(calls, "first")
+				v.IsNull()
+			},
+			func(v *Value) {
+				calls = append(calls, "second")
+			},
+		)(value)
+
+		value.chain.assert(t, failure)
+		assert.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("failed chain", func(t *testing.T) {
+		called := false
+
+		value := newValue(newFailedChain(t), "foo")
+		AllOf(func(v *Value) {
+			called = true
+		})(value)
+
+		value.chain.assert(t, failure)
+		assert.False(t, called)
+	})
+}
+
+func TestCombinators_AnyOf(t *testing.T) {
+	notEmpty := func(v *Value) { v.String().NotEmpty() }
+	isNull := func(v *Value) { v.IsNull() }
+	isNumber := func(v *Value) { v.IsNumber() }
+
+	cases := []struct {
+		name       string
+		value      interface{}
+		matchers   []func(*Value)
+		wantResult chainResult
+	}{
+		{
+			name:       "first succeeds",
+			value:      "foo",
+			matchers:   []func(*Value){notEmpty, isNull},
+			wantResult: success,
+		},
+		{
+			name:       "second succeeds",
+			value:      nil,
+			matchers:   []func(*Value){notEmpty, isNull},
+			wantResult: success,
+		},
+		{
+			name:       "all fail",
+			value:      "",
+			matchers:   []func(*Value){notEmpty, isNull, isNumber},
+			wantResult: failure,
+		},
+		{
+			name:       "no matchers",
+			value:      "foo",
+			matchers:   []func(*Value){},
+			wantResult: failure,
+		},
+		{
+			name:       "nil matcher",
+			value:      "foo",
+			matchers:   []func(*Value){nil, notEmpty},
+			wantResult: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewValue(reporter, tc.value)
+			AnyOf(tc.matchers...)(value)
+
+			value.chain.assert(t, tc.wantResult)
+			assert.Equal(t, tc.wantResult == failure, reporter.reported)
+		})
+	}
+
+	t.Run("stops after first success", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		var calls []string
+
+		value := NewValue(reporter, nil)
+		AnyOf(
+			func(v *Value) {
+				calls = append(calls, "first")
+				v.IsString()
+			},
+			func(v *Value) {
+				calls = append(calls, "second")
+				v.IsNull()
+			},
+			func(v *Value) {
+				calls = append(calls, "third")
+			},
+		)(value)
+
+		value.chain.assert(t, success)
+		assert.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("nested in AllOf", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, nil)
+		AllOf(
+			AnyOf(notEmpty, isNull),
+			isNull,
+		)(value)
+
+		value.chain.assert(t, success)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("failed chain", func(t *testing.T) {
+		called := false
+
+		value := newValue(newFailedChain(t), "foo")
+		AnyOf(func(v *Value) {
+			called = true
+		})(value)
+
+		value.chain.assert(t, failure)
+		assert.False(t, called)
+	})
+}
@@ -2,7 +2,10 @@ package httpexpect
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -239,6 +242,9 @@ func (c *Cookie) NotHaveMaxAge() *Cookie {
 //
 // If Max-Age is not present, method fails.
 //
+// If cookie was parsed from Set-Cookie header and Max-Age attribute is
+// present but is not a valid integer (see RFC 6265), method fails as well.
+//
 // If Max-Age is present and is zero (which means delete cookie now),
 // methods succeeds and the returned Duration is equal to zero.
 //
@@ -257,6 +263,18 @@ func (c *Cookie) MaxAge() *Duration {
 
 	switch {
 	case c.value.MaxAge == 0: // zero value means not present
+		if val, ok := rawCookieAttr(c.value.Raw, "Max-Age"); ok && !validMaxAge(val) {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{c.value},
+				Errors: []error{
+					errors.New("expected: cookie Max-Age is an integer number of seconds"),
+					fmt.Errorf("invalid Max-Age value %q", val),
+				},
+			})
+			return newDuration(opChain, nil)
+		}
+
 		// TODO: after removing Duration.IsSet, add failure here (breaking change)
 		_ = (*Duration).IsSet
 		return newDuration(opChain, nil)
@@ -270,3 +288,171 @@ func (c *Cookie) MaxAge() *Duration {
 		return newDuration(opChain, &age)
 	}
 }
+
+// HasSecure succeeds if cookie has Secure attribute.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.HasSecure()
+func (c *Cookie) HasSecure() *Cookie {
+	opChain := c.chain.enter("HasSecure()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !c.value.Secure {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie has Secure attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotHasSecure succeeds if cookie does not have Secure attribute.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.NotHasSecure()
+func (c *Cookie) NotHasSecure() *Cookie {
+	opChain := c.chain.enter("NotHasSecure()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.value.Secure {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie does not have Secure attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// HasHTTPOnly succeeds if cookie has HttpOnly attribute.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.HasHTTPOnly()
+func (c *Cookie) HasHTTPOnly() *Cookie {
+	opChain := c.chain.enter("HasHTTPOnly()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !c.value.HttpOnly {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie has HttpOnly attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotHasHTTPOnly succeeds if cookie does not have HttpOnly attribute.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.NotHasHTTPOnly()
+func (c *Cookie) NotHasHTTPOnly() *Cookie {
+	opChain := c.chain.enter("NotHasHTTPOnly()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.value.HttpOnly {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie does not have HttpOnly attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// SameSite returns a new String instance with cookie SameSite attribute.
+//
+// Returned string is one of "Strict", "Lax", or "None". If SameSite
+// attribute is not present or has unknown value, returned string is empty.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.SameSite().IsEqual("Strict")
+func (c *Cookie) SameSite() *String {
+	opChain := c.chain.enter("SameSite()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	switch c.value.SameSite {
+	case http.SameSiteStrictMode:
+		return newString(opChain, "Strict")
+	case http.SameSiteLaxMode:
+		return newString(opChain, "Lax")
+	case http.SameSiteNoneMode:
+		return newString(opChain, "None")
+	default:
+		return newString(opChain, "")
+	}
+}
+
+func rawCookieAttr(raw, name string) (string, bool) {
+	parts := strings.Split(raw, ";")
+
+	// first part is name=value pair, skip it
+	for i := 1; i < len(parts); i++ {
+		attr, val := strings.TrimSpace(parts[i]), ""
+		if j := strings.Index(attr, "="); j >= 0 {
+			attr, val = strings.TrimSpace(attr[:j]), strings.TrimSpace(attr[j+1:])
+		}
+		if strings.EqualFold(attr, name) {
+			return val, true
+		}
+	}
+
+	return "", false
+}
+
+func validMaxAge(val string) bool {
+	secs, err := strconv.Atoi(val)
+	if err != nil {
+		return false
+	}
+
+	// leading zeros are not allowed by RFC 6265
+	if secs != 0 && val[0] == '0' {
+		return false
+	}
+
+	return true
+}
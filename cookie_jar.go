@@ -3,6 +3,7 @@ package httpexpect
 import (
 	"net/http"
 	"net/http/cookiejar"
+	"net/url"
 
 	"golang.org/x/net/publicsuffix"
 )
@@ -27,3 +28,24 @@ func NewCookieJar() http.CookieJar {
 func NewJar() http.CookieJar {
 	return NewCookieJar()
 }
+
+// Cookie jar that hides cookies with given names
+// Used to let cookies set explicitly on request override cookies from jar
+type cookieOverrideJar struct {
+	jar   http.CookieJar
+	names map[string]struct{}
+}
+
+func (j *cookieOverrideJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar.SetCookies(u, cookies)
+}
+
+func (j *cookieOverrideJar) Cookies(u *url.URL) []*http.Cookie {
+	var cookies []*http.Cookie
+	for _, c := range j.jar.Cookies(u) {
+		if _, ok := j.names[c.Name]; !ok {
+			cookies = append(cookies, c)
+		}
+	}
+	return cookies
+}
@@ -27,9 +27,14 @@ func TestCookie_FailedChain(t *testing.T) {
 		value.Path().chain.assert(t, failure)
 		value.Expires().chain.assert(t, failure)
 		value.MaxAge().chain.assert(t, failure)
+		value.SameSite().chain.assert(t, failure)
 
 		value.HasMaxAge()
 		value.NotHasMaxAge()
+		value.HasSecure()
+		value.NotHasSecure()
+		value.HasHTTPOnly()
+		value.NotHasHTTPOnly()
 	}
 
 	t.Run("failed chain", func(t *testing.T) {
@@ -145,6 +150,95 @@ func TestCookie_Getters(t *testing.T) {
 	value.chain.assert(t, success)
 }
 
+func TestCookie_Attributes(t *testing.T) {
+	cases := []struct {
+		name         string
+		setCookie    string
+		wantSecure   chainResult
+		wantHTTPOnly chainResult
+		wantSameSite string
+	}{
+		{
+			name:         "no attributes",
+			setCookie:    "foo=bar",
+			wantSecure:   failure,
+			wantHTTPOnly: failure,
+			wantSameSite: "",
+		},
+		{
+			name:         "secure",
+			setCookie:    "foo=bar; Secure",
+			wantSecure:   success,
+			wantHTTPOnly: failure,
+			wantSameSite: "",
+		},
+		{
+			name:         "httponly",
+			setCookie:    "foo=bar; HttpOnly",
+			wantSecure:   failure,
+			wantHTTPOnly: success,
+			wantSameSite: "",
+		},
+		{
+			name:         "samesite strict",
+			setCookie:    "foo=bar; Secure; HttpOnly; SameSite=Strict",
+			wantSecure:   success,
+			wantHTTPOnly: success,
+			wantSameSite: "Strict",
+		},
+		{
+			name:         "samesite lax",
+			setCookie:    "foo=bar; SameSite=lax",
+			wantSecure:   failure,
+			wantHTTPOnly: failure,
+			wantSameSite: "Lax",
+		},
+		{
+			name:         "samesite none",
+			setCookie:    "foo=bar; Secure; SameSite=None",
+			wantSecure:   success,
+			wantHTTPOnly: failure,
+			wantSameSite: "None",
+		},
+		{
+			name:         "samesite unknown",
+			setCookie:    "foo=bar; SameSite=bad",
+			wantSecure:   failure,
+			wantHTTPOnly: failure,
+			wantSameSite: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			header := http.Header{}
+			header.Add("Set-Cookie", tc.setCookie)
+
+			cookies := (&http.Response{Header: header}).Cookies()
+			require.Equal(t, 1, len(cookies))
+
+			data := cookies[0]
+
+			NewCookie(reporter, data).HasSecure().
+				chain.assert(t, tc.wantSecure)
+
+			NewCookie(reporter, data).NotHasSecure().
+				chain.assert(t, !tc.wantSecure)
+
+			NewCookie(reporter, data).HasHTTPOnly().
+				chain.assert(t, tc.wantHTTPOnly)
+
+			NewCookie(reporter, data).NotHasHTTPOnly().
+				chain.assert(t, !tc.wantHTTPOnly)
+
+			assert.Equal(t, tc.wantSameSite,
+				NewCookie(reporter, data).SameSite().Raw())
+		})
+	}
+}
+
 func TestCookie_MaxAge(t *testing.T) {
 	cases := []struct {
 		name          string
@@ -197,3 +291,68 @@ func TestCookie_MaxAge(t *testing.T) {
 		})
 	}
 }
+
+func TestCookie_MaxAgeRaw(t *testing.T) {
+	cases := []struct {
+		name         string
+		header       string
+		wantMaxAge   chainResult
+		wantDuration *time.Duration
+	}{
+		{
+			name:       "absent",
+			header:     "foo=bar; Path=/",
+			wantMaxAge: success,
+		},
+		{
+			name:         "valid",
+			header:       "foo=bar; Max-Age=60",
+			wantMaxAge:   success,
+			wantDuration: func() *time.Duration { d := time.Minute; return &d }(),
+		},
+		{
+			name:         "zero",
+			header:       "foo=bar; max-age=0",
+			wantMaxAge:   success,
+			wantDuration: func() *time.Duration { d := time.Duration(0); return &d }(),
+		},
+		{
+			name:       "not a number",
+			header:     "foo=bar; Max-Age=abc",
+			wantMaxAge: failure,
+		},
+		{
+			name:       "fractional",
+			header:     "foo=bar; Max-Age=1.5",
+			wantMaxAge: failure,
+		},
+		{
+			name:       "leading zero",
+			header:     "foo=bar; Max-Age=010",
+			wantMaxAge: failure,
+		},
+		{
+			name:       "empty",
+			header:     "foo=bar; Max-Age=",
+			wantMaxAge: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := &http.Response{
+				Header: http.Header{"Set-Cookie": {tc.header}},
+			}
+
+			cookies := resp.Cookies()
+			require.Equal(t, 1, len(cookies))
+
+			maxAge := NewCookie(reporter, cookies[0]).MaxAge()
+			maxAge.chain.assert(t, tc.wantMaxAge)
+
+			assert.Equal(t, tc.wantDuration, maxAge.value)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -364,6 +365,10 @@ func (dt *DateTime) Equal(value time.Time) *DateTime {
 
 // InRange succeeds if DateTime is within given range [min; max].
 //
+// Time points are compared as instants, regardless of their time zones.
+// On failure, all time points are reported in UTC along with their
+// original offsets.
+//
 // Example:
 //
 //	dt := NewDateTime(t, time.Unix(0, 2))
@@ -385,6 +390,9 @@ func (dt *DateTime) InRange(min, max time.Time) *DateTime {
 			Expected: &AssertionValue{AssertionRange{min, max}},
 			Errors: []error{
 				errors.New("expected: time point is within given range"),
+				fmt.Errorf("actual: %s", formatTimeUTC(dt.value)),
+				fmt.Errorf("min: %s", formatTimeUTC(min)),
+				fmt.Errorf("max: %s", formatTimeUTC(max)),
 			},
 		})
 	}
@@ -394,6 +402,10 @@ func (dt *DateTime) InRange(min, max time.Time) *DateTime {
 
 // NotInRange succeeds if DateTime is not within given range [min; max].
 //
+// Time points are compared as instants, regardless of their time zones.
+// On failure, all time points are reported in UTC along with their
+// original offsets.
+//
 // Example:
 //
 //	dt := NewDateTime(t, time.Unix(0, 10))
@@ -415,6 +427,9 @@ func (dt *DateTime) NotInRange(min, max time.Time) *DateTime {
 			Expected: &AssertionValue{AssertionRange{min, max}},
 			Errors: []error{
 				errors.New("expected: time point is not within given range"),
+				fmt.Errorf("actual: %s", formatTimeUTC(dt.value)),
+				fmt.Errorf("min: %s", formatTimeUTC(min)),
+				fmt.Errorf("max: %s", formatTimeUTC(max)),
 			},
 		})
 	}
@@ -669,3 +684,8 @@ func (dt *DateTime) AsLocal() *DateTime {
 
 	return newDateTime(opChain, dt.value.Local())
 }
+
+func formatTimeUTC(t time.Time) string {
+	return fmt.Sprintf("%s (offset %s)",
+		t.UTC().Format(time.RFC3339Nano), t.Format("-07:00"))
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDateTime_FailedChain(t *testing.T) {
@@ -140,6 +141,19 @@ func TestDateTime_IsEqual(t *testing.T) {
 	value.NotEqual(time.Unix(0, 1234))
 	value.chain.assert(t, failure)
 	value.chain.clear()
+
+	utc := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	zoned := utc.In(time.FixedZone("", 2*60*60))
+
+	value = NewDateTime(reporter, zoned)
+
+	value.IsEqual(utc)
+	value.chain.assert(t, success)
+	value.chain.clear()
+
+	value.NotEqual(utc)
+	value.chain.assert(t, failure)
+	value.chain.clear()
 }
 
 func TestDateTime_IsGreater(t *testing.T) {
@@ -243,6 +257,24 @@ func TestDateTime_InRange(t *testing.T) {
 			wantInRange:    failure,
 			wantNotInRange: success,
 		},
+		{
+			name:  "value in different time zone",
+			value: time.Date(2020, 1, 1, 12, 0, 0, 0, time.FixedZone("", 2*60*60)),
+			min:   time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+			max:   time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+
+			wantInRange:    success,
+			wantNotInRange: failure,
+		},
+		{
+			name:  "range in different time zone",
+			value: time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+			min:   time.Date(2020, 1, 1, 13, 0, 0, 0, time.FixedZone("", 2*60*60)),
+			max:   time.Date(2020, 1, 1, 14, 0, 0, 0, time.FixedZone("", 2*60*60)),
+
+			wantInRange:    failure,
+			wantNotInRange: success,
+		},
 		{
 			name:           "invalid range",
 			value:          time.Unix(0, 1234),
@@ -266,6 +298,36 @@ func TestDateTime_InRange(t *testing.T) {
 	}
 }
 
+func TestDateTime_InRangeFailure(t *testing.T) {
+	handler := &mockAssertionHandler{}
+
+	config := Config{
+		AssertionHandler: handler,
+	}
+
+	zone := time.FixedZone("", 2*60*60)
+
+	NewDateTimeC(config, time.Date(2020, 1, 1, 12, 0, 0, 0, zone)).
+		InRange(
+			time.Date(2020, 1, 1, 11, 0, 0, 0, time.UTC),
+			time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+		)
+
+	require.NotNil(t, handler.failure)
+
+	var errs []string
+	for _, err := range handler.failure.Errors {
+		errs = append(errs, err.Error())
+	}
+
+	assert.Equal(t, []string{
+		"expected: time point is within given range",
+		"actual: 2020-01-01T10:00:00Z (offset +02:00)",
+		"min: 2020-01-01T11:00:00Z (offset +00:00)",
+		"max: 2020-01-01T12:00:00Z (offset +00:00)",
+	}, errs)
+}
+
 func TestDateTime_InList(t *testing.T) {
 	cases := []struct {
 		name          string
@@ -63,6 +63,102 @@ func (d *Duration) Alias(name string) *Duration {
 	return d
 }
 
+// Round returns a new Duration instance with duration rounded to the nearest
+// multiple of unit. Halfway values are rounded away from zero.
+//
+// Example:
+//
+//	d := NewDuration(t, 1234*time.Millisecond)
+//	d.Round(100 * time.Millisecond).IsEqual(1200 * time.Millisecond)
+func (d *Duration) Round(unit time.Duration) *Duration {
+	opChain := d.chain.enter("Round()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	if unit <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected non-positive unit argument"),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	if !d.checkPresent(opChain) {
+		return newDuration(opChain, nil)
+	}
+
+	rounded := d.value.Round(unit)
+
+	return newDuration(opChain, &rounded)
+}
+
+// Seconds returns a new Number instance with duration as floating point
+// number of seconds, e.g. 1.5 for 1500ms.
+//
+// Example:
+//
+//	d := NewDuration(t, 1500*time.Millisecond)
+//	d.Seconds().IsEqual(1.5)
+func (d *Duration) Seconds() *Number {
+	opChain := d.chain.enter("Seconds()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, float64(0))
+	}
+
+	if !d.checkPresent(opChain) {
+		return newNumber(opChain, float64(0))
+	}
+
+	return newNumber(opChain, d.value.Seconds())
+}
+
+// Milliseconds returns a new Number instance with duration as floating point
+// number of milliseconds.
+//
+// Unlike time.Duration.Milliseconds, fractional part is preserved,
+// e.g. 1.5 for 1500µs. Use Round to get integral number.
+//
+// Example:
+//
+//	d := NewDuration(t, 250*time.Millisecond)
+//	d.Milliseconds().IsEqual(250)
+func (d *Duration) Milliseconds() *Number {
+	opChain := d.chain.enter("Milliseconds()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, float64(0))
+	}
+
+	if !d.checkPresent(opChain) {
+		return newNumber(opChain, float64(0))
+	}
+
+	return newNumber(opChain, float64(*d.value)/float64(time.Millisecond))
+}
+
+func (d *Duration) checkPresent(opChain *chain) bool {
+	if d.value == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{d.value},
+			Errors: []error{
+				errors.New("expected: duration is present"),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
 // Deprecated: support for unset durations will be removed. The only method that
 // can create unset duration is Cookie.MaxAge. Instead of Cookie.MaxAge().IsSet(),
 // please use Cookie.HasMaxAge().
@@ -15,6 +15,11 @@ func TestDuration_FailedChain(t *testing.T) {
 	value.chain.assert(t, failure)
 
 	value.Alias("foo")
+
+	value.Round(time.Millisecond).chain.assert(t, failure)
+	value.Seconds().chain.assert(t, failure)
+	value.Milliseconds().chain.assert(t, failure)
+
 	value.IsEqual(tm)
 	value.NotEqual(tm)
 	value.InRange(tm, tm)
@@ -66,6 +71,156 @@ func TestDuration_Alias(t *testing.T) {
 	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
 }
 
+func TestDuration_Round(t *testing.T) {
+	cases := []struct {
+		name  string
+		value time.Duration
+		unit  time.Duration
+		want  time.Duration
+	}{
+		{
+			name:  "round down",
+			value: 1234 * time.Millisecond,
+			unit:  100 * time.Millisecond,
+			want:  1200 * time.Millisecond,
+		},
+		{
+			name:  "round up",
+			value: 1250 * time.Millisecond,
+			unit:  100 * time.Millisecond,
+			want:  1300 * time.Millisecond,
+		},
+		{
+			name:  "negative",
+			value: -1250 * time.Millisecond,
+			unit:  100 * time.Millisecond,
+			want:  -1300 * time.Millisecond,
+		},
+		{
+			name:  "already rounded",
+			value: 2 * time.Second,
+			unit:  time.Second,
+			want:  2 * time.Second,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewDuration(reporter, tc.value)
+
+			rounded := value.Round(tc.unit)
+			rounded.chain.assert(t, success)
+
+			assert.Equal(t, tc.want, rounded.Raw())
+			assert.Equal(t, tc.value, value.Raw())
+
+			rounded.IsEqual(tc.want)
+			rounded.chain.assert(t, success)
+		})
+	}
+
+	t.Run("invalid unit", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewDuration(reporter, time.Second)
+
+		value.Round(0).chain.assert(t, failure)
+		value.chain.assert(t, failure)
+		value.chain.clear()
+
+		value.Round(-time.Second).chain.assert(t, failure)
+		value.chain.assert(t, failure)
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newDuration(chain, nil)
+
+		value.Round(time.Second).chain.assert(t, failure)
+		value.chain.assert(t, failure)
+	})
+}
+
+func TestDuration_Units(t *testing.T) {
+	cases := []struct {
+		name             string
+		value            time.Duration
+		wantSeconds      float64
+		wantMilliseconds float64
+	}{
+		{
+			name:             "zero",
+			value:            0,
+			wantSeconds:      0,
+			wantMilliseconds: 0,
+		},
+		{
+			name:             "whole",
+			value:            2 * time.Second,
+			wantSeconds:      2,
+			wantMilliseconds: 2000,
+		},
+		{
+			name:             "fractional",
+			value:            1500 * time.Microsecond,
+			wantSeconds:      0.0015,
+			wantMilliseconds: 1.5,
+		},
+		{
+			name:             "negative",
+			value:            -250 * time.Millisecond,
+			wantSeconds:      -0.25,
+			wantMilliseconds: -250,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewDuration(reporter, tc.value)
+
+			seconds := value.Seconds()
+			seconds.chain.assert(t, success)
+			assert.Equal(t, tc.wantSeconds, seconds.Raw())
+
+			milliseconds := value.Milliseconds()
+			milliseconds.chain.assert(t, success)
+			assert.Equal(t, tc.wantMilliseconds, milliseconds.Raw())
+
+			seconds.IsEqual(tc.wantSeconds)
+			milliseconds.IsEqual(tc.wantMilliseconds)
+			value.chain.assert(t, success)
+		})
+	}
+
+	t.Run("rounded", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewDuration(reporter, 1234*time.Millisecond)
+
+		value.Round(100 * time.Millisecond).Milliseconds().IsEqual(1200)
+		value.Round(time.Second).Seconds().IsEqual(1)
+		value.chain.assert(t, success)
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newDuration(chain, nil)
+
+		value.Seconds().chain.assert(t, failure)
+		value.chain.assert(t, failure)
+		value.chain.clear()
+
+		value.Milliseconds().chain.assert(t, failure)
+		value.chain.assert(t, failure)
+	})
+}
+
 func TestDuration_IsEqual(t *testing.T) {
 	reporter := newMockReporter(t)
 
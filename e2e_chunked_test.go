@@ -2,6 +2,8 @@ package httpexpect
 
 import (
 	"bufio"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -97,3 +99,63 @@ func TestE2EChunked_BinderFast(t *testing.T) {
 		},
 	}))
 }
+
+func createChunkedFileStreamHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TransferEncoding) != 1 || r.TransferEncoding[0] != "chunked" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if err := r.ParseMultipartForm(1024); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer r.MultipartForm.RemoveAll() //nolint
+
+		f, fh, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+
+		n, _ := io.Copy(io.Discard, f)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"key": %q, "filename": %q, "size": %d}`,
+			r.FormValue("key"), fh.Filename, n)
+	})
+
+	return mux
+}
+
+func TestE2EChunked_FileStream(t *testing.T) {
+	server := httptest.NewServer(createChunkedFileStreamHandler())
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+		Printers: []Printer{
+			NewDebugPrinter(t, true),
+		},
+	})
+
+	size := 1 << 20
+
+	e.POST("/").
+		WithMultipart().
+		WithFormField("key", "value").
+		WithFileStream("file", "data.bin", strings.NewReader(strings.Repeat("x", size))).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		IsEqual(map[string]interface{}{
+			"key":      "value",
+			"filename": "data.bin",
+			"size":     size,
+		})
+}
@@ -3,9 +3,12 @@ package httpexpect
 import (
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp/fasthttpadaptor"
 )
 
@@ -22,6 +25,13 @@ func createCookieHandler() http.Handler {
 		w.WriteHeader(http.StatusNoContent)
 	})
 
+	mux.HandleFunc("/list", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		for _, cookie := range r.Cookies() {
+			_, _ = w.Write([]byte(cookie.String() + ";"))
+		}
+	})
+
 	mux.HandleFunc("/get", func(w http.ResponseWriter, r *http.Request) {
 		cookie, err := r.Cookie("myname")
 		if err != nil {
@@ -144,3 +154,81 @@ func TestE2ECookie_BinderFastEnabled(t *testing.T) {
 
 	testCookieHandler(e, true)
 }
+
+func TestE2ECookie_ConfigJar(t *testing.T) {
+	handler := createCookieHandler()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	t.Run("default client", func(t *testing.T) {
+		jar := NewCookieJar()
+
+		e := WithConfig(Config{
+			BaseURL:   server.URL,
+			Reporter:  NewAssertReporter(t),
+			CookieJar: jar,
+		})
+
+		testCookieHandler(e, true)
+
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, len(jar.Cookies(u)))
+	})
+
+	t.Run("client without jar", func(t *testing.T) {
+		client := &http.Client{
+			Jar: nil,
+		}
+
+		e := WithConfig(Config{
+			BaseURL:   server.URL,
+			Reporter:  NewAssertReporter(t),
+			Client:    client,
+			CookieJar: NewCookieJar(),
+		})
+
+		testCookieHandler(e, true)
+
+		assert.Nil(t, client.Jar)
+	})
+}
+
+func TestE2ECookie_JarOverride(t *testing.T) {
+	handler := createCookieHandler()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:   server.URL,
+		Reporter:  NewAssertReporter(t),
+		CookieJar: NewCookieJar(),
+	})
+
+	e.PUT("/set").Expect().Status(http.StatusNoContent)
+
+	e.GET("/list").
+		Expect().
+		Status(http.StatusOK).
+		Text().IsEqual("myname=myvalue;")
+
+	e.GET("/list").
+		WithCookie("other", "value").
+		Expect().
+		Status(http.StatusOK).
+		Text().IsEqual("other=value;myname=myvalue;")
+
+	e.GET("/list").
+		WithCookie("myname", "override").
+		Expect().
+		Status(http.StatusOK).
+		Text().IsEqual("myname=override;")
+
+	e.GET("/list").
+		Expect().
+		Status(http.StatusOK).
+		Text().IsEqual("myname=myvalue;")
+}
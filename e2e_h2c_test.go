@@ -0,0 +1,101 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func createH2CHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/proto", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	})
+
+	return h2c.NewHandler(mux, &http2.Server{})
+}
+
+func TestE2EH2C_Live(t *testing.T) {
+	server := httptest.NewServer(createH2CHandler())
+	defer server.Close()
+
+	t.Run("h2c client", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Client:   NewH2CClient(),
+			Reporter: NewAssertReporter(t),
+		})
+
+		resp := e.GET("/proto").
+			Expect().
+			Status(http.StatusOK).
+			HTTPVersion(2, 0)
+
+		resp.Proto().IsEqual("HTTP/2.0")
+		resp.Body().IsEqual("HTTP/2.0")
+	})
+
+	t.Run("default client", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: NewAssertReporter(t),
+		})
+
+		resp := e.GET("/proto").
+			Expect().
+			Status(http.StatusOK).
+			HTTPVersion(1, 1)
+
+		resp.Proto().IsEqual("HTTP/1.1")
+		resp.Body().IsEqual("HTTP/1.1")
+	})
+}
+
+func TestE2EH2C_Fallback(t *testing.T) {
+	t.Run("websocket through h2c server", func(t *testing.T) {
+		server := httptest.NewServer(
+			h2c.NewHandler(createWebsocketHandler(wsHandlerOpts{}), &http2.Server{}))
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Client:   NewH2CClient(),
+			Reporter: NewAssertReporter(t),
+		})
+
+		testWebsocket(e)
+	})
+
+	t.Run("websocket through http/1.1 server", func(t *testing.T) {
+		server := httptest.NewServer(createWebsocketHandler(wsHandlerOpts{}))
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Client:   NewH2CClient(),
+			Reporter: NewAssertReporter(t),
+		})
+
+		testWebsocket(e)
+	})
+
+	t.Run("http request to http/1.1 server", func(t *testing.T) {
+		server := httptest.NewServer(createWebsocketHandler(wsHandlerOpts{}))
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:          server.URL,
+			Client:           NewH2CClient(),
+			AssertionHandler: &mockAssertionHandler{},
+		})
+
+		// h2c client uses prior knowledge and never falls back to HTTP/1.1
+		e.GET("/empty").
+			Expect().
+			chain.assertFailed(t)
+	})
+}
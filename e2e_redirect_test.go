@@ -161,7 +161,9 @@ func testRedirects(t *testing.T, createFn func(Reporter) *Expect) {
 
 		e.POST("/redirect308").
 			WithMaxRedirects(0).
-			Expect().chain.assertFailed(t)
+			Expect().
+			Status(http.StatusPermanentRedirect).
+			chain.assertNotFailed(t)
 
 		e.POST("/content").
 			WithMaxRedirects(0).
@@ -9,6 +9,7 @@ import (
 	"text/template"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type recordingReporter struct {
@@ -414,3 +415,110 @@ func TestE2EReport_RequestResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestE2EReport_SoftAssertions(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"foo":123}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	handler := &mockAssertionHandler{}
+
+	e := WithConfig(Config{
+		BaseURL:          server.URL,
+		AssertionHandler: handler,
+		SoftAssertions:   true,
+	})
+
+	resp := e.GET("/test").
+		Expect()
+
+	resp.Status(http.StatusTeapot)                    // will fail
+	resp.Header("Content-Type").IsEqual("text/plain") // will fail
+	resp.JSON().Object().Value("foo").IsEqual(456)    // will fail
+	resp.JSON().Object().Value("foo").IsEqual(123)
+
+	assert.Equal(t, 3, handler.failureCount)
+}
+
+func TestE2EReport_SoftAssertionsFlush(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"foo":123}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Run("test end", func(t *testing.T) {
+		backend := &mockCleanupT{}
+
+		e := WithConfig(Config{
+			BaseURL:        server.URL,
+			Reporter:       NewAssertReporter(backend),
+			SoftAssertions: true,
+		})
+
+		resp := e.GET("/test").
+			Expect()
+
+		resp.Status(http.StatusTeapot)                    // will fail
+		resp.Header("Content-Type").IsEqual("text/plain") // will fail
+		resp.JSON().Object().Value("foo").IsEqual(123)
+
+		assert.Empty(t, backend.messages)
+
+		backend.runCleanups()
+
+		require.Equal(t, 1, len(backend.messages))
+		assert.Contains(t, backend.messages[0], "2 assertions failed")
+		assert.Contains(t, backend.messages[0], "418")
+		assert.Contains(t, backend.messages[0], "text/plain")
+	})
+
+	t.Run("explicit flush", func(t *testing.T) {
+		backend := &mockCleanupT{}
+
+		e := WithConfig(Config{
+			BaseURL:        server.URL,
+			Reporter:       backend,
+			SoftAssertions: true,
+		})
+
+		e.GET("/test").
+			Expect().
+			Status(http.StatusTeapot)
+
+		assert.Empty(t, backend.messages)
+
+		e.Flush()
+		assert.Equal(t, 1, len(backend.messages))
+
+		backend.runCleanups()
+		assert.Equal(t, 1, len(backend.messages))
+	})
+
+	t.Run("no cleanup", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:        server.URL,
+			Reporter:       reporter,
+			SoftAssertions: true,
+		})
+
+		e.GET("/test").
+			Expect().
+			Status(http.StatusTeapot)
+
+		// Should report immediately
+		assert.True(t, reporter.reported)
+	})
+}
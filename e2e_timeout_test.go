@@ -1,6 +1,8 @@
 package httpexpect
 
 import (
+	"context"
+	"errors"
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
@@ -8,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
@@ -70,6 +73,140 @@ func TestE2ETimeout_DeadlineExpired(t *testing.T) {
 	assert.True(t, r.reported)
 }
 
+func TestE2ETimeout_ConfigTimeout(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	handler := createTimeoutHandler()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	t.Run("deadline expired", func(t *testing.T) {
+		assertionHandler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          server.URL,
+			Timeout:          10 * time.Millisecond,
+			AssertionHandler: assertionHandler,
+		})
+
+		e.GET("/sleep").
+			Expect().
+			chain.assertFailed(t)
+
+		require.NotNil(t, assertionHandler.failure)
+		assert.Contains(t, assertionHandler.failure.Errors,
+			errors.New("request exceeded timeout of 10ms set by Config.Timeout"))
+	})
+
+	t.Run("request timeout fired", func(t *testing.T) {
+		assertionHandler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          server.URL,
+			Timeout:          time.Minute,
+			AssertionHandler: assertionHandler,
+		})
+
+		e.GET("/sleep").
+			WithTimeout(10 * time.Millisecond).
+			Expect().
+			chain.assertFailed(t)
+
+		require.NotNil(t, assertionHandler.failure)
+		assert.Contains(t, assertionHandler.failure.Errors,
+			errors.New("request exceeded timeout of 10ms set by WithTimeout()"))
+	})
+
+	t.Run("context deadline fired", func(t *testing.T) {
+		assertionHandler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          server.URL,
+			Timeout:          time.Minute,
+			AssertionHandler: assertionHandler,
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		e.GET("/sleep").
+			WithContext(ctx).
+			Expect().
+			chain.assertFailed(t)
+
+		require.NotNil(t, assertionHandler.failure)
+		for _, err := range assertionHandler.failure.Errors {
+			assert.NotContains(t, err.Error(), "request exceeded")
+		}
+	})
+
+	t.Run("overridden by request", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Timeout:  10 * time.Millisecond,
+			Reporter: NewAssertReporter(t),
+		})
+
+		e.GET("/small").
+			WithTimeout(20 * time.Minute).
+			Expect().
+			Status(http.StatusOK)
+	})
+}
+
+func TestE2ETimeout_Deadline(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	handler := createTimeoutHandler()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	t.Run("deadline fired", func(t *testing.T) {
+		assertionHandler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          server.URL,
+			Timeout:          time.Minute,
+			AssertionHandler: assertionHandler,
+		})
+
+		e.GET("/sleep").
+			WithDeadline(time.Now().Add(10 * time.Millisecond)).
+			Expect().
+			chain.assertFailed(t)
+
+		require.NotNil(t, assertionHandler.failure)
+		require.True(t, len(assertionHandler.failure.Errors) > 1)
+		assert.Contains(t, assertionHandler.failure.Errors[1].Error(),
+			"request exceeded configured deadline")
+	})
+
+	t.Run("timeout fired", func(t *testing.T) {
+		assertionHandler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          server.URL,
+			Timeout:          10 * time.Millisecond,
+			AssertionHandler: assertionHandler,
+		})
+
+		e.GET("/sleep").
+			WithDeadline(time.Now().Add(time.Minute)).
+			Expect().
+			chain.assertFailed(t)
+
+		require.NotNil(t, assertionHandler.failure)
+		assert.Contains(t, assertionHandler.failure.Errors,
+			errors.New("request exceeded timeout of 10ms set by Config.Timeout"))
+	})
+}
+
 func TestE2ETimeout_SmallBody(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -1,13 +1,21 @@
 package httpexpect
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 )
 
@@ -138,3 +146,191 @@ func TestE2EAutoTLS_BinderFast(t *testing.T) {
 		})
 	}
 }
+
+func createClientCert(t *testing.T, name string) (tls.Certificate, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, leaf
+}
+
+func TestE2EMutualTLS(t *testing.T) {
+	cert, leaf := createClientCert(t, "client")
+	wrongCert, _ := createClientCert(t, "wrong")
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+
+	tracker := &connTracker{}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+		}))
+
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	server.Config.ConnState = tracker.connState
+
+	server.StartTLS()
+	defer server.Close()
+
+	t.Run("request cert", func(t *testing.T) {
+		client := server.Client()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+
+		e.GET("/").
+			WithTLSClientCert(cert).
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("client").
+			chain.assertNotFailed(t)
+
+		// Should not modify original client
+		assert.Nil(t, client.Transport.(*http.Transport).TLSClientConfig.Certificates)
+
+		e.GET("/").
+			Expect().
+			chain.assertFailed(t)
+	})
+
+	t.Run("config cert", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:       server.URL,
+			Client:        server.Client(),
+			TLSClientCert: &cert,
+			Reporter:      newMockReporter(t),
+		})
+
+		e.GET("/").
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("client").
+			chain.assertNotFailed(t)
+	})
+
+	t.Run("config cert connection reuse", func(t *testing.T) {
+		client := server.Client()
+
+		e := WithConfig(Config{
+			BaseURL:       server.URL,
+			Client:        client,
+			TLSClientCert: &cert,
+			Reporter:      newMockReporter(t),
+		})
+
+		openedBefore, _ := tracker.counts()
+
+		for i := 0; i < 3; i++ {
+			e.GET("/").
+				Expect().
+				Status(http.StatusOK).
+				Body().IsEqual("client").
+				chain.assertNotFailed(t)
+		}
+
+		// Should reuse single connection
+		openedAfter, _ := tracker.counts()
+		assert.Equal(t, 1, openedAfter-openedBefore)
+
+		// Should not modify original client
+		assert.Nil(t, client.Transport.(*http.Transport).TLSClientConfig.Certificates)
+	})
+
+	t.Run("request cert closes connections", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Client:   server.Client(),
+			Reporter: newMockReporter(t),
+		})
+
+		openedBefore, closedBefore := tracker.counts()
+
+		e.GET("/").
+			WithTLSClientCert(cert).
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("client").
+			chain.assertNotFailed(t)
+
+		// Should close connection of transport copy
+		assert.Eventually(t, func() bool {
+			opened, closed := tracker.counts()
+			return opened-openedBefore == 1 && closed-closedBefore >= 1
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("request cert overrides config cert", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:       server.URL,
+			Client:        server.Client(),
+			TLSClientCert: &wrongCert,
+			Reporter:      newMockReporter(t),
+		})
+
+		e.GET("/").
+			Expect().
+			chain.assertFailed(t)
+
+		e.GET("/").
+			WithTLSClientCert(cert).
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("client").
+			chain.assertNotFailed(t)
+	})
+
+	t.Run("with client", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
+
+		e.GET("/").
+			WithClient(server.Client()).
+			WithTLSClientCert(cert).
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("client").
+			chain.assertNotFailed(t)
+	})
+
+	t.Run("wrong cert", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Client:   server.Client(),
+			Reporter: newMockReporter(t),
+		})
+
+		e.GET("/").
+			WithTLSClientCert(wrongCert).
+			Expect().
+			chain.assertFailed(t)
+	})
+}
@@ -0,0 +1,65 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestE2ETrace_Live(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("hello"))
+		}))
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Client:   server.Client(),
+		Reporter: NewAssertReporter(t),
+	})
+
+	t.Run("new connection", func(t *testing.T) {
+		resp := e.GET("/").
+			WithTrace().
+			Expect().
+			Status(http.StatusOK)
+
+		resp.Body().IsEqual("hello")
+
+		trace := resp.Trace()
+
+		trace.ConnReused().IsFalse()
+		trace.Connect().IsSet().Lt(time.Minute)
+		trace.TLSHandshake().IsSet().Lt(time.Minute)
+		trace.FirstByte().IsSet().Lt(time.Minute)
+	})
+
+	t.Run("reused connection", func(t *testing.T) {
+		resp := e.GET("/").
+			WithTrace().
+			Expect().
+			Status(http.StatusOK)
+
+		resp.Body().IsEqual("hello")
+
+		trace := resp.Trace()
+
+		trace.ConnReused().IsTrue()
+		trace.DNS().NotSet()
+		trace.Connect().NotSet()
+		trace.TLSHandshake().NotSet()
+		trace.FirstByte().IsSet().Lt(time.Minute)
+	})
+
+	t.Run("with timeout", func(t *testing.T) {
+		resp := e.GET("/").
+			WithTrace().
+			WithTimeout(time.Minute).
+			Expect().
+			Status(http.StatusOK)
+
+		resp.Trace().FirstByte().IsSet()
+	})
+}
@@ -0,0 +1,70 @@
+package httpexpect
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func createTrailerHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		w.Header().Set("Trailer", "Echo-Checksum")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+
+		w.Header().Set("Echo-Checksum", r.Trailer.Get("Checksum"))
+	})
+
+	return mux
+}
+
+func testTrailerHandler(t *testing.T, e *Expect) {
+	resp := e.POST("/echo").
+		WithText("payload").
+		WithTrailer("Checksum", "abcdef").
+		Expect().
+		Status(http.StatusOK)
+
+	resp.Trailer("Echo-Checksum").IsEqual("abcdef")
+	resp.Body().IsEqual("payload")
+	resp.chain.assertNotFailed(t)
+
+	resp = e.POST("/echo").
+		WithText("payload").
+		Expect().
+		Status(http.StatusOK)
+
+	resp.Trailer("Echo-Checksum").IsEmpty()
+	resp.chain.assertNotFailed(t)
+
+	resp.Trailer("Missing").chain.assertFailed(t)
+}
+
+func TestE2ETrailer_Live(t *testing.T) {
+	handler := createTrailerHandler()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	testTrailerHandler(t, WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: newMockReporter(t),
+	}))
+}
+
+func TestE2ETrailer_Binder(t *testing.T) {
+	handler := createTrailerHandler()
+
+	testTrailerHandler(t, WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	}))
+}
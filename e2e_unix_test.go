@@ -0,0 +1,203 @@
+package httpexpect
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createUnixSocketServer(t *testing.T, handler http.Handler) (string, func()) {
+	return createUnixSocketServerWithTracker(t, handler, nil)
+}
+
+func createUnixSocketServerWithTracker(
+	t *testing.T, handler http.Handler, tracker *connTracker,
+) (string, func()) {
+	path := filepath.Join(t.TempDir(), "test.sock")
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("unix sockets are not supported: %v", err)
+	}
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = listener
+	if tracker != nil {
+		server.Config.ConnState = tracker.connState
+	}
+	server.Start()
+
+	return path, server.Close
+}
+
+func TestE2EUnixSocket_HTTP(t *testing.T) {
+	var hosts []string
+
+	path, closeFn := createUnixSocketServer(t, http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			hosts = append(hosts, r.Host)
+			_, _ = w.Write([]byte(r.URL.Path))
+		}))
+	defer closeFn()
+
+	client := &http.Client{}
+
+	e := WithConfig(Config{
+		BaseURL:  "http://admin.example.invalid",
+		Client:   client,
+		Reporter: newMockReporter(t),
+	})
+
+	e.GET("/metrics").
+		WithUnixSocket(path).
+		Expect().
+		Status(http.StatusOK).
+		Body().IsEqual("/metrics")
+
+	e.POST("/reload").
+		WithUnixSocket(path).
+		WithHost("other.example.invalid").
+		WithText("test").
+		Expect().
+		Status(http.StatusOK).
+		Body().IsEqual("/reload")
+
+	assert.Equal(t,
+		[]string{"admin.example.invalid", "other.example.invalid"}, hosts)
+
+	// Should not modify original client
+	assert.Nil(t, client.Transport)
+}
+
+func TestE2EUnixSocket_Connections(t *testing.T) {
+	tracker := &connTracker{}
+
+	path, closeFn := createUnixSocketServerWithTracker(t, http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}), tracker)
+	defer closeFn()
+
+	e := WithConfig(Config{
+		BaseURL:  "http://admin.example.invalid",
+		Reporter: newMockReporter(t),
+	})
+
+	for i := 0; i < 2; i++ {
+		e.GET("/metrics").
+			WithUnixSocket(path).
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("ok")
+	}
+
+	// Should close connections of transport copies
+	tracker.assertClosed(t, 2)
+}
+
+func TestE2EUnixSocket_Websocket(t *testing.T) {
+	path, closeFn := createUnixSocketServer(t,
+		createWebsocketHandler(wsHandlerOpts{}))
+	defer closeFn()
+
+	e := WithConfig(Config{
+		BaseURL:  "http://admin.example.invalid",
+		Reporter: newMockReporter(t),
+	})
+
+	ws := e.GET("/test").
+		WithUnixSocket(path).
+		WithWebsocketUpgrade().
+		Expect().
+		Status(http.StatusSwitchingProtocols).
+		Websocket()
+	defer ws.Disconnect()
+
+	ws.WriteText("hi").
+		Expect().
+		TextMessage().
+		Body().IsEqual("hi")
+
+	ws.chain.assertNotFailed(t)
+}
+
+func TestE2EUnixSocket_Errors(t *testing.T) {
+	t.Run("socket does not exist", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          "http://admin.example.invalid",
+			AssertionHandler: handler,
+		})
+
+		path := filepath.Join(t.TempDir(), "missing.sock")
+
+		resp := e.GET("/metrics").
+			WithUnixSocket(path).
+			Expect()
+
+		resp.chain.assertFailed(t)
+
+		if assert.NotNil(t, handler.failure) {
+			assert.Equal(t, AssertOperation, handler.failure.Type)
+			assert.Contains(t, handler.failure.Errors[0].Error(), "missing.sock")
+		}
+	})
+
+	t.Run("not a socket", func(t *testing.T) {
+		dir := t.TempDir()
+
+		file := filepath.Join(dir, "regular.sock")
+		require.NoError(t, os.WriteFile(file, []byte("test"), 0o600))
+
+		for _, path := range []string{file, dir} {
+			handler := &mockAssertionHandler{}
+
+			e := WithConfig(Config{
+				BaseURL:          "http://admin.example.invalid",
+				AssertionHandler: handler,
+			})
+
+			resp := e.GET("/metrics").
+				WithUnixSocket(path).
+				Expect()
+
+			resp.chain.assertFailed(t)
+
+			if assert.NotNil(t, handler.failure) {
+				assert.Equal(t, AssertOperation, handler.failure.Type)
+				assert.Contains(t, handler.failure.Errors[0].Error(),
+					"is not a unix socket")
+			}
+		}
+	})
+
+	t.Run("combined with proxy", func(t *testing.T) {
+		path, closeFn := createUnixSocketServer(t, http.NotFoundHandler())
+		defer closeFn()
+
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          "http://admin.example.invalid",
+			AssertionHandler: handler,
+		})
+
+		resp := e.GET("/metrics").
+			WithUnixSocket(path).
+			WithProxy("http://127.0.0.1:3128").
+			Expect()
+
+		resp.chain.assertFailed(t)
+
+		if assert.NotNil(t, handler.failure) {
+			assert.Equal(t, AssertUsage, handler.failure.Type)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,6 +9,8 @@ import (
 
 	fastwebsocket "github.com/fasthttp/websocket"
 	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 )
 
@@ -330,6 +333,64 @@ func TestE2EWebsocket_Timeouts(t *testing.T) {
 	})
 }
 
+func TestE2EWebsocket_ConfigTimeout(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Run("connection outlives timeout", func(t *testing.T) {
+		handler := createWebsocketHandler(wsHandlerOpts{})
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Timeout:  50 * time.Millisecond,
+			Reporter: NewAssertReporter(t),
+		})
+
+		ws := e.GET("/test").WithWebsocketUpgrade().
+			Expect().
+			Status(http.StatusSwitchingProtocols).
+			Websocket()
+		defer ws.Disconnect()
+
+		time.Sleep(100 * time.Millisecond)
+
+		ws.WriteText("hi").
+			Expect().
+			TextMessage().Body().IsEqual("hi")
+	})
+
+	t.Run("handshake exceeds timeout", func(t *testing.T) {
+		mux := http.NewServeMux()
+
+		mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(time.Second)
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		assertionHandler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          server.URL,
+			Timeout:          10 * time.Millisecond,
+			AssertionHandler: assertionHandler,
+		})
+
+		e.GET("/slow").WithWebsocketUpgrade().
+			Expect().
+			chain.assertFailed(t)
+
+		require.NotNil(t, assertionHandler.failure)
+		assert.Contains(t, assertionHandler.failure.Errors,
+			errors.New("request exceeded timeout of 10ms set by Config.Timeout"))
+	})
+}
+
 func TestE2EWebsocket_Closed(t *testing.T) {
 	t.Run("close-write", func(t *testing.T) {
 		handler := createWebsocketHandler(wsHandlerOpts{})
@@ -380,6 +441,199 @@ func TestE2EWebsocket_Closed(t *testing.T) {
 	})
 }
 
+func TestE2EWebsocket_CloseWithCode(t *testing.T) {
+	t.Run("acknowledged", func(t *testing.T) {
+		handler := createWebsocketHandler(wsHandlerOpts{})
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: NewAssertReporter(t),
+		})
+
+		ws := e.GET("/test").WithWebsocketUpgrade().
+			Expect().
+			Status(http.StatusSwitchingProtocols).
+			Websocket()
+		defer ws.Disconnect()
+
+		ws.WithReadTimeout(time.Second)
+		ws.CloseWithCode(websocket.CloseGoingAway, "bye")
+		ws.chain.assertNotFailed(t)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				upgrader := &websocket.Upgrader{}
+
+				c, err := upgrader.Upgrade(w, r, nil)
+				if err != nil {
+					panic(err)
+				}
+				defer c.Close()
+
+				c.SetCloseHandler(func(int, string) error {
+					return c.WriteMessage(websocket.CloseMessage,
+						websocket.FormatCloseMessage(
+							websocket.CloseInternalServerErr, ""))
+				})
+
+				for {
+					if _, _, err := c.ReadMessage(); err != nil {
+						break
+					}
+				}
+			}))
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
+
+		ws := e.GET("/").WithWebsocketUpgrade().
+			Expect().
+			Status(http.StatusSwitchingProtocols).
+			Websocket()
+		defer ws.Disconnect()
+
+		ws.WithReadTimeout(time.Second)
+		ws.CloseWithCode(websocket.CloseGoingAway, "bye")
+		ws.chain.assertFailed(t)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		done := make(chan struct{})
+
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				upgrader := &websocket.Upgrader{}
+
+				c, err := upgrader.Upgrade(w, r, nil)
+				if err != nil {
+					panic(err)
+				}
+				defer c.Close()
+
+				<-done
+			}))
+		defer server.Close()
+		defer close(done)
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
+
+		ws := e.GET("/").WithWebsocketUpgrade().
+			Expect().
+			Status(http.StatusSwitchingProtocols).
+			Websocket()
+		defer ws.Disconnect()
+
+		ws.WithReadTimeout(time.Millisecond * 100)
+		ws.CloseWithCode(websocket.CloseGoingAway, "bye")
+		ws.chain.assertFailed(t)
+	})
+}
+
+func TestE2EWebsocket_Subprotocols(t *testing.T) {
+	newServer := func(upgrader *websocket.Upgrader, respHeader http.Header) (
+		*httptest.Server, *[]string,
+	) {
+		var advertised []string
+
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				advertised = websocket.Subprotocols(r)
+
+				c, err := upgrader.Upgrade(w, r, respHeader)
+				if err != nil {
+					panic(err)
+				}
+				defer c.Close()
+
+				for {
+					if _, _, err := c.ReadMessage(); err != nil {
+						break
+					}
+				}
+			}))
+
+		return server, &advertised
+	}
+
+	t.Run("negotiated", func(t *testing.T) {
+		server, advertised := newServer(&websocket.Upgrader{
+			Subprotocols: []string{"v3", "v2", "v1"},
+		}, nil)
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
+
+		ws := e.GET("/").
+			WithWebsocketUpgrade().
+			WithWebsocketSubprotocols("v1", "v2").
+			Expect().
+			Status(http.StatusSwitchingProtocols).
+			Websocket()
+		defer ws.Disconnect()
+
+		ws.Subprotocol().IsEqual("v2")
+		ws.chain.assertNotFailed(t)
+
+		assert.Equal(t, []string{"v1", "v2"}, *advertised)
+	})
+
+	t.Run("not selected", func(t *testing.T) {
+		server, advertised := newServer(&websocket.Upgrader{}, nil)
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
+
+		ws := e.GET("/").
+			WithWebsocketUpgrade().
+			WithWebsocketSubprotocols("v1").
+			Expect().
+			Status(http.StatusSwitchingProtocols).
+			Websocket()
+		defer ws.Disconnect()
+
+		ws.Subprotocol().IsEmpty()
+		ws.chain.assertNotFailed(t)
+
+		assert.Equal(t, []string{"v1"}, *advertised)
+	})
+
+	t.Run("not advertised", func(t *testing.T) {
+		server, _ := newServer(&websocket.Upgrader{}, http.Header{
+			"Sec-Websocket-Protocol": []string{"v3"},
+		})
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
+
+		resp := e.GET("/").
+			WithWebsocketUpgrade().
+			WithWebsocketSubprotocols("v1", "v2").
+			Expect()
+
+		resp.chain.assertFailed(t)
+	})
+}
+
 func TestE2EWebsocket_Disconnected(t *testing.T) {
 	t.Run("disconnect-write", func(t *testing.T) {
 		handler := createWebsocketHandler(wsHandlerOpts{})
@@ -79,8 +79,13 @@ package httpexpect
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -118,6 +123,28 @@ type Config struct {
 	//
 	// You can use DefaultRequestFactory, or provide custom implementation.
 	// Useful for Google App Engine testing for example.
+	//
+	// Factory is invoked once per Request, when the Request is constructed,
+	// with request method, BaseURL, and nil body. Path, query, headers, body,
+	// and other parameters are applied to the returned http.Request later by
+	// Request methods, so factory may pre-populate it, e.g. add headers or
+	// attach context.
+	//
+	// If factory returns error or nil request, failure is reported, including
+	// method and URL that were passed to factory.
+	//
+	// You can wrap DefaultRequestFactory using RequestFactoryFunc, e.g. to
+	// inject correlation ID into every request:
+	//
+	//	RequestFactory: httpexpect.RequestFactoryFunc(
+	//		func(method, url string, body io.Reader) (*http.Request, error) {
+	//			req, err := httpexpect.DefaultRequestFactory{}.NewRequest(
+	//				method, url, body)
+	//			if err == nil {
+	//				req.Header.Set("X-Correlation-Id", newID())
+	//			}
+	//			return req, err
+	//		}),
 	RequestFactory RequestFactory
 
 	// Client is used to send http.Request and receive http.Response.
@@ -132,6 +159,33 @@ type Config struct {
 	// custom implementation.
 	Client Client
 
+	// CookieJar is used to store cookies from responses and send them with
+	// subsequent requests to the same host.
+	// May be nil.
+	//
+	// If non-nil, it is set as Jar of Client, which should be *http.Client
+	// (or nil, in which case a default client is created).
+	// If nil, Client's own Jar is used as is.
+	//
+	// Cookies added to a request via Request.WithCookie or Request.WithCookies
+	// are sent together with cookies from the jar. If the jar has a cookie
+	// with the same name, the cookie set on the request takes precedence
+	// and the jar cookie is not sent.
+	CookieJar http.CookieJar
+
+	// TLSClientCert is a client certificate used for mutual TLS.
+	// May be nil.
+	//
+	// If non-nil, every request is sent with this certificate, as if
+	// Request.WithTLSClientCert was called. Request.WithTLSClientCert
+	// overrides it. Client should be *http.Client with nil Transport or
+	// Transport of type *http.Transport.
+	//
+	// When Expect instance is constructed, Client and WebsocketDialer are
+	// copied once and the certificate is installed into the copies, so that
+	// requests share the same transport and reuse connections.
+	TLSClientCert *tls.Certificate
+
 	// WebsocketDialer is used to establish websocket.Conn and receive http.Response
 	// of handshake result.
 	// May be nil.
@@ -151,6 +205,31 @@ type Config struct {
 	// for per-request timeout.
 	Context context.Context
 
+	// Timeout is a default timeout for every request.
+	// May be zero.
+	//
+	// If non-zero, every request is sent with a context deadline, as if
+	// Request.WithTimeout was called. Request.WithTimeout overrides it.
+	//
+	// For websocket requests, the timeout applies only to the handshake
+	// (when WebsocketDialer is *websocket.Dialer). The established
+	// connection is not affected.
+	Timeout time.Duration
+
+	// Rand is the source of randomness used by httpexpect internally,
+	// e.g. to generate multipart boundaries. May be nil.
+	//
+	// If nil, a shared source seeded with current time is used. Set it to
+	// a source with fixed seed to make requests reproducible, e.g. to
+	// re-run a failed CI job exactly:
+	//
+	//	rand.New(rand.NewSource(seed))
+	//
+	// httpexpect serializes access to Rand, so it can be shared between
+	// concurrent requests, but it should not be used elsewhere at the
+	// same time.
+	Rand *rand.Rand
+
 	// Reporter is used to report formatted failure messages.
 	// Should NOT be nil, unless custom AssertionHandler is used.
 	//
@@ -199,6 +278,41 @@ type Config struct {
 	// set Reporter. Use AssertionHandler for more precise control of reports.
 	AssertionHandler AssertionHandler
 
+	// AssertionHandlers is a list of handlers for successful and failed assertions.
+	// May be nil.
+	//
+	// If non-empty, it takes precedence over AssertionHandler. Every assertion
+	// is passed to all handlers in the order in which they are listed. This
+	// allows, for example, to combine DefaultAssertionHandler, which reports
+	// failures to the test suite, with a handler that collects metrics.
+	//
+	// If one of handlers stops the test on failure, e.g. because it uses
+	// RequireReporter, handlers that follow it are not invoked for that
+	// failure. Such handlers should be placed last.
+	AssertionHandlers []AssertionHandler
+
+	// SoftAssertions disables fail-fast behavior for assertions.
+	// Default is false.
+	//
+	// By default, after first failed assertion on a matcher (Response, Object,
+	// String, etc.), all subsequent assertions on the same matcher are skipped.
+	// If SoftAssertions is true, failed checks (comparisons, containment,
+	// range checks, etc.) don't prevent subsequent assertions on the same
+	// matcher, so all of them are executed and all failures are reported.
+	//
+	// Failures that make further checks meaningless still stop the chain:
+	// usage errors, failed operations (e.g. network errors), invalid or
+	// unexpected type of the value, and nil values. Matchers returned from
+	// a failed assertion (e.g. Value of absent key) are always failed.
+	//
+	// If AssertionHandler is not set and Reporter is testing.TB, or one of
+	// AssertReporter, RequireReporter, FatalReporter constructed from it,
+	// failures are collected by Expect instance and passed to Reporter as a
+	// single consolidated report at the end of the test, or when Expect.Flush
+	// is called. Otherwise, every failure is passed to AssertionHandler as
+	// soon as it happens.
+	SoftAssertions bool
+
 	// Printers are used to print requests and responses.
 	// May be nil.
 	//
@@ -222,6 +336,10 @@ type Config struct {
 	// If Environment is nil, a new empty environment is automatically created
 	// when Expect instance is constructed.
 	Environment *Environment
+
+	// Client and WebsocketDialer with TLSClientCert already installed.
+	tlsCertClient *http.Client
+	tlsCertDialer *websocket.Dialer
 }
 
 func (config Config) withDefaults() Config {
@@ -230,8 +348,18 @@ func (config Config) withDefaults() Config {
 	}
 
 	if config.Client == nil {
+		jar := config.CookieJar
+		if jar == nil {
+			jar = NewCookieJar()
+		}
 		config.Client = &http.Client{
-			Jar: NewCookieJar(),
+			Jar: jar,
+		}
+	} else if config.CookieJar != nil {
+		if client, ok := config.Client.(*http.Client); ok && client.Jar != config.CookieJar {
+			clientCopy := *client
+			clientCopy.Jar = config.CookieJar
+			config.Client = &clientCopy
 		}
 	}
 
@@ -239,6 +367,11 @@ func (config Config) withDefaults() Config {
 		config.WebsocketDialer = &websocket.Dialer{}
 	}
 
+	if len(config.AssertionHandlers) != 0 {
+		config.AssertionHandler = assertionHandlerList(
+			append([]AssertionHandler(nil), config.AssertionHandlers...))
+	}
+
 	if config.AssertionHandler == nil {
 		if config.Formatter == nil {
 			config.Formatter = &DefaultFormatter{}
@@ -257,6 +390,68 @@ func (config Config) withDefaults() Config {
 	return config
 }
 
+// withSoftReporter wraps Reporter to collect failures, if SoftAssertions is
+// enabled and Reporter is used by default AssertionHandler.
+func (config Config) withSoftReporter() Config {
+	if !config.SoftAssertions || config.Reporter == nil ||
+		config.AssertionHandler != nil || len(config.AssertionHandlers) != 0 {
+		return config
+	}
+
+	if _, ok := config.Reporter.(*softReporter); ok {
+		return config
+	}
+
+	if reporter := newSoftReporter(config.Reporter); reporter != nil {
+		config.Reporter = reporter
+	}
+
+	return config
+}
+
+// withClientCert installs TLSClientCert into copies of Client and WebsocketDialer,
+// if they're compatible. Otherwise, the certificate is installed by every request
+// into its own copy.
+func (config Config) withClientCert() Config {
+	if config.TLSClientCert == nil {
+		return config
+	}
+
+	if client, ok := config.Client.(*http.Client); ok && client != config.tlsCertClient {
+		var transport *http.Transport
+
+		switch tp := client.Transport.(type) {
+		case nil:
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		case *http.Transport:
+			transport = tp.Clone()
+		}
+
+		if transport != nil {
+			transport.TLSClientConfig = withClientCert(
+				transport.TLSClientConfig, *config.TLSClientCert)
+
+			clientCopy := *client
+			clientCopy.Transport = transport
+
+			config.Client = &clientCopy
+			config.tlsCertClient = &clientCopy
+		}
+	}
+
+	if dialer, ok := config.WebsocketDialer.(*websocket.Dialer); ok &&
+		dialer != config.tlsCertDialer {
+		dialerCopy := *dialer
+		dialerCopy.TLSClientConfig = withClientCert(
+			dialerCopy.TLSClientConfig, *config.TLSClientCert)
+
+		config.WebsocketDialer = &dialerCopy
+		config.tlsCertDialer = &dialerCopy
+	}
+
+	return config
+}
+
 func (config *Config) validate() {
 	if config.RequestFactory == nil {
 		panic("Config.RequestFactory is nil")
@@ -270,13 +465,35 @@ func (config *Config) validate() {
 		panic("Config.AssertionHandler is nil")
 	}
 
-	if handler, ok := config.AssertionHandler.(*DefaultAssertionHandler); ok {
-		if handler.Formatter == nil {
-			panic("DefaultAssertionHandler.Formatter is nil")
+	if config.Timeout < 0 {
+		panic("Config.Timeout is negative")
+	}
+
+	if config.CookieJar != nil {
+		if _, ok := config.Client.(*http.Client); !ok {
+			panic("Config.CookieJar can be used only if Config.Client is *http.Client")
 		}
+	}
+
+	handlers := []AssertionHandler{config.AssertionHandler}
+
+	if len(config.AssertionHandlers) != 0 {
+		handlers = config.AssertionHandlers
+	}
+
+	for n, handler := range handlers {
+		if handler == nil {
+			panic(fmt.Sprintf("Config.AssertionHandlers[%d] is nil", n))
+		}
+
+		if handler, ok := handler.(*DefaultAssertionHandler); ok {
+			if handler.Formatter == nil {
+				panic("DefaultAssertionHandler.Formatter is nil")
+			}
 
-		if handler.Reporter == nil {
-			panic("DefaultAssertionHandler.Reporter is nil")
+			if handler.Reporter == nil {
+				panic("DefaultAssertionHandler.Reporter is nil")
+			}
 		}
 	}
 }
@@ -293,7 +510,7 @@ type RequestFactory interface {
 // Example:
 //
 //	e := httpexpect.WithConfig(httpexpect.Config{
-//		RequestFactory: httpextect.RequestFactoryFunc(
+//		RequestFactory: httpexpect.RequestFactoryFunc(
 //			func(method string, url string, body io.Reader) (*http.Request, error) {
 //				// factory code here
 //			}),
@@ -302,6 +519,7 @@ type RequestFactoryFunc func(
 	method string, url string, body io.Reader,
 ) (*http.Request, error)
 
+// NewRequest implements RequestFactory.NewRequest.
 func (f RequestFactoryFunc) NewRequest(
 	method string, url string, body io.Reader,
 ) (*http.Request, error) {
@@ -516,7 +734,9 @@ func Default(t TestingTB, baseURL string) *Expect {
 //			Status(http.StatusOK)
 //	}
 func WithConfig(config Config) *Expect {
+	config = config.withSoftReporter()
 	config = config.withDefaults()
+	config = config.withClientCert()
 
 	config.validate()
 
@@ -539,6 +759,31 @@ func (e *Expect) Env() *Environment {
 	return e.chain.env()
 }
 
+// Flush reports failures collected in soft assertions mode.
+//
+// If Config.SoftAssertions is enabled, failures are collected and passed to
+// Reporter as a single consolidated report at the end of the test. Flush
+// allows to report failures collected so far earlier. Otherwise, it does
+// nothing.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter:       httpexpect.NewAssertReporter(t),
+//		SoftAssertions: true,
+//	})
+//
+//	resp := e.GET("/path").Expect()
+//	resp.Status(http.StatusOK)
+//	resp.Header("Content-Type").IsEqual("application/json")
+//
+//	e.Flush() // reports both failures, if any
+func (e *Expect) Flush() {
+	if reporter, ok := e.config.Reporter.(*softReporter); ok {
+		reporter.flush()
+	}
+}
+
 func (e *Expect) clone() *Expect {
 	return &Expect{
 		config:   e.config,
@@ -550,7 +795,11 @@ func (e *Expect) clone() *Expect {
 
 // Builder returns a copy of Expect instance with given builder attached to it.
 // Returned copy contains all previously attached builders plus a new one.
-// Builders are invoked from Request method, after constructing every new request.
+// Builders are invoked from Request method, after constructing every new request,
+// in the order in which they were attached.
+//
+// If builder is nil, failure is reported, and all requests created from
+// returned copy will fail.
 //
 // Example:
 //
@@ -570,13 +819,31 @@ func (e *Expect) clone() *Expect {
 func (e *Expect) Builder(builder func(*Request)) *Expect {
 	ret := e.clone()
 
+	opChain := ret.chain.enter("Builder()")
+	defer opChain.leave()
+
+	if builder == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return ret
+	}
+
 	ret.builders = append(ret.builders, builder)
 	return ret
 }
 
 // Matcher returns a copy of Expect instance with given matcher attached to it.
 // Returned copy contains all previously attached matchers plus a new one.
-// Matchers are invoked from Request.Expect method, after retrieving a new response.
+// Matchers are invoked from Request.Expect method, after retrieving a new response,
+// in the order in which they were attached. Failures reported by matchers are
+// attributed to the response, e.g. Request("GET").Expect().Header(...).
+//
+// If matcher is nil, failure is reported, and all requests created from
+// returned copy will fail.
 //
 // Example:
 //
@@ -596,6 +863,19 @@ func (e *Expect) Builder(builder func(*Request)) *Expect {
 func (e *Expect) Matcher(matcher func(*Response)) *Expect {
 	ret := e.clone()
 
+	opChain := ret.chain.enter("Matcher()")
+	defer opChain.leave()
+
+	if matcher == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return ret
+	}
+
 	ret.matchers = append(ret.matchers, matcher)
 	return ret
 }
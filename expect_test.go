@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"crypto/tls"
 	"errors"
 	"io"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExpect_Constructors(t *testing.T) {
@@ -151,6 +153,45 @@ func TestExpect_Builders(t *testing.T) {
 		assert.Equal(t, 1, counter2a)
 		assert.Equal(t, 1, counter2b)
 	})
+
+	t.Run("order", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:   &mockClient{},
+			Reporter: NewAssertReporter(t),
+		})
+
+		var calls []string
+
+		e = e.
+			Builder(func(r *Request) {
+				calls = append(calls, "first")
+			}).
+			Builder(func(r *Request) {
+				calls = append(calls, "second")
+			}).
+			Builder(func(r *Request) {
+				calls = append(calls, "third")
+			})
+
+		e.Request("GET", "/url")
+
+		assert.Equal(t, []string{"first", "second", "third"}, calls)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			Client:   &mockClient{},
+			Reporter: reporter,
+		})
+
+		e1 := e.Builder(nil)
+		assert.True(t, reporter.reported)
+
+		e1.Request("GET", "/url").chain.assertFailed(t)
+		e.Request("GET", "/url").chain.assertNotFailed(t)
+	})
 }
 
 func TestExpect_Matchers(t *testing.T) {
@@ -252,6 +293,65 @@ func TestExpect_Matchers(t *testing.T) {
 		assert.Equal(t, 1, counter2a)
 		assert.Equal(t, 1, counter2b)
 	})
+
+	t.Run("order", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:   &mockClient{},
+			Reporter: NewAssertReporter(t),
+		})
+
+		var calls []string
+
+		e = e.
+			Matcher(func(r *Response) {
+				calls = append(calls, "first")
+			}).
+			Matcher(func(r *Response) {
+				calls = append(calls, "second")
+			}).
+			Matcher(func(r *Response) {
+				calls = append(calls, "third")
+			})
+
+		e.Request("GET", "/url").Expect()
+
+		assert.Equal(t, []string{"first", "second", "third"}, calls)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+		})
+
+		e = e.Matcher(func(r *Response) {
+			r.Header("X-Request-Id").NotEmpty()
+		})
+
+		e.Request("GET", "/url").Expect()
+
+		assert.NotNil(t, handler.failure)
+		assert.Equal(t,
+			[]string{`Request("GET")`, `Expect()`, `Header("X-Request-Id")`, `NotEmpty()`},
+			handler.ctx.Path)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			Client:   &mockClient{},
+			Reporter: reporter,
+		})
+
+		e1 := e.Matcher(nil)
+		assert.True(t, reporter.reported)
+
+		e1.Request("GET", "/url").chain.assertFailed(t)
+		e.Request("GET", "/url").chain.assertNotFailed(t)
+	})
 }
 
 func TestExpect_Traverse(t *testing.T) {
@@ -386,6 +486,98 @@ func TestExpect_RequestFactory(t *testing.T) {
 
 		assert.Nil(t, factory.lastreq)
 	})
+
+	t.Run("factory failure message", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          "http://example.com",
+			AssertionHandler: handler,
+			RequestFactory: &mockRequestFactory{
+				fail: true,
+			},
+		})
+
+		req := e.Request("PROPFIND", "/")
+		req.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		require.Equal(t, 2, len(handler.failure.Errors))
+		assert.Contains(t, handler.failure.Errors[0].Error(), `"PROPFIND"`)
+		assert.Contains(t, handler.failure.Errors[0].Error(), `"http://example.com"`)
+		assert.Equal(t, "testRequestFactory", handler.failure.Errors[1].Error())
+	})
+
+	t.Run("factory returns nil", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          "http://example.com",
+			AssertionHandler: handler,
+			RequestFactory: RequestFactoryFunc(
+				func(string, string, io.Reader) (*http.Request, error) {
+					return nil, nil
+				}),
+		})
+
+		req := e.Request("GET", "/")
+		req.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		assert.Nil(t, req.httpReq)
+
+		req.WithHeader("foo", "bar")
+		req.Expect().chain.assertFailed(t)
+	})
+
+	t.Run("wrapped factory", func(t *testing.T) {
+		client := &mockClient{}
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client:   client,
+			RequestFactory: RequestFactoryFunc(
+				func(method, url string, body io.Reader) (*http.Request, error) {
+					req, err := DefaultRequestFactory{}.NewRequest(method, url, body)
+					if err == nil {
+						req.Header.Set("X-Correlation-Id", "123")
+					}
+					return req, err
+				}),
+		})
+
+		e.GET("/path").WithHeader("foo", "bar").
+			Expect().
+			chain.assertNotFailed(t)
+
+		require.NotNil(t, client.req)
+		assert.Equal(t, "123", client.req.Header.Get("X-Correlation-Id"))
+		assert.Equal(t, "bar", client.req.Header.Get("Foo"))
+		assert.Equal(t, "http://example.com/path", client.req.URL.String())
+	})
+
+	t.Run("nil header", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client:   &mockClient{},
+			RequestFactory: RequestFactoryFunc(
+				func(method, url string, body io.Reader) (*http.Request, error) {
+					req, err := http.NewRequest(method, url, body)
+					if err == nil {
+						req.Header = nil
+					}
+					return req, err
+				}),
+		})
+
+		e.GET("/path").WithHeader("foo", "bar").
+			Expect().
+			chain.assertNotFailed(t)
+	})
 }
 
 func TestExpect_Panics(t *testing.T) {
@@ -432,6 +624,9 @@ func TestExpect_Config(t *testing.T) {
 		assert.NotNil(t, config.Formatter)
 		assert.NotNil(t, config.Reporter)
 
+		// Should use shared default source
+		assert.Nil(t, config.Rand)
+
 		assert.NotPanics(t, func() {
 			config.validate()
 		})
@@ -456,6 +651,26 @@ func TestExpect_Config(t *testing.T) {
 		})
 	})
 
+	t.Run("defaults, non-nil AssertionHandlers", func(t *testing.T) {
+		handler1 := &mockAssertionHandler{}
+		handler2 := &mockAssertionHandler{}
+
+		config := Config{
+			AssertionHandlers: []AssertionHandler{handler1, handler2},
+		}
+
+		config = config.withDefaults()
+
+		assert.Equal(t,
+			assertionHandlerList{handler1, handler2}, config.AssertionHandler)
+		assert.Nil(t, config.Formatter)
+		assert.Nil(t, config.Reporter)
+
+		assert.NotPanics(t, func() {
+			config.validate()
+		})
+	})
+
 	t.Run("defaults, nil Reporter and AssertionHandler", func(t *testing.T) {
 		config := Config{}
 
@@ -496,6 +711,19 @@ func TestExpect_Config(t *testing.T) {
 			badConfig.AssertionHandler = nil
 			badConfig.validate()
 		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.Timeout = -1
+			badConfig.validate()
+		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.Client = &mockClient{}
+			badConfig.CookieJar = NewCookieJar()
+			badConfig.validate()
+		})
 	})
 
 	t.Run("validate handler", func(t *testing.T) {
@@ -532,6 +760,175 @@ func TestExpect_Config(t *testing.T) {
 			badConfig.validate()
 		})
 	})
+
+	t.Run("validate handler list", func(t *testing.T) {
+		config := Config{
+			Reporter: newMockReporter(t),
+		}
+
+		config = config.withDefaults()
+
+		assert.NotPanics(t, func() {
+			badConfig := config
+			badConfig.AssertionHandlers = []AssertionHandler{
+				&mockAssertionHandler{},
+				&DefaultAssertionHandler{
+					Formatter: &DefaultFormatter{},
+					Reporter:  newMockReporter(t),
+				},
+			}
+			badConfig.validate()
+		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.AssertionHandlers = []AssertionHandler{
+				&mockAssertionHandler{},
+				nil,
+			}
+			badConfig.validate()
+		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.AssertionHandlers = []AssertionHandler{
+				&mockAssertionHandler{},
+				&DefaultAssertionHandler{
+					Formatter: &DefaultFormatter{},
+					Reporter:  nil,
+				},
+			}
+			badConfig.validate()
+		})
+	})
+
+	t.Run("client cert", func(t *testing.T) {
+		cert := tls.Certificate{Certificate: [][]byte{{1}}}
+
+		client := &http.Client{}
+		dialer := &websocket.Dialer{}
+
+		config := Config{
+			Client:          client,
+			WebsocketDialer: dialer,
+			TLSClientCert:   &cert,
+			Reporter:        newMockReporter(t),
+		}
+
+		config = config.withDefaults()
+		config = config.withClientCert()
+
+		require.IsType(t, &http.Client{}, config.Client)
+		assert.NotSame(t, client, config.Client)
+		assert.Same(t, config.tlsCertClient, config.Client)
+		assert.Nil(t, client.Transport)
+		assert.Equal(t, []tls.Certificate{cert},
+			config.Client.(*http.Client).Transport.(*http.Transport).
+				TLSClientConfig.Certificates)
+
+		require.IsType(t, &websocket.Dialer{}, config.WebsocketDialer)
+		assert.NotSame(t, dialer, config.WebsocketDialer)
+		assert.Same(t, config.tlsCertDialer, config.WebsocketDialer)
+		assert.Nil(t, dialer.TLSClientConfig)
+		assert.Equal(t, []tls.Certificate{cert},
+			config.WebsocketDialer.(*websocket.Dialer).TLSClientConfig.Certificates)
+
+		// Should not copy again
+		again := config.withClientCert()
+		assert.Same(t, config.Client, again.Client)
+		assert.Same(t, config.WebsocketDialer, again.WebsocketDialer)
+	})
+
+	t.Run("client cert, incompatible client", func(t *testing.T) {
+		cert := tls.Certificate{Certificate: [][]byte{{1}}}
+
+		client := &mockClient{}
+
+		config := Config{
+			Client:        client,
+			TLSClientCert: &cert,
+			Reporter:      newMockReporter(t),
+		}
+
+		config = config.withDefaults()
+		config = config.withClientCert()
+
+		assert.Same(t, client, config.Client)
+		assert.Nil(t, config.tlsCertClient)
+	})
+}
+
+type orderedAssertionHandler struct {
+	name  string
+	calls *[]string
+}
+
+func (h *orderedAssertionHandler) Success(*AssertionContext) {
+	*h.calls = append(*h.calls, h.name+" success")
+}
+
+func (h *orderedAssertionHandler) Failure(*AssertionContext, *AssertionFailure) {
+	*h.calls = append(*h.calls, h.name+" failure")
+}
+
+func TestExpect_AssertionHandlers(t *testing.T) {
+	t.Run("order", func(t *testing.T) {
+		var calls []string
+
+		e := WithConfig(Config{
+			AssertionHandlers: []AssertionHandler{
+				&orderedAssertionHandler{name: "first", calls: &calls},
+				&orderedAssertionHandler{name: "second", calls: &calls},
+			},
+		})
+
+		e.Value(123).Number().IsEqual(123)
+		assert.Equal(t, []string{
+			"first success",
+			"second success",
+		}, calls[len(calls)-2:])
+
+		e.Value(123).Number().IsEqual(456)
+		assert.Equal(t, []string{
+			"first failure",
+			"second failure",
+		}, calls[len(calls)-2:])
+	})
+
+	t.Run("precedence", func(t *testing.T) {
+		single := &mockAssertionHandler{}
+		listed := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			AssertionHandler:  single,
+			AssertionHandlers: []AssertionHandler{listed},
+		})
+
+		e.Value(123).Number().IsEqual(456)
+
+		assert.Nil(t, single.failure)
+		assert.NotNil(t, listed.failure)
+	})
+
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		metrics := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			AssertionHandlers: []AssertionHandler{
+				metrics,
+				&DefaultAssertionHandler{
+					Formatter: &DefaultFormatter{},
+					Reporter:  reporter,
+				},
+			},
+		})
+
+		e.Value(123).Number().IsEqual(456)
+
+		assert.NotNil(t, metrics.failure)
+		assert.True(t, reporter.reported)
+	})
 }
 
 func TestExpect_Adapters(t *testing.T) {
@@ -7,7 +7,9 @@ import (
 	"math"
 	"net/http/httputil"
 	"os"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -52,6 +54,10 @@ type DefaultFormatter struct {
 	// Exclude diff from failure report.
 	DisableDiffs bool
 
+	// Diff format.
+	// Default is DiffModeCompact.
+	DiffMode DiffMode
+
 	// Exclude HTTP request from failure report.
 	DisableRequests bool
 
@@ -173,6 +179,22 @@ const (
 	ColorModeNever
 )
 
+// DiffMode defines how the diff between expected and actual value is printed.
+//
+// Diff is printed only for equality assertions on objects and arrays.
+type DiffMode int
+
+const (
+	// Print only differing paths, one per line: keys and elements that were
+	// added, removed, or changed. Full expected and actual values are not
+	// printed when the diff is available.
+	DiffModeCompact DiffMode = iota
+
+	// Print full expected and actual values, followed by a diff of the
+	// whole values with the differing lines marked.
+	DiffModeFull
+)
+
 // FormatData defines data passed to template engine when DefaultFormatter
 // formats assertion. You can use these fields in your custom templates.
 type FormatData struct {
@@ -282,6 +304,11 @@ func (f *DefaultFormatter) buildFormatData(
 			f.fillDelta(&data, ctx, failure)
 		}
 
+		if data.HaveDiff && f.DiffMode == DiffModeCompact {
+			data.HaveActual = false
+			data.HaveExpected = false
+		}
+
 		f.fillRequest(&data, ctx, failure)
 		f.fillResponse(&data, ctx, failure)
 	}
@@ -670,6 +697,13 @@ func (f *DefaultFormatter) formatDiff(expected, actual interface{}) (string, boo
 		return "", false
 	}
 
+	if f.DiffMode == DiffModeCompact {
+		var lines []string
+		f.formatCompactDiff(&lines, "$", expected, actual)
+
+		return "--- expected\n+++ actual\n" + strings.Join(lines, "\n"), true
+	}
+
 	config := formatter.AsciiFormatterConfig{
 		ShowArrayIndex: true,
 	}
@@ -685,6 +719,93 @@ func (f *DefaultFormatter) formatDiff(expected, actual interface{}) (string, boo
 	return diffText, true
 }
 
+func (f *DefaultFormatter) formatCompactDiff(
+	lines *[]string, path string, expected, actual interface{},
+) {
+	removed := func(path string, value interface{}) {
+		*lines = append(*lines, fmt.Sprintf("- %s: %s", path, f.formatDiffValue(value)))
+	}
+	added := func(path string, value interface{}) {
+		*lines = append(*lines, fmt.Sprintf("+ %s: %s", path, f.formatDiffValue(value)))
+	}
+
+	switch ve := expected.(type) {
+	case map[string]interface{}:
+		if va, ok := actual.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(ve)+len(va))
+			for k := range ve {
+				keys = append(keys, k)
+			}
+			for k := range va {
+				if _, ok := ve[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				keyPath := path + formatDiffKey(key)
+
+				e, haveExpected := ve[key]
+				a, haveActual := va[key]
+
+				switch {
+				case !haveActual:
+					removed(keyPath, e)
+				case !haveExpected:
+					added(keyPath, a)
+				default:
+					f.formatCompactDiff(lines, keyPath, e, a)
+				}
+			}
+			return
+		}
+
+	case []interface{}:
+		if va, ok := actual.([]interface{}); ok {
+			for i := 0; i < len(ve) || i < len(va); i++ {
+				indexPath := fmt.Sprintf("%s[%d]", path, i)
+
+				switch {
+				case i >= len(va):
+					removed(indexPath, ve[i])
+				case i >= len(ve):
+					added(indexPath, va[i])
+				default:
+					f.formatCompactDiff(lines, indexPath, ve[i], va[i])
+				}
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		removed(path, expected)
+		added(path, actual)
+	}
+}
+
+func (f *DefaultFormatter) formatDiffValue(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		if b, err := json.Marshal(value); err == nil {
+			return string(b)
+		}
+	}
+
+	return f.formatValue(value)
+}
+
+var diffKeyRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func formatDiffKey(key string) string {
+	if diffKeyRe.MatchString(key) {
+		return "." + key
+	}
+
+	return fmt.Sprintf("[%q]", key)
+}
+
 func (f *DefaultFormatter) reformatNumber(numStr string) string {
 	signPart, intPart, fracPart, expPart := f.decomposeNumber(numStr)
 	if intPart == "" {
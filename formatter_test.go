@@ -1028,6 +1028,88 @@ func TestFormatter_FormatDiff(t *testing.T) {
 	})
 }
 
+func TestFormatter_DiffMode(t *testing.T) {
+	expected := map[string]interface{}{
+		"id":   1.0,
+		"name": "foo",
+		"tags": []interface{}{"a", "b"},
+		"meta": map[string]interface{}{
+			"old key": true,
+		},
+	}
+
+	actual := map[string]interface{}{
+		"id":   1.0,
+		"name": "bar",
+		"tags": []interface{}{"a", "b", "c"},
+		"meta": map[string]interface{}{
+			"new": map[string]interface{}{"x": nil},
+		},
+	}
+
+	failure := &AssertionFailure{
+		Type:     AssertEqual,
+		Actual:   &AssertionValue{actual},
+		Expected: &AssertionValue{expected},
+	}
+
+	t.Run("compact", func(t *testing.T) {
+		formatter := &DefaultFormatter{
+			DiffMode: DiffModeCompact,
+		}
+
+		diff, ok := formatter.formatDiff(expected, actual)
+		require.True(t, ok)
+
+		assert.Equal(t, strings.Join([]string{
+			"--- expected",
+			"+++ actual",
+			`+ $.meta.new: {"x":null}`,
+			`- $.meta["old key"]: true`,
+			`- $.name: "foo"`,
+			`+ $.name: "bar"`,
+			`+ $.tags[2]: "c"`,
+		}, "\n"), diff)
+
+		data := formatter.buildFormatData(&AssertionContext{}, failure)
+
+		assert.True(t, data.HaveDiff)
+		assert.Equal(t, diff, data.Diff)
+		assert.False(t, data.HaveActual)
+		assert.False(t, data.HaveExpected)
+	})
+
+	t.Run("full", func(t *testing.T) {
+		formatter := &DefaultFormatter{
+			DiffMode: DiffModeFull,
+		}
+
+		diff, ok := formatter.formatDiff(expected, actual)
+		require.True(t, ok)
+
+		assert.Contains(t, diff, `"id": 1`)
+
+		data := formatter.buildFormatData(&AssertionContext{}, failure)
+
+		assert.True(t, data.HaveDiff)
+		assert.Equal(t, diff, data.Diff)
+		assert.True(t, data.HaveActual)
+		assert.True(t, data.HaveExpected)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		formatter := &DefaultFormatter{
+			DisableDiffs: true,
+		}
+
+		data := formatter.buildFormatData(&AssertionContext{}, failure)
+
+		assert.False(t, data.HaveDiff)
+		assert.True(t, data.HaveActual)
+		assert.True(t, data.HaveExpected)
+	})
+}
+
 func TestFormatter_ColorMode(t *testing.T) {
 	cases := []struct {
 		name string
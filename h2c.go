@@ -0,0 +1,44 @@
+package httpexpect
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"golang.org/x/net/http2"
+)
+
+// NewH2CClient returns a new http.Client that talks HTTP/2 over cleartext
+// TCP (h2c) with prior knowledge.
+//
+// Requests are sent as HTTP/2 right away, without HTTP/1.1 Upgrade and
+// without TLS ALPN negotiation, so the server must accept HTTP/2 preface
+// on plain connections (e.g. net/http server wrapped with h2c.NewHandler).
+//
+// Returned client has a non-nil Jar, like the default client.
+//
+// Websocket requests are not affected, because they are sent using
+// Config.WebsocketDialer, which always uses HTTP/1.1.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:  "http://example.com",
+//		Client:   httpexpect.NewH2CClient(),
+//		Reporter: httpexpect.NewAssertReporter(t),
+//	})
+func NewH2CClient() *http.Client {
+	return &http.Client{
+		Jar: NewCookieJar(),
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(
+				ctx context.Context, network, addr string, _ *tls.Config,
+			) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, addr)
+			},
+		},
+	}
+}
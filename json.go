@@ -3,8 +3,13 @@ package httpexpect
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/xeipuuv/gojsonschema"
 	"github.com/yalp/jsonpath"
@@ -30,14 +35,20 @@ func jsonPath(opChain *chain, value interface{}, path string) *Value {
 
 	result, err := filterFn(value)
 	if err != nil {
+		errs := []error{
+			errors.New("expected: value matches given json path"),
+		}
+		if segment, ok := jsonPathSegment(path, err); ok {
+			errs = append(errs,
+				fmt.Errorf("failed to resolve path segment %q", segment))
+		}
+		errs = append(errs, err)
+
 		opChain.fail(AssertionFailure{
 			Type:     AssertMatchPath,
 			Actual:   &AssertionValue{value},
 			Expected: &AssertionValue{path},
-			Errors: []error{
-				errors.New("expected: value matches given json path"),
-				err,
-			},
+			Errors:   errs,
 		})
 		return newValue(opChain, nil)
 	}
@@ -45,6 +56,50 @@ func jsonPath(opChain *chain, value interface{}, path string) *Value {
 	return newValue(opChain, result)
 }
 
+var jsonPathColumnRe = regexp.MustCompile(` at (\d+)$`)
+
+// Find segment of path (like ".foo" or "[2]") on which evaluation failed
+// jsonpath errors end with 1-based column of the failed token
+func jsonPathSegment(path string, err error) (string, bool) {
+	m := jsonPathColumnRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return "", false
+	}
+
+	pos, convErr := strconv.Atoi(m[1])
+	if convErr != nil {
+		return "", false
+	}
+	pos--
+
+	for start := 0; start < len(path); {
+		end := start + 1
+
+		switch {
+		case strings.HasPrefix(path[start:], ".."):
+			end = start + 2
+
+		case path[start] == '.':
+			for end < len(path) && path[end] != '.' && path[end] != '[' {
+				end++
+			}
+
+		case path[start] == '[':
+			for end < len(path) && path[end-1] != ']' {
+				end++
+			}
+		}
+
+		if pos >= start && pos < end {
+			return path[start:end], true
+		}
+
+		start = end
+	}
+
+	return "", false
+}
+
 func jsonSchema(opChain *chain, value, schema interface{}) {
 	if opChain.failed() {
 		return
@@ -68,6 +123,9 @@ func jsonSchema(opChain *chain, value, schema interface{}) {
 		if ok, _ := regexp.MatchString(`^\w+://`, str); ok {
 			schemaLoader = gojsonschema.NewReferenceLoader(str)
 			schemaData = str
+		} else if uri, ok := schemaFileURI(str); ok {
+			schemaLoader = gojsonschema.NewReferenceLoader(uri)
+			schemaData = str
 		} else {
 			schemaLoader = gojsonschema.NewStringLoader(str)
 			schemaData, _ = schemaLoader.LoadJSON()
@@ -77,15 +135,27 @@ func jsonSchema(opChain *chain, value, schema interface{}) {
 		schemaData = schema
 	}
 
+	schemaObj, err := gojsonschema.NewSchema(schemaLoader)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid json schema"),
+				err,
+			},
+		})
+		return
+	}
+
 	valueLoader := gojsonschema.NewGoLoader(value)
 
-	result, err := gojsonschema.Validate(schemaLoader, valueLoader)
+	result, err := schemaObj.Validate(valueLoader)
 	if err != nil {
 		opChain.fail(AssertionFailure{
 			Type:   AssertValid,
-			Actual: &AssertionValue{schema},
+			Actual: &AssertionValue{value},
 			Errors: []error{
-				errors.New("expected: valid json schema"),
+				errors.New("failed to convert value to json"),
 				err,
 			},
 		})
@@ -97,7 +167,8 @@ func jsonSchema(opChain *chain, value, schema interface{}) {
 			errors.New("expected: value matches given json schema"),
 		}
 		for _, err := range result.Errors() {
-			errors = append(errors, fmt.Errorf("%s", err))
+			errors = append(errors, fmt.Errorf("%s: %s (rule: %s)",
+				err.Field(), err.Description(), err.Type()))
 		}
 		opChain.fail(AssertionFailure{
 			Type:     AssertMatchSchema,
@@ -107,3 +178,27 @@ func jsonSchema(opChain *chain, value, schema interface{}) {
 		})
 	}
 }
+
+// If str is a path to existing file, returns file:// URI for it
+func schemaFileURI(str string) (string, bool) {
+	if strings.ContainsAny(str, "{}[]\n") {
+		return "", false
+	}
+
+	info, err := os.Stat(str)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+
+	path, err := filepath.Abs(str)
+	if err != nil {
+		return "", false
+	}
+
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return (&url.URL{Scheme: "file", Path: path}).String(), true
+}
@@ -0,0 +1,291 @@
+package httpexpect
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// JUnitReporter implements AssertionHandler that collects assertion results
+// and writes them as JUnit XML document.
+//
+// Assertions are grouped into test cases by AssertionContext.TestName.
+// Every failure with SeverityError becomes a <failure> element of its test
+// case, with formatted failure message as content.
+//
+// Results are accumulated until Close, which writes a single document with
+// all of them to the underlying writer. If the writer is a file (or another
+// writer with Seek and Truncate methods), Flush can be used to write the
+// document earlier; subsequent Flush or Close rewrite it in place, so the
+// file always contains a single valid document.
+//
+// JUnitReporter doesn't report failures to testing.T by itself. To do it,
+// set Handler field, e.g. to DefaultAssertionHandler.
+//
+// Example:
+//
+//	junit := httpexpect.NewJUnitReporter(file)
+//	defer junit.Close()
+//
+//	junit.Handler = &httpexpect.DefaultAssertionHandler{
+//		Formatter: &httpexpect.DefaultFormatter{},
+//		Reporter:  httpexpect.NewAssertReporter(t),
+//	}
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		TestName:         t.Name(),
+//		BaseURL:          "http://example.com",
+//		AssertionHandler: junit,
+//	})
+type JUnitReporter struct {
+	// Name of generated <testsuite> element.
+	// If empty, "httpexpect" is used.
+	SuiteName string
+
+	// Formatter used to format failure messages.
+	// If nil, DefaultFormatter with disabled colors is used.
+	Formatter Formatter
+
+	// If non-nil, every assertion is also passed to this handler.
+	Handler AssertionHandler
+
+	mu      sync.Mutex
+	writer  io.Writer
+	cases   []*junitCase
+	caseMap map[string]*junitCase
+	written bool  // document was written by Flush
+	offset  int64 // position of written document
+	dirty   bool  // results changed since document was written
+	closed  bool
+}
+
+// Writer that allows to rewrite previously written data, e.g. *os.File.
+type junitRewriter interface {
+	io.Writer
+	io.Seeker
+	Truncate(size int64) error
+}
+
+type junitCase struct {
+	name       string
+	assertions int
+	startTime  time.Time
+	endTime    time.Time
+	failures   []junitFailure
+}
+
+type junitSuite struct {
+	XMLName  xml.Name        `xml:"testsuite"`
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitCaseElem `xml:"testcase"`
+}
+
+type junitCaseElem struct {
+	Name       string         `xml:"name,attr"`
+	Assertions int            `xml:"assertions,attr"`
+	Time       string         `xml:"time,attr"`
+	Failures   []junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// NewJUnitReporter returns a new JUnitReporter writing to w.
+//
+// If w is nil, the function panics.
+func NewJUnitReporter(w io.Writer) *JUnitReporter {
+	if w == nil {
+		panic("writer is nil")
+	}
+
+	return &JUnitReporter{
+		writer:  w,
+		caseMap: make(map[string]*junitCase),
+	}
+}
+
+// Success implements AssertionHandler.Success.
+func (r *JUnitReporter) Success(ctx *AssertionContext) {
+	r.mu.Lock()
+	r.getCase(ctx).assertions++
+	r.dirty = true
+	r.mu.Unlock()
+
+	if r.Handler != nil {
+		r.Handler.Success(ctx)
+	}
+}
+
+// Failure implements AssertionHandler.Failure.
+func (r *JUnitReporter) Failure(ctx *AssertionContext, failure *AssertionFailure) {
+	if failure.Severity == SeverityError {
+		msg := r.formatter().FormatFailure(ctx, failure)
+
+		var summary string
+		for _, err := range failure.Errors {
+			if !refIsNil(err) {
+				summary = err.Error()
+				break
+			}
+		}
+
+		r.mu.Lock()
+		tc := r.getCase(ctx)
+		tc.assertions++
+		tc.failures = append(tc.failures, junitFailure{
+			Message: summary,
+			Type:    failure.Type.String(),
+			Text:    msg,
+		})
+		r.dirty = true
+		r.mu.Unlock()
+	}
+
+	if r.Handler != nil {
+		r.Handler.Failure(ctx, failure)
+	}
+}
+
+// Flush writes JUnit XML document with all results collected so far,
+// if the underlying writer supports rewriting (has Seek and Truncate
+// methods, like *os.File). Otherwise, Flush does nothing, and the
+// document is written only by Close.
+//
+// Results are not cleared. If Flush is called again, or Close is called,
+// the previously written document is replaced with the new one.
+func (r *JUnitReporter) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+
+	if _, ok := r.writer.(junitRewriter); !ok {
+		return nil
+	}
+
+	return r.flush()
+}
+
+// Close writes JUnit XML document with all collected results.
+//
+// Subsequent calls to Close do nothing. The underlying writer is not closed.
+func (r *JUnitReporter) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	return r.flush()
+}
+
+func (r *JUnitReporter) flush() error {
+	if r.written && !r.dirty {
+		return nil
+	}
+
+	if rw, ok := r.writer.(junitRewriter); ok {
+		if r.written {
+			if _, err := rw.Seek(r.offset, io.SeekStart); err != nil {
+				return err
+			}
+			if err := rw.Truncate(r.offset); err != nil {
+				return err
+			}
+		} else {
+			offset, err := rw.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return err
+			}
+			r.offset = offset
+		}
+	}
+
+	if err := r.write(); err != nil {
+		return err
+	}
+
+	r.written = true
+	r.dirty = false
+
+	return nil
+}
+
+func (r *JUnitReporter) formatter() Formatter {
+	if r.Formatter != nil {
+		return r.Formatter
+	}
+
+	return &DefaultFormatter{
+		ColorMode: ColorModeNever,
+	}
+}
+
+func (r *JUnitReporter) getCase(ctx *AssertionContext) *junitCase {
+	now := time.Now()
+
+	tc := r.caseMap[ctx.TestName]
+	if tc == nil {
+		tc = &junitCase{
+			name:      ctx.TestName,
+			startTime: now,
+		}
+		r.caseMap[ctx.TestName] = tc
+		r.cases = append(r.cases, tc)
+	}
+
+	tc.endTime = now
+
+	return tc
+}
+
+func (r *JUnitReporter) write() error {
+	suite := junitSuite{
+		Name: r.SuiteName,
+	}
+
+	if suite.Name == "" {
+		suite.Name = "httpexpect"
+	}
+
+	for _, tc := range r.cases {
+		suite.Tests++
+		if len(tc.failures) != 0 {
+			suite.Failures++
+		}
+
+		suite.Cases = append(suite.Cases, junitCaseElem{
+			Name:       tc.name,
+			Assertions: tc.assertions,
+			Time: fmt.Sprintf("%.3f",
+				tc.endTime.Sub(tc.startTime).Seconds()),
+			Failures: tc.failures,
+		})
+	}
+
+	b, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(r.writer, xml.Header); err != nil {
+		return err
+	}
+
+	if _, err := r.writer.Write(append(b, '\n')); err != nil {
+		return err
+	}
+
+	return nil
+}
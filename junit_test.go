@@ -0,0 +1,230 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJUnitReporter_Basic(t *testing.T) {
+	buf := &bytes.Buffer{}
+	junit := NewJUnitReporter(buf)
+
+	config1 := Config{
+		TestName:         "TestFoo",
+		AssertionHandler: junit,
+	}
+	config2 := Config{
+		TestName:         "TestBar",
+		AssertionHandler: junit,
+	}
+
+	NewValueC(config1, 123).Number().IsEqual(123)
+	NewValueC(config2, "foo").String().IsEqual("bar")
+	NewValueC(config2, "foo").String().IsEqual("baz")
+
+	err := junit.Close()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	var suite junitSuite
+	err = xml.Unmarshal(buf.Bytes(), &suite)
+	require.NoError(t, err)
+
+	assert.Equal(t, "httpexpect", suite.Name)
+	assert.Equal(t, 2, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+
+	require.Equal(t, 2, len(suite.Cases))
+
+	assert.Equal(t, "TestFoo", suite.Cases[0].Name)
+	assert.Equal(t, 0, len(suite.Cases[0].Failures))
+	assert.NotEqual(t, 0, suite.Cases[0].Assertions)
+
+	assert.Equal(t, "TestBar", suite.Cases[1].Name)
+	require.Equal(t, 2, len(suite.Cases[1].Failures))
+	assert.NotEqual(t, 0, suite.Cases[1].Assertions)
+
+	failure := suite.Cases[1].Failures[0]
+	assert.Equal(t, "AssertEqual", failure.Type)
+	assert.Equal(t, "expected: strings are equal", failure.Message)
+	assert.Contains(t, failure.Text, "test name: TestBar")
+	assert.Contains(t, failure.Text, `"bar"`)
+}
+
+func TestJUnitReporter_Handler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	junit := NewJUnitReporter(buf)
+
+	handler := &mockAssertionHandler{}
+	junit.Handler = handler
+
+	config := Config{
+		TestName:         "TestFoo",
+		AssertionHandler: junit,
+	}
+
+	NewValueC(config, 123).Number().IsEqual(123)
+	assert.NotNil(t, handler.ctx)
+	assert.Nil(t, handler.failure)
+
+	NewValueC(config, 123).Number().IsEqual(456)
+	assert.NotNil(t, handler.ctx)
+	assert.NotNil(t, handler.failure)
+}
+
+func TestJUnitReporter_Severity(t *testing.T) {
+	buf := &bytes.Buffer{}
+	junit := NewJUnitReporter(buf)
+
+	config := Config{
+		TestName:         "TestFoo",
+		AssertionHandler: junit,
+	}
+
+	e := WithConfig(config)
+	e.Value(123).Number().IsEqual(123)
+
+	chain := newChainWithConfig("test", config.withDefaults())
+	chain.setSeverity(SeverityLog)
+
+	newNumber(chain, 123).IsEqual(456)
+
+	err := junit.Close()
+	require.NoError(t, err)
+
+	var suite junitSuite
+	err = xml.Unmarshal(buf.Bytes(), &suite)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, suite.Failures)
+	require.Equal(t, 1, len(suite.Cases))
+	assert.Equal(t, 0, len(suite.Cases[0].Failures))
+}
+
+func TestJUnitReporter_Close(t *testing.T) {
+	buf := &bytes.Buffer{}
+	junit := NewJUnitReporter(buf)
+	junit.SuiteName = "my suite"
+
+	NewValueC(Config{
+		TestName:         "TestFoo",
+		AssertionHandler: junit,
+	}, 123).Number().IsEqual(456)
+
+	err := junit.Close()
+	require.NoError(t, err)
+
+	size := buf.Len()
+	assert.NotEqual(t, 0, size)
+
+	err = junit.Close()
+	require.NoError(t, err)
+	assert.Equal(t, size, buf.Len())
+
+	var suite junitSuite
+	err = xml.Unmarshal(buf.Bytes(), &suite)
+	require.NoError(t, err)
+
+	assert.Equal(t, "my suite", suite.Name)
+	assert.Equal(t, 1, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+}
+
+func TestJUnitReporter_Flush(t *testing.T) {
+	t.Run("buffer", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		junit := NewJUnitReporter(buf)
+
+		config := Config{
+			TestName:         "TestFoo",
+			AssertionHandler: junit,
+		}
+
+		NewValueC(config, 123).Number().IsEqual(456)
+
+		// Should not write anything, since buffer can't be rewritten
+		err := junit.Flush()
+		require.NoError(t, err)
+		assert.Equal(t, 0, buf.Len())
+
+		config.TestName = "TestBar"
+		NewValueC(config, 123).Number().IsEqual(123)
+
+		err = junit.Close()
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, strings.Count(buf.String(), xml.Header))
+
+		var suite junitSuite
+		err = xml.Unmarshal(buf.Bytes(), &suite)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, suite.Tests)
+		assert.Equal(t, 1, suite.Failures)
+
+		require.Equal(t, 2, len(suite.Cases))
+		assert.Equal(t, "TestFoo", suite.Cases[0].Name)
+		assert.Equal(t, "TestBar", suite.Cases[1].Name)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		file, err := ioutil.TempFile(t.TempDir(), "junit")
+		require.NoError(t, err)
+		defer file.Close()
+
+		junit := NewJUnitReporter(file)
+
+		config := Config{
+			TestName:         "TestFoo",
+			AssertionHandler: junit,
+		}
+
+		NewValueC(config, 123).Number().IsEqual(456)
+
+		err = junit.Flush()
+		require.NoError(t, err)
+
+		b, err := ioutil.ReadFile(file.Name())
+		require.NoError(t, err)
+
+		var suite junitSuite
+		err = xml.Unmarshal(b, &suite)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, suite.Tests)
+		assert.Equal(t, 1, suite.Failures)
+
+		config.TestName = "TestBar"
+		NewValueC(config, 123).Number().IsEqual(123)
+
+		err = junit.Flush()
+		require.NoError(t, err)
+
+		err = junit.Close()
+		require.NoError(t, err)
+
+		b, err = ioutil.ReadFile(file.Name())
+		require.NoError(t, err)
+
+		// Should rewrite document instead of appending another one
+		assert.Equal(t, 1, strings.Count(string(b), xml.Header))
+
+		suite = junitSuite{}
+		err = xml.Unmarshal(b, &suite)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, suite.Tests)
+		assert.Equal(t, 1, suite.Failures)
+
+		require.Equal(t, 2, len(suite.Cases))
+		assert.Equal(t, "TestFoo", suite.Cases[0].Name)
+		assert.Equal(t, "TestBar", suite.Cases[1].Name)
+	})
+}
@@ -217,6 +217,8 @@ func (f *mockFormatter) FormatFailure(
 type mockAssertionHandler struct {
 	ctx     *AssertionContext
 	failure *AssertionFailure
+
+	failureCount int
 }
 
 func (h *mockAssertionHandler) Success(ctx *AssertionContext) {
@@ -228,6 +230,7 @@ func (h *mockAssertionHandler) Failure(
 ) {
 	h.ctx = ctx
 	h.failure = failure
+	h.failureCount++
 }
 
 type mockPrinter struct {
@@ -254,6 +257,9 @@ func (p *mockPrinter) Response(resp *http.Response, rtt time.Duration) {
 type mockWebsocketPrinter struct {
 	isWrittenTo bool
 	isReadFrom  bool
+
+	writeType    int
+	writeContent []byte
 }
 
 func newMockWsPrinter() *mockWebsocketPrinter {
@@ -271,6 +277,8 @@ func (p *mockWebsocketPrinter) Response(*http.Response, time.Duration) {
 
 func (p *mockWebsocketPrinter) WebsocketWrite(typ int, content []byte, closeCode int) {
 	p.isWrittenTo = true
+	p.writeType = typ
+	p.writeContent = content
 }
 
 func (p *mockWebsocketPrinter) WebsocketRead(typ int, content []byte, closeCode int) {
@@ -286,6 +294,7 @@ type mockWebsocketConn struct {
 	writeDlError error
 	msgType      int
 	msg          []byte
+	readDeadline time.Time
 }
 
 func (wc *mockWebsocketConn) Subprotocol() string {
@@ -297,6 +306,7 @@ func (wc *mockWebsocketConn) Close() error {
 }
 
 func (wc *mockWebsocketConn) SetReadDeadline(t time.Time) error {
+	wc.readDeadline = t
 	return wc.readDlError
 }
 
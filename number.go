@@ -52,6 +52,67 @@ func (n *Number) Raw() float64 {
 	return n.value
 }
 
+// Int returns underlying value attached to Number converted to int64.
+//
+// If number is not a signed integer, or doesn't fit into int64, failure
+// is reported and zero is returned.
+//
+// Example:
+//
+//	number := NewNumber(t, 123)
+//	assert.Equal(t, int64(123), number.Int())
+func (n *Number) Int() int64 {
+	opChain := n.chain.enter("Int()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return 0
+	}
+
+	if math.IsNaN(n.value) || math.IsInf(n.value, 0) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{n.value},
+			Errors: []error{
+				errors.New("expected: number is signed integer"),
+			},
+		})
+		return 0
+	}
+
+	inum, acc := big.NewFloat(n.value).Int(nil)
+	if !(acc == big.Exact) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{n.value},
+			Errors: []error{
+				errors.New("expected: number is signed integer"),
+			},
+		})
+		return 0
+	}
+
+	if !inum.IsInt64() {
+		imax := big.NewInt(math.MaxInt64)
+		imin := big.NewInt(math.MinInt64)
+
+		opChain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{n.value},
+			Expected: &AssertionValue{AssertionRange{
+				Min: intBoundary{imin, -1, 63},
+				Max: intBoundary{imax, +1, 63},
+			}},
+			Errors: []error{
+				errors.New("expected: number fits into int64"),
+			},
+		})
+		return 0
+	}
+
+	return inum.Int64()
+}
+
 // Decode unmarshals the underlying value attached to the Number to a target variable.
 // target should be one of these:
 //
@@ -183,7 +244,11 @@ func (n *Number) Equal(value interface{}) *Number {
 	return n.IsEqual(value)
 }
 
-// InDelta succeeds if two numerals are within delta of each other.
+// InDelta succeeds if two numerals are within delta of each other,
+// i.e. |number - value| <= delta.
+//
+// If number, value, or delta is NaN, failure is reported. Failure message
+// includes actual difference between number and value.
 //
 // Example:
 //
@@ -220,6 +285,7 @@ func (n *Number) InDelta(value, delta float64) *Number {
 			Delta:    &AssertionValue{delta},
 			Errors: []error{
 				errors.New("expected: numbers lie within delta"),
+				fmt.Errorf("actual difference: %v", math.Abs(diff)),
 			},
 		})
 		return n
@@ -228,7 +294,11 @@ func (n *Number) InDelta(value, delta float64) *Number {
 	return n
 }
 
-// NotInDelta succeeds if two numerals are not within delta of each other.
+// NotInDelta succeeds if two numerals are not within delta of each other,
+// i.e. |number - value| > delta.
+//
+// If number, value, or delta is NaN, failure is reported. Failure message
+// includes actual difference between number and value.
 //
 // Example:
 //
@@ -265,6 +335,7 @@ func (n *Number) NotInDelta(value, delta float64) *Number {
 			Delta:    &AssertionValue{delta},
 			Errors: []error{
 				errors.New("expected: numbers do not lie within delta"),
+				fmt.Errorf("actual difference: %v", math.Abs(diff)),
 			},
 		})
 		return n
@@ -288,6 +359,9 @@ func (n *Number) NotEqualDelta(value, delta float64) *Number {
 // min and max should have numeric type convertible to float64. Before comparison,
 // they are converted to float64.
 //
+// If min is greater than max, usage failure is reported. If number, min,
+// or max is NaN, failure is reported.
+//
 // Example:
 //
 //	number := NewNumber(t, 123)
@@ -312,6 +386,10 @@ func (n *Number) InRange(min, max interface{}) *Number {
 		return n
 	}
 
+	if !checkNumberRange(opChain, n.value, a, b, AssertInRange) {
+		return n
+	}
+
 	if !(n.value >= a && n.value <= b) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertInRange,
@@ -331,6 +409,9 @@ func (n *Number) InRange(min, max interface{}) *Number {
 // min and max should have numeric type convertible to float64. Before comparison,
 // they are converted to float64.
 //
+// If min is greater than max, usage failure is reported. If number, min,
+// or max is NaN, failure is reported.
+//
 // Example:
 //
 //	number := NewNumber(t, 100)
@@ -354,6 +435,10 @@ func (n *Number) NotInRange(min, max interface{}) *Number {
 		return n
 	}
 
+	if !checkNumberRange(opChain, n.value, a, b, AssertNotInRange) {
+		return n
+	}
+
 	if n.value >= a && n.value <= b {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotInRange,
@@ -1091,3 +1176,32 @@ func (b intBoundary) String() string {
 	}
 	return fmt.Sprintf("%s", b.val)
 }
+
+func checkNumberRange(
+	opChain *chain, value, min, max float64, failType AssertionType,
+) bool {
+	if math.IsNaN(value) || math.IsNaN(min) || math.IsNaN(max) {
+		opChain.fail(AssertionFailure{
+			Type:     failType,
+			Actual:   &AssertionValue{value},
+			Expected: &AssertionValue{AssertionRange{min, max}},
+			Errors: []error{
+				errors.New("expected: numbers are comparable"),
+			},
+		})
+		return false
+	}
+
+	if min > max {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("invalid range: min (%v) is greater than max (%v)",
+					min, max),
+			},
+		})
+		return false
+	}
+
+	return true
+}
@@ -1,10 +1,12 @@
 package httpexpect
 
 import (
+	"errors"
 	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNumber_FailedChain(t *testing.T) {
@@ -21,6 +23,8 @@ func TestNumber_FailedChain(t *testing.T) {
 	var target interface{}
 	value.Decode(&target)
 
+	assert.Equal(t, int64(0), value.Int())
+
 	value.IsEqual(0)
 	value.NotEqual(0)
 	value.InDelta(0, 0)
@@ -124,6 +128,88 @@ func TestNumber_Decode(t *testing.T) {
 	})
 }
 
+func TestNumber_Int(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  float64
+		result int64
+		fail   bool
+	}{
+		{
+			name:   "0",
+			value:  0,
+			result: 0,
+		},
+		{
+			name:   "-123",
+			value:  -123,
+			result: -123,
+		},
+		{
+			name:   "1e18",
+			value:  1e18,
+			result: 1000000000000000000,
+		},
+		{
+			name:   "min int64",
+			value:  math.MinInt64,
+			result: math.MinInt64,
+		},
+		{
+			name:  "max int64 + 1",
+			value: -math.MinInt64,
+			fail:  true,
+		},
+		{
+			name:  "1e19",
+			value: 1e19,
+			fail:  true,
+		},
+		{
+			name:  "-1e19",
+			value: -1e19,
+			fail:  true,
+		},
+		{
+			name:  "0.5",
+			value: 0.5,
+			fail:  true,
+		},
+		{
+			name:  "NaN",
+			value: math.NaN(),
+			fail:  true,
+		},
+		{
+			name:  "+Inf",
+			value: math.Inf(+1),
+			fail:  true,
+		},
+		{
+			name:  "-Inf",
+			value: math.Inf(-1),
+			fail:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewNumber(reporter, tc.value)
+			result := value.Int()
+
+			if tc.fail {
+				value.chain.assertFailed(t)
+				assert.Equal(t, int64(0), result)
+			} else {
+				value.chain.assertNotFailed(t)
+				assert.Equal(t, tc.result, result)
+			}
+		})
+	}
+}
+
 func TestNumber_Alias(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -334,8 +420,51 @@ func TestNumber_InDelta(t *testing.T) {
 					NotInDelta(tc.value, tc.delta).
 					chain.assertFailed(t)
 			}
+
+			if tc.isInDelta {
+				NewNumber(reporter, tc.number).
+					EqualDelta(tc.value, tc.delta).
+					chain.assertNotFailed(t)
+			} else {
+				NewNumber(reporter, tc.number).
+					EqualDelta(tc.value, tc.delta).
+					chain.assertFailed(t)
+			}
+
+			if tc.isNotInDelta {
+				NewNumber(reporter, tc.number).
+					NotEqualDelta(tc.value, tc.delta).
+					chain.assertNotFailed(t)
+			} else {
+				NewNumber(reporter, tc.number).
+					NotEqualDelta(tc.value, tc.delta).
+					chain.assertFailed(t)
+			}
 		})
 	}
+
+	t.Run("failure message", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		NewNumberC(Config{AssertionHandler: handler}, 10).
+			InDelta(12, 1)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, &AssertionValue{10.0}, handler.failure.Actual)
+		assert.Equal(t, &AssertionValue{12.0}, handler.failure.Expected)
+		assert.Equal(t, &AssertionValue{1.0}, handler.failure.Delta)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("actual difference: 2"))
+
+		handler = &mockAssertionHandler{}
+
+		NewNumberC(Config{AssertionHandler: handler}, 10).
+			NotInDelta(9.5, 1)
+
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("actual difference: 0.5"))
+	})
 }
 
 func TestNumber_InRange(t *testing.T) {
@@ -394,7 +523,7 @@ func TestNumber_InRange(t *testing.T) {
 				min:          1234 + 1,
 				max:          1234 - 1,
 				isInRange:    false,
-				isNotInRange: true,
+				isNotInRange: false,
 			},
 		}
 
@@ -484,6 +613,28 @@ func TestNumber_InRange(t *testing.T) {
 		NewNumber(reporter, 1234).NotInRange("NOT NUMBER", float32(1235)).
 			chain.assertFailed(t)
 	})
+
+	t.Run("nan", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewNumber(reporter, math.NaN()).InRange(0, 1).
+			chain.assertFailed(t)
+
+		NewNumber(reporter, math.NaN()).NotInRange(0, 1).
+			chain.assertFailed(t)
+
+		NewNumber(reporter, 1).InRange(math.NaN(), 2).
+			chain.assertFailed(t)
+
+		NewNumber(reporter, 1).NotInRange(math.NaN(), 2).
+			chain.assertFailed(t)
+
+		NewNumber(reporter, 1).InRange(0, math.NaN()).
+			chain.assertFailed(t)
+
+		NewNumber(reporter, 1).NotInRange(0, math.NaN()).
+			chain.assertFailed(t)
+	})
 }
 
 func TestNumber_InList(t *testing.T) {
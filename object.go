@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
+	"strings"
 )
 
 // Object provides methods to inspect attached map[string]interface{} object
@@ -190,10 +192,21 @@ func (o *Object) Values() *Array {
 
 // Value returns a new Value instance with value for given key.
 //
+// key is always treated as a single literal key, even if it contains
+// dots, brackets, or other special characters. To navigate nested objects
+// using dotted path, use ValueByPath. For JSONPath expressions, use Path.
+//
+// If key is absent, failure is reported, and returned Value is in failed
+// state. Key present with null value is not a failure; use Value.IsNull
+// to check it.
+//
 // Example:
 //
 //	object := NewObject(t, map[string]interface{}{"foo": 123})
 //	object.Value("foo").Number().IsEqual(123)
+//
+//	object := NewObject(t, map[string]interface{}{"user.name": "john"})
+//	object.Value("user.name").String().IsEqual("john")
 func (o *Object) Value(key string) *Value {
 	opChain := o.chain.enter("Value(%q)", key)
 	defer opChain.leave()
@@ -211,6 +224,7 @@ func (o *Object) Value(key string) *Value {
 			Expected: &AssertionValue{key},
 			Errors: []error{
 				errors.New("expected: map contains key"),
+				fmt.Errorf("key %q is absent", key),
 			},
 		})
 		return newValue(opChain, nil)
@@ -219,6 +233,109 @@ func (o *Object) Value(key string) *Value {
 	return newValue(opChain, value)
 }
 
+// ValueByPath returns a new Value instance with nested value for given
+// dotted path.
+//
+// path consists of segments separated by dots. Every segment is either
+// a key of nested object, or a decimal index of nested array. Unlike
+// Value, ValueByPath splits its argument on dots, so keys containing
+// dots can't be accessed with it; use Value for them. Unlike Path,
+// no other JSONPath syntax is supported.
+//
+// If path is empty or contains empty segment, or some segment refers
+// to absent key, invalid index, or value that is neither object nor
+// array, failure is reported, and returned Value is in failed state.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{
+//		"user": map[string]interface{}{
+//			"name":   "john",
+//			"emails": []interface{}{"john@example.com"},
+//		},
+//	})
+//	object.ValueByPath("user.name").String().IsEqual("john")
+//	object.ValueByPath("user.emails.0").String().IsEqual("john@example.com")
+func (o *Object) ValueByPath(path string) *Value {
+	opChain := o.chain.enter("ValueByPath(%q)", path)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	segments := strings.Split(path, ".")
+
+	for _, segment := range segments {
+		if segment == "" {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("unexpected empty segment in path %q", path),
+				},
+			})
+			return newValue(opChain, nil)
+		}
+	}
+
+	var value interface{} = o.value
+
+	for n, segment := range segments {
+		prefix := strings.Join(segments[:n+1], ".")
+
+		switch container := value.(type) {
+		case map[string]interface{}:
+			elem, ok := container[segment]
+			if !ok {
+				opChain.fail(AssertionFailure{
+					Type:     AssertContainsKey,
+					Actual:   &AssertionValue{container},
+					Expected: &AssertionValue{segment},
+					Errors: []error{
+						errors.New("expected: map contains key"),
+						fmt.Errorf("key %q is absent at path %q", segment, prefix),
+					},
+				})
+				return newValue(opChain, nil)
+			}
+			value = elem
+
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(container) {
+				opChain.fail(AssertionFailure{
+					Type:   AssertInRange,
+					Actual: &AssertionValue{segment},
+					Expected: &AssertionValue{AssertionRange{
+						Min: 0,
+						Max: len(container) - 1,
+					}},
+					Errors: []error{
+						errors.New("expected: valid element index"),
+						fmt.Errorf("invalid index %q at path %q", segment, prefix),
+					},
+				})
+				return newValue(opChain, nil)
+			}
+			value = container[index]
+
+		default:
+			opChain.fail(AssertionFailure{
+				Type:   AssertType,
+				Actual: &AssertionValue{value},
+				Errors: []error{
+					errors.New("expected: object or array"),
+					fmt.Errorf("can't get %q of non-container value at path %q",
+						segment, prefix),
+				},
+			})
+			return newValue(opChain, nil)
+		}
+	}
+
+	return newValue(opChain, value)
+}
+
 // HasValue succeeds if object's value for given key is equal to given value.
 // Before comparison, both values are converted to canonical form.
 //
@@ -513,6 +630,68 @@ func (o *Object) Transform(fn func(key string, value interface{}) interface{}) *
 	return newObject(opChain, transformedObject)
 }
 
+// Remap returns a new object with keys transformed by given function,
+// without affecting original object. Values are left unchanged.
+//
+// This is handy to normalize key naming convention before comparison, e.g.
+// when expected value uses camelCase keys, but actual object uses snake_case.
+//
+// Only top-level keys are transformed; keys of nested objects are kept as is.
+//
+// If two keys are mapped to the same name, failure is reported.
+//
+// The function is invoked for keys sorted in ascending order.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"user_id": 1, "user_name": "foo"})
+//	object.Remap(func(key string) string {
+//		return strings.ReplaceAll(key, "_", "")
+//	}).IsEqual(map[string]interface{}{"userid": 1, "username": "foo"})
+func (o *Object) Remap(fn func(key string) string) *Object {
+	opChain := o.chain.enter("Remap()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	remappedObject := map[string]interface{}{}
+	origKeys := map[string]string{}
+
+	for _, kv := range o.sortedKV() {
+		newKey := fn(kv.key)
+
+		if origKey, ok := origKeys[newKey]; ok {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{o.value},
+				Errors: []error{
+					errors.New("expected: keys are remapped to distinct names"),
+					fmt.Errorf("keys %q and %q are both remapped to %q",
+						origKey, kv.key, newKey),
+				},
+			})
+			return newObject(opChain, nil)
+		}
+
+		origKeys[newKey] = kv.key
+		remappedObject[newKey] = kv.val
+	}
+
+	return newObject(opChain, remappedObject)
+}
+
 // Find accepts a function that returns a boolean, runs it over the object
 // elements, and returns the first element on which it returned true.
 //
@@ -1028,6 +1207,102 @@ func (o *Object) NotContainsKey(key string) *Object {
 	return o
 }
 
+// ContainsKeys succeeds if object contains all given keys.
+//
+// If some keys are missing, failure lists all of them.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"foo": 123, "bar": 456})
+//	object.ContainsKeys("foo", "bar")
+func (o *Object) ContainsKeys(keys ...string) *Object {
+	opChain := o.chain.enter("ContainsKeys()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	if len(keys) == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty key list"),
+			},
+		})
+		return o
+	}
+
+	var missing []string
+	for _, key := range keys {
+		if !containsKey(opChain, o.value, key) {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{keyList(missing)},
+			Errors: []error{
+				errors.New("expected: map contains all keys"),
+				fmt.Errorf("missing keys: %q", missing),
+			},
+		})
+	}
+
+	return o
+}
+
+// NotContainsKeys succeeds if object doesn't contain any of given keys.
+//
+// If some keys are present, failure lists all of them.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"foo": 123})
+//	object.NotContainsKeys("bar", "baz")
+func (o *Object) NotContainsKeys(keys ...string) *Object {
+	opChain := o.chain.enter("NotContainsKeys()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	if len(keys) == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty key list"),
+			},
+		})
+		return o
+	}
+
+	var present []string
+	for _, key := range keys {
+		if containsKey(opChain, o.value, key) {
+			present = append(present, key)
+		}
+	}
+
+	if len(present) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsKey,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{keyList(present)},
+			Errors: []error{
+				errors.New("expected: map does not contain any of keys"),
+				fmt.Errorf("unexpected keys: %q", present),
+			},
+		})
+	}
+
+	return o
+}
+
 // ContainsValue succeeds if object contains given value with any key.
 // Before comparison, both object and value are converted to canonical form.
 //
@@ -1126,14 +1401,19 @@ func (o *Object) ContainsSubset(value interface{}) *Object {
 		return o
 	}
 
-	if !containsSubset(opChain, o.value, value) {
+	if ok, mismatch := containsSubset(opChain, o.value, value); !ok {
+		errs := []error{
+			errors.New("expected: map contains sub-map"),
+		}
+		if mismatch != nil {
+			errs = append(errs, mismatch)
+		}
+
 		opChain.fail(AssertionFailure{
 			Type:     AssertContainsSubset,
 			Actual:   &AssertionValue{o.value},
 			Expected: &AssertionValue{value},
-			Errors: []error{
-				errors.New("expected: map contains sub-map"),
-			},
+			Errors:   errs,
 		})
 	}
 
@@ -1157,7 +1437,7 @@ func (o *Object) NotContainsSubset(value interface{}) *Object {
 		return o
 	}
 
-	if containsSubset(opChain, o.value, value) {
+	if ok, _ := containsSubset(opChain, o.value, value); ok {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotContainsSubset,
 			Actual:   &AssertionValue{o.value},
@@ -1200,6 +1480,14 @@ func (o *Object) sortedKV() []kv {
 	return kvs
 }
 
+func keyList(keys []string) AssertionList {
+	list := make(AssertionList, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, key)
+	}
+	return list
+}
+
 func containsKey(
 	opChain *chain, obj map[string]interface{}, key string,
 ) bool {
@@ -1230,35 +1518,61 @@ func containsValue(
 
 func containsSubset(
 	opChain *chain, obj map[string]interface{}, val interface{},
-) bool {
+) (bool, error) {
 	canonVal, ok := canonMap(opChain, val)
 	if !ok {
-		return false
+		return false, nil
 	}
 
-	return isSubset(obj, canonVal)
+	path, missing, found := findSubsetMismatch(obj, canonVal, "")
+	if !found {
+		return true, nil
+	}
+
+	if missing {
+		return false, fmt.Errorf("missing key %q", path)
+	}
+
+	return false, fmt.Errorf("mismatched value at %q", path)
 }
 
-func isSubset(outer, inner map[string]interface{}) bool {
-	for k, iv := range inner {
+// findSubsetMismatch returns path to the first key of inner which is
+// either missing in outer or has different value.
+func findSubsetMismatch(
+	outer, inner map[string]interface{}, prefix string,
+) (path string, missing bool, found bool) {
+	keys := make([]string, 0, len(inner))
+	for k := range inner {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		iv := inner[k]
+
+		keyPath := k
+		if prefix != "" {
+			keyPath = prefix + "." + k
+		}
+
 		ov, ok := outer[k]
 		if !ok {
-			return false
+			return keyPath, true, true
 		}
 
 		if ovm, ok := ov.(map[string]interface{}); ok {
 			if ivm, ok := iv.(map[string]interface{}); ok {
-				if !isSubset(ovm, ivm) {
-					return false
+				if path, missing, found := findSubsetMismatch(ovm, ivm, keyPath); found {
+					return path, missing, found
 				}
 				continue
 			}
 		}
 
 		if !reflect.DeepEqual(ov, iv) {
-			return false
+			return keyPath, false, true
 		}
 	}
 
-	return true
+	return "", false, false
 }
@@ -1,10 +1,13 @@
 package httpexpect
 
 import (
+	"errors"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestObject_FailedChain(t *testing.T) {
@@ -21,6 +24,7 @@ func TestObject_FailedChain(t *testing.T) {
 		value.Keys().chain.assert(t, failure)
 		value.Values().chain.assert(t, failure)
 		value.Value("foo").chain.assert(t, failure)
+		value.ValueByPath("foo").chain.assert(t, failure)
 
 		value.IsEmpty()
 		value.NotEmpty()
@@ -30,6 +34,8 @@ func TestObject_FailedChain(t *testing.T) {
 		value.NotInList(nil)
 		value.ContainsKey("foo")
 		value.NotContainsKey("foo")
+		value.ContainsKeys("foo")
+		value.NotContainsKeys("foo")
 		value.ContainsValue("foo")
 		value.NotContainsValue("foo")
 		value.ContainsSubset(nil)
@@ -46,6 +52,9 @@ func TestObject_FailedChain(t *testing.T) {
 		value.Transform(func(key string, value interface{}) interface{} {
 			return nil
 		})
+		value.Remap(func(key string) string {
+			return key
+		})
 		value.Filter(func(_ string, value *Value) bool {
 			value.String().NotEmpty()
 			return true
@@ -334,6 +343,102 @@ func TestObject_Getters(t *testing.T) {
 	value.chain.clear()
 }
 
+func TestObject_ValueByPath(t *testing.T) {
+	m := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":   "john",
+			"emails": []interface{}{"a@example.com", "b@example.com"},
+			"groups": []interface{}{
+				map[string]interface{}{"id": 1.0},
+			},
+		},
+		"user.name":    "literal",
+		"user.tags[0]": "bracket",
+		"null":         nil,
+	}
+
+	cases := []struct {
+		name   string
+		path   string
+		value  interface{}
+		result chainResult
+	}{
+		{"top level", "null", nil, success},
+		{"nested key", "user.name", "john", success},
+		{"array index", "user.emails.1", "b@example.com", success},
+		{"key in array", "user.groups.0.id", 1.0, success},
+		{"absent key", "user.age", nil, failure},
+		{"absent top level key", "group.name", nil, failure},
+		{"index out of range", "user.emails.2", nil, failure},
+		{"negative index", "user.emails.-1", nil, failure},
+		{"non-numeric index", "user.emails.foo", nil, failure},
+		{"non-container", "user.name.first", nil, failure},
+		{"null container", "null.foo", nil, failure},
+		{"empty path", "", nil, failure},
+		{"empty segment", "user..name", nil, failure},
+		{"trailing dot", "user.", nil, failure},
+		{"dotted key", "user.tags[0]", nil, failure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			object := NewObject(reporter, m)
+
+			value := object.ValueByPath(tc.path)
+			value.chain.assert(t, tc.result)
+			object.chain.assert(t, tc.result)
+
+			assert.Equal(t, tc.value, value.Raw())
+		})
+	}
+
+	t.Run("literal keys", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		object := NewObject(reporter, m)
+
+		object.Value("user.name").String().IsEqual("literal")
+		object.Value("user.tags[0]").String().IsEqual("bracket")
+		object.chain.assert(t, success)
+
+		object.Value("user.emails").chain.assert(t, failure)
+	})
+}
+
+func TestObject_KeysOrder(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	m := map[string]interface{}{
+		"foo": 1.0,
+		"bar": 2.0,
+		"baz": 3.0,
+		"Qux": 4.0,
+		"":    5.0,
+	}
+
+	value := NewObject(reporter, m)
+
+	for n := 0; n < 10; n++ {
+		value.Keys().IsEqual([]interface{}{"", "Qux", "bar", "baz", "foo"})
+		value.chain.assert(t, success)
+		value.chain.clear()
+
+		value.Values().IsEqual([]interface{}{5.0, 4.0, 2.0, 3.0, 1.0})
+		value.chain.assert(t, success)
+		value.chain.clear()
+	}
+
+	value.ContainsKey("Qux")
+	value.chain.assert(t, success)
+	value.chain.clear()
+
+	value.ContainsKey("qux")
+	value.chain.assert(t, failure)
+	value.chain.clear()
+}
+
 func TestObject_IsEmpty(t *testing.T) {
 	t.Run("empty map", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -695,6 +800,80 @@ func TestObject_ContainsKey(t *testing.T) {
 	value.chain.clear()
 }
 
+func TestObject_ContainsKeys(t *testing.T) {
+	cases := []struct {
+		name            string
+		keys            []string
+		wantContains    chainResult
+		wantNotContains chainResult
+	}{
+		{
+			name:            "all present",
+			keys:            []string{"foo", "bar"},
+			wantContains:    success,
+			wantNotContains: failure,
+		},
+		{
+			name:            "some present",
+			keys:            []string{"foo", "baz"},
+			wantContains:    failure,
+			wantNotContains: failure,
+		},
+		{
+			name:            "none present",
+			keys:            []string{"baz", "BAR"},
+			wantContains:    failure,
+			wantNotContains: success,
+		},
+		{
+			name:            "empty list",
+			keys:            []string{},
+			wantContains:    failure,
+			wantNotContains: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			data := map[string]interface{}{"foo": 123, "bar": ""}
+
+			NewObject(reporter, data).ContainsKeys(tc.keys...).
+				chain.assert(t, tc.wantContains)
+
+			NewObject(reporter, data).NotContainsKeys(tc.keys...).
+				chain.assert(t, tc.wantNotContains)
+		})
+	}
+
+	t.Run("failure lists all keys", func(t *testing.T) {
+		data := map[string]interface{}{"foo": 123, "bar": ""}
+
+		handler := &mockAssertionHandler{}
+		NewObjectC(Config{AssertionHandler: handler}, data).
+			ContainsKeys("foo", "baz", "qux")
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertContainsKey, handler.failure.Type)
+		assert.Equal(t, &AssertionValue{AssertionList{"baz", "qux"}},
+			handler.failure.Expected)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New(`missing keys: ["baz" "qux"]`))
+
+		handler = &mockAssertionHandler{}
+		NewObjectC(Config{AssertionHandler: handler}, data).
+			NotContainsKeys("foo", "baz", "bar")
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertNotContainsKey, handler.failure.Type)
+		assert.Equal(t, &AssertionValue{AssertionList{"foo", "bar"}},
+			handler.failure.Expected)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New(`unexpected keys: ["foo" "bar"]`))
+	})
+}
+
 func TestObject_ContainsValue(t *testing.T) {
 	t.Run("basic", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -1006,6 +1185,72 @@ func TestObject_ContainsSubset(t *testing.T) {
 		value.chain.assert(t, failure)
 		value.chain.clear()
 	})
+
+	t.Run("mismatch path", func(t *testing.T) {
+		cases := []struct {
+			name      string
+			subset    map[string]interface{}
+			wantError string
+		}{
+			{
+				name: "missing key",
+				subset: map[string]interface{}{
+					"user": map[string]interface{}{
+						"address": map[string]interface{}{
+							"zip": "12345",
+						},
+					},
+				},
+				wantError: `missing key "user.address.zip"`,
+			},
+			{
+				name: "mismatched value",
+				subset: map[string]interface{}{
+					"user": map[string]interface{}{
+						"name": "John",
+						"address": map[string]interface{}{
+							"city": "Paris",
+						},
+					},
+				},
+				wantError: `mismatched value at "user.address.city"`,
+			},
+			{
+				name: "mismatched array",
+				subset: map[string]interface{}{
+					"tags": []interface{}{"a"},
+				},
+				wantError: `mismatched value at "tags"`,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				handler := &mockAssertionHandler{}
+
+				value := NewObjectC(Config{
+					AssertionHandler: handler,
+				}, map[string]interface{}{
+					"user": map[string]interface{}{
+						"name": "John",
+						"address": map[string]interface{}{
+							"city": "London",
+						},
+					},
+					"tags": []interface{}{"a", "b"},
+				})
+
+				value.ContainsSubset(tc.subset)
+				value.chain.assert(t, failure)
+
+				if assert.NotNil(t, handler.failure) &&
+					assert.Equal(t, 2, len(handler.failure.Errors)) {
+					assert.Equal(t, tc.wantError,
+						handler.failure.Errors[1].Error())
+				}
+			})
+		}
+	})
 }
 
 func TestObject_HasValue(t *testing.T) {
@@ -1061,6 +1306,42 @@ func TestObject_HasValue(t *testing.T) {
 		value.chain.clear()
 	})
 
+	t.Run("numeric types", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewObject(reporter, map[string]interface{}{
+			"foo": 123.0,
+			"bar": []interface{}{1.0, 2.5},
+			"baz": map[string]interface{}{
+				"a": 456.0,
+			},
+		})
+
+		for _, num := range []interface{}{
+			int(123), int8(123), int64(123), uint32(123), float32(123), float64(123),
+		} {
+			value.HasValue("foo", num)
+			value.chain.assert(t, success)
+			value.chain.clear()
+
+			value.NotHasValue("foo", num)
+			value.chain.assert(t, failure)
+			value.chain.clear()
+		}
+
+		value.HasValue("bar", []interface{}{int64(1), float32(2.5)})
+		value.chain.assert(t, success)
+		value.chain.clear()
+
+		value.HasValue("baz", map[string]int{"a": 456})
+		value.chain.assert(t, success)
+		value.chain.clear()
+
+		value.HasValue("baz", map[string]int{"a": 457})
+		value.chain.assert(t, failure)
+		value.chain.clear()
+	})
+
 	t.Run("struct", func(t *testing.T) {
 		reporter := newMockReporter(t)
 
@@ -1253,6 +1534,25 @@ func TestObject_Every(t *testing.T) {
 		assert.Equal(t, expectedOrder, actualOrder)
 	})
 
+	t.Run("failure path", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		object := NewObjectC(Config{
+			AssertionHandler: handler,
+		}, map[string]interface{}{
+			"foo": "123",
+			"bar": "456",
+			"baz": "b",
+		})
+
+		object.Every(func(_ string, value *Value) {
+			value.String().IsASCII().AsNumber()
+		})
+		object.chain.assert(t, failure)
+
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, handler.ctx.Path, `Every["baz"]`)
+	})
+
 	t.Run("invalid argument", func(t *testing.T) {
 		reporter := newMockReporter(t)
 		object := NewObject(reporter, map[string]interface{}{})
@@ -1393,6 +1693,122 @@ func TestObject_Transform(t *testing.T) {
 	})
 }
 
+func TestObject_Remap(t *testing.T) {
+	t.Run("remap keys", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		object := NewObject(reporter, map[string]interface{}{
+			"user_id":   1,
+			"user_name": "foo",
+			"tags":      map[string]interface{}{"is_admin": true},
+		})
+
+		newObject := object.Remap(func(key string) string {
+			return strings.ReplaceAll(key, "_", "")
+		})
+		newObject.chain.assert(t, success)
+
+		assert.Equal(t,
+			map[string]interface{}{
+				"userid":   1.0,
+				"username": "foo",
+				"tags":     map[string]interface{}{"is_admin": true},
+			},
+			newObject.Raw())
+
+		newObject.IsEqual(map[string]interface{}{
+			"userid":   1,
+			"username": "foo",
+			"tags":     map[string]interface{}{"is_admin": true},
+		})
+		newObject.chain.assert(t, success)
+
+		// Should not modify original object
+		assert.Equal(t,
+			map[string]interface{}{
+				"user_id":   1.0,
+				"user_name": "foo",
+				"tags":      map[string]interface{}{"is_admin": true},
+			},
+			object.Raw())
+	})
+
+	t.Run("case normalization", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		object := NewObject(reporter, map[string]interface{}{
+			"UserID": 1,
+			"Name":   "foo",
+		})
+
+		object.Remap(strings.ToLower).
+			IsEqual(map[string]interface{}{
+				"userid": 1,
+				"name":   "foo",
+			}).
+			chain.assert(t, success)
+	})
+
+	t.Run("call order", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		object := NewObject(reporter, map[string]interface{}{
+			"foo": "123",
+			"bar": "456",
+			"b":   "456",
+			"baz": "baz",
+		})
+
+		actualOrder := []string{}
+		object.Remap(func(key string) string {
+			actualOrder = append(actualOrder, key)
+			return key
+		})
+
+		assert.Equal(t, []string{"b", "bar", "baz", "foo"}, actualOrder)
+	})
+
+	t.Run("empty object", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		object := NewObject(reporter, map[string]interface{}{})
+
+		newObject := object.Remap(strings.ToUpper)
+		newObject.chain.assert(t, success)
+
+		assert.Equal(t, map[string]interface{}{}, newObject.Raw())
+	})
+
+	t.Run("key collision", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		object := NewObjectC(Config{AssertionHandler: handler},
+			map[string]interface{}{
+				"userId":  1,
+				"user_id": 2,
+				"name":    "foo",
+			})
+
+		newObject := object.Remap(func(key string) string {
+			return strings.ToLower(strings.ReplaceAll(key, "_", ""))
+		})
+		newObject.chain.assert(t, failure)
+
+		assert.Nil(t, newObject.Raw())
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertValid, handler.failure.Type)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New(`keys "userId" and "user_id" are both remapped to "userid"`))
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		object := NewObject(reporter, map[string]interface{}{
+			"foo": "123",
+		})
+
+		newObject := object.Remap(nil)
+
+		newObject.chain.assert(t, failure)
+	})
+}
+
 func TestObject_Filter(t *testing.T) {
 	t.Run("elements of same type", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -1512,6 +1928,30 @@ func TestObject_Filter(t *testing.T) {
 		assert.Equal(t, expectedOrder, actualOrder)
 	})
 
+	t.Run("chained result", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		object := NewObject(reporter, map[string]interface{}{
+			"foo": 1.0,
+			"bar": 2.0,
+			"baz": 3.0,
+		})
+
+		filteredObject := object.Filter(func(_ string, value *Value) bool {
+			return value.Number().Raw() > 1
+		})
+		filteredObject.Keys().ConsistsOf("bar", "baz")
+		filteredObject.chain.assert(t, success)
+		object.chain.assert(t, success)
+
+		filteredObject.ContainsKey("foo")
+		filteredObject.chain.assert(t, failure)
+		assert.Equal(t, map[string]interface{}{
+			"foo": 1.0,
+			"bar": 2.0,
+			"baz": 3.0,
+		}, object.Raw())
+	})
+
 	t.Run("invalid argument", func(t *testing.T) {
 		reporter := newMockReporter(t)
 		object := NewObject(reporter, map[string]interface{}{})
@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httputil"
@@ -24,6 +25,27 @@ type Printer interface {
 	Response(*http.Response, time.Duration)
 }
 
+// RequestAttempt returns the number of attempt to send given request,
+// starting from 1. Numbers greater than 1 mean that request is retried,
+// see Request.WithMaxRetries.
+//
+// It can be used by Printer implementations to distinguish retries.
+// CompactPrinter, DebugPrinter, and CurlPrinter print attempt number
+// for retries. If request wasn't sent by httpexpect, zero is returned.
+func RequestAttempt(req *http.Request) int {
+	if req == nil {
+		return 0
+	}
+	attempt, _ := req.Context().Value(requestAttemptKey{}).(int)
+	return attempt
+}
+
+type requestAttemptKey struct{}
+
+func withRequestAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, requestAttemptKey{}, attempt)
+}
+
 // WebsocketPrinter is used to print writes and reads of WebSocket connection.
 //
 // If WebSocket connection is used, all Printers that also implement WebsocketPrinter
@@ -54,7 +76,11 @@ func NewCompactPrinter(logger Logger) CompactPrinter {
 // Request implements Printer.Request.
 func (p CompactPrinter) Request(req *http.Request) {
 	if req != nil {
-		p.logger.Logf("%s %s", req.Method, req.URL)
+		if attempt := RequestAttempt(req); attempt > 1 {
+			p.logger.Logf("%s %s (attempt %d)", req.Method, req.URL, attempt)
+		} else {
+			p.logger.Logf("%s %s", req.Method, req.URL)
+		}
 	}
 }
 
@@ -81,7 +107,11 @@ func (p CurlPrinter) Request(req *http.Request) {
 		if err != nil {
 			panic(err)
 		}
-		p.logger.Logf("%s", cmd.String())
+		if attempt := RequestAttempt(req); attempt > 1 {
+			p.logger.Logf("# attempt %d\n%s", attempt, cmd.String())
+		} else {
+			p.logger.Logf("%s", cmd.String())
+		}
 	}
 }
 
@@ -113,7 +143,12 @@ func (p DebugPrinter) Request(req *http.Request) {
 	if err != nil {
 		panic(err)
 	}
-	p.logger.Logf("%s", dump)
+
+	if attempt := RequestAttempt(req); attempt > 1 {
+		p.logger.Logf("attempt %d\n%s", attempt, dump)
+	} else {
+		p.logger.Logf("%s", dump)
+	}
 }
 
 // Response implements Printer.Response.
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -46,6 +47,56 @@ func TestPrinter_Debug(t *testing.T) {
 	printer.Response(nil, 0)
 }
 
+func TestPrinter_Attempt(t *testing.T) {
+	newRequest := func(attempt int) *http.Request {
+		req, _ := http.NewRequest("GET", "http://example.com", nil)
+		if attempt != 0 {
+			req = req.WithContext(withRequestAttempt(req.Context(), attempt))
+		}
+		return req
+	}
+
+	t.Run("RequestAttempt", func(t *testing.T) {
+		assert.Equal(t, 0, RequestAttempt(nil))
+		assert.Equal(t, 0, RequestAttempt(newRequest(0)))
+		assert.Equal(t, 1, RequestAttempt(newRequest(1)))
+		assert.Equal(t, 2, RequestAttempt(newRequest(2)))
+	})
+
+	t.Run("CompactPrinter", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewCompactPrinter(logger)
+
+		printer.Request(newRequest(1))
+		assert.Equal(t, "GET http://example.com", logger.lastMessage)
+
+		printer.Request(newRequest(2))
+		assert.Equal(t, "GET http://example.com (attempt 2)", logger.lastMessage)
+	})
+
+	t.Run("DebugPrinter", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinter(logger, true)
+
+		printer.Request(newRequest(1))
+		assert.True(t, strings.HasPrefix(logger.lastMessage, "GET / HTTP/1.1"))
+
+		printer.Request(newRequest(2))
+		assert.True(t, strings.HasPrefix(logger.lastMessage, "attempt 2\nGET / HTTP/1.1"))
+	})
+
+	t.Run("CurlPrinter", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewCurlPrinter(logger)
+
+		printer.Request(newRequest(1))
+		assert.True(t, strings.HasPrefix(logger.lastMessage, "curl"))
+
+		printer.Request(newRequest(2))
+		assert.True(t, strings.HasPrefix(logger.lastMessage, "# attempt 2\ncurl"))
+	})
+}
+
 type errorReader struct{}
 
 func (errorReader) Read(_ []byte) (n int, err error) {
@@ -0,0 +1,42 @@
+package httpexpect
+
+import (
+	"encoding/hex"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var (
+	// Guards all instances of rand.Rand used via Config.Rand,
+	// since rand.Rand is not safe for concurrent use
+	randMu sync.Mutex
+
+	// Used when Config.Rand is nil
+	defaultRand = newDefaultRand()
+)
+
+func newDefaultRand() *rand.Rand {
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// Fill buffer with random bytes from given source
+// If source is nil, default source is used
+func randomRead(rnd *rand.Rand, buf []byte) {
+	randMu.Lock()
+	defer randMu.Unlock()
+
+	if rnd == nil {
+		rnd = defaultRand
+	}
+
+	_, _ = rnd.Read(buf)
+}
+
+// Generate multipart boundary, in the same format as used by
+// mime/multipart package
+func randomBoundary(rnd *rand.Rand) string {
+	var buf [30]byte
+	randomRead(rnd, buf[:])
+	return hex.EncodeToString(buf[:])
+}
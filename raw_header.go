@@ -0,0 +1,293 @@
+package httpexpect
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Maximum size of response header block recorded by rawHeaderConn;
+// same as default limit of http.Transport.
+const maxRawHeaderBytes = 1 << 20
+
+// rawHeaderRecorder records response header names as they were received,
+// before canonicalization performed by http.Transport.
+//
+// Connections are wrapped into rawHeaderConn by dialers installed by
+// setupDialers, and the connection used by the request is obtained via
+// httptrace.
+type rawHeaderRecorder struct {
+	mu   sync.Mutex
+	conn *rawHeaderConn
+}
+
+func (rec *rawHeaderRecorder) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			rec.mu.Lock()
+			defer rec.mu.Unlock()
+
+			// if connection is not wrapped, e.g. because TLS is established
+			// by transport over proxy connection, names are not recorded
+			rec.conn, _ = info.Conn.(*rawHeaderConn)
+		},
+	}
+}
+
+// setupDialers installs dialers that wrap connections of transport
+// into rawHeaderConn.
+//
+// TLS connections are established by the recorder itself, so that
+// rawHeaderConn can see decrypted data. Since the connection should use
+// HTTP/1.x to preserve header names, HTTP/2 is not negotiated.
+func (rec *rawHeaderRecorder) setupDialers(transport *http.Transport) {
+	dialContext := transport.DialContext
+	if dialContext == nil {
+		var dialer net.Dialer
+		dialContext = dialer.DialContext
+	}
+
+	transport.DialContext = func(
+		ctx context.Context, network, addr string,
+	) (net.Conn, error) {
+		conn, err := dialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return &rawHeaderConn{Conn: conn}, nil
+	}
+
+	if transport.DialTLSContext != nil || transport.DialTLS != nil {
+		return
+	}
+
+	transport.DialTLSContext = func(
+		ctx context.Context, network, addr string,
+	) (net.Conn, error) {
+		conn, err := dialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		var config *tls.Config
+		if transport.TLSClientConfig != nil {
+			config = transport.TLSClientConfig.Clone()
+		} else {
+			config = &tls.Config{}
+		}
+
+		if config.ServerName == "" {
+			if host, _, err := net.SplitHostPort(addr); err == nil {
+				config.ServerName = host
+			} else {
+				config.ServerName = addr
+			}
+		}
+
+		config.NextProtos = []string{"http/1.1"}
+
+		tlsConn := tls.Client(conn, config)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+
+		return &rawHeaderConn{Conn: tlsConn}, nil
+	}
+}
+
+// header returns response header with raw names.
+// Returns nil if raw names were not recorded.
+func (rec *rawHeaderRecorder) header(httpResp *http.Response) http.Header {
+	rec.mu.Lock()
+	conn := rec.conn
+	rec.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+
+	names := conn.lastNames()
+	if names == nil {
+		return nil
+	}
+
+	return rawHeader(httpResp.Header, names)
+}
+
+// rawHeader builds header with raw names from canonicalized header and the
+// list of raw names in the order they were received.
+func rawHeader(header http.Header, names []string) http.Header {
+	raw := make(http.Header, len(names))
+
+	indexes := make(map[string]int, len(names))
+
+	for _, name := range names {
+		canonName := textproto.CanonicalMIMEHeaderKey(name)
+
+		// header may be removed by transport, e.g. Content-Encoding when
+		// body is decompressed transparently
+		values := header[canonName]
+		index := indexes[canonName]
+		if index >= len(values) {
+			continue
+		}
+		indexes[canonName] = index + 1
+
+		raw[name] = append(raw[name], values[index])
+	}
+
+	return raw
+}
+
+// rawHeaderConn records names of header fields of HTTP/1.x responses
+// read from connection.
+//
+// Recording starts when request is written and ends when the whole
+// header block of the final (non-informational) response is read.
+type rawHeaderConn struct {
+	net.Conn
+
+	mu        sync.Mutex
+	recording bool
+	buf       []byte
+	names     []string
+}
+
+func (c *rawHeaderConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	if !c.recording {
+		c.recording = true
+		c.buf = nil
+		c.names = nil
+	}
+	c.mu.Unlock()
+
+	return c.Conn.Write(b)
+}
+
+func (c *rawHeaderConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+
+	if n > 0 {
+		c.record(b[:n])
+	}
+
+	return n, err
+}
+
+func (c *rawHeaderConn) lastNames() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.recording {
+		return nil
+	}
+
+	return c.names
+}
+
+func (c *rawHeaderConn) record(data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.recording {
+		return
+	}
+
+	c.buf = append(c.buf, data...)
+
+	for {
+		end := headerBlockEnd(c.buf)
+		if end < 0 {
+			if len(c.buf) > maxRawHeaderBytes {
+				c.recording = false
+				c.buf = nil
+			}
+			return
+		}
+
+		block := c.buf[:end]
+		rest := c.buf[end:]
+
+		if isInformationalResponse(block) {
+			// final response follows
+			c.buf = append([]byte(nil), rest...)
+			continue
+		}
+
+		c.names = parseHeaderNames(block)
+		c.recording = false
+		c.buf = nil
+
+		return
+	}
+}
+
+// headerBlockEnd returns position after the empty line which terminates
+// header block, or -1 if buffer doesn't contain complete header block.
+func headerBlockEnd(buf []byte) int {
+	pos := 0
+
+	for {
+		n := bytes.IndexByte(buf[pos:], '\n')
+		if n < 0 {
+			return -1
+		}
+
+		line := buf[pos : pos+n]
+		pos += n + 1
+
+		if len(line) == 0 || (len(line) == 1 && line[0] == '\r') {
+			return pos
+		}
+	}
+}
+
+func isInformationalResponse(block []byte) bool {
+	statusLine := block
+	if n := bytes.IndexByte(block, '\n'); n >= 0 {
+		statusLine = block[:n]
+	}
+
+	fields := strings.Fields(string(statusLine))
+	if len(fields) < 2 {
+		return false
+	}
+
+	code, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return false
+	}
+
+	return code >= 100 && code < 200 && code != http.StatusSwitchingProtocols
+}
+
+func parseHeaderNames(block []byte) []string {
+	lines := strings.Split(string(block), "\n")
+
+	names := []string{}
+
+	// first line is status line
+	for _, line := range lines[1:] {
+		line = strings.TrimSuffix(line, "\r")
+
+		// skip empty line and obsolete line folding
+		if line == "" || line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+
+		if n := strings.IndexByte(line, ':'); n > 0 {
+			names = append(names, line[:n])
+		}
+	}
+
+	return names
+}
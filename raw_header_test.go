@@ -0,0 +1,123 @@
+package httpexpect
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawHeader_Conn(t *testing.T) {
+	newConn := func() *rawHeaderConn {
+		client, server := net.Pipe()
+		t.Cleanup(func() {
+			_ = client.Close()
+			_ = server.Close()
+		})
+		go func() {
+			_, _ = io.Copy(ioutil.Discard, server)
+		}()
+		return &rawHeaderConn{Conn: client}
+	}
+
+	t.Run("basic", func(t *testing.T) {
+		conn := newConn()
+
+		_, err := conn.Write([]byte("GET / HTTP/1.1\r\n\r\n"))
+		assert.NoError(t, err)
+
+		conn.record([]byte("HTTP/1.1 200 OK\r\n" +
+			"x-foo: 1\r\n" +
+			"X-Bar: 2\r\n" +
+			"\r\n" +
+			"body"))
+
+		assert.Equal(t, []string{"x-foo", "X-Bar"}, conn.lastNames())
+	})
+
+	t.Run("split reads", func(t *testing.T) {
+		conn := newConn()
+
+		conn.recording = true
+		conn.record([]byte("HTTP/1.1 200 OK\r\nx-fo"))
+		assert.Nil(t, conn.lastNames())
+
+		conn.record([]byte("o: 1\r\n\r"))
+		assert.Nil(t, conn.lastNames())
+
+		conn.record([]byte("\nbody"))
+		assert.Equal(t, []string{"x-foo"}, conn.lastNames())
+	})
+
+	t.Run("bare lf", func(t *testing.T) {
+		conn := newConn()
+
+		conn.recording = true
+		conn.record([]byte("HTTP/1.1 200 OK\nx-foo: 1\n\n"))
+
+		assert.Equal(t, []string{"x-foo"}, conn.lastNames())
+	})
+
+	t.Run("informational response", func(t *testing.T) {
+		conn := newConn()
+
+		conn.recording = true
+		conn.record([]byte("HTTP/1.1 100 Continue\r\n" +
+			"x-early: 1\r\n" +
+			"\r\n" +
+			"HTTP/1.1 200 OK\r\n" +
+			"x-final: 1\r\n" +
+			"\r\n"))
+
+		assert.Equal(t, []string{"x-final"}, conn.lastNames())
+	})
+
+	t.Run("next request", func(t *testing.T) {
+		conn := newConn()
+
+		conn.recording = true
+		conn.record([]byte("HTTP/1.1 200 OK\r\nx-first: 1\r\n\r\n"))
+		assert.Equal(t, []string{"x-first"}, conn.lastNames())
+
+		// Should ignore body
+		conn.record([]byte("HTTP/1.1 200 OK\r\nx-body: 1\r\n\r\n"))
+		assert.Equal(t, []string{"x-first"}, conn.lastNames())
+
+		_, err := conn.Write([]byte("GET / HTTP/1.1\r\n"))
+		assert.NoError(t, err)
+		assert.Nil(t, conn.lastNames())
+
+		conn.record([]byte("HTTP/1.1 200 OK\r\nx-second: 1\r\n\r\n"))
+		assert.Equal(t, []string{"x-second"}, conn.lastNames())
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		conn := newConn()
+
+		conn.recording = true
+		conn.record(make([]byte, maxRawHeaderBytes+1))
+
+		assert.False(t, conn.recording)
+		assert.Nil(t, conn.lastNames())
+	})
+}
+
+func TestRawHeader_Build(t *testing.T) {
+	header := http.Header{
+		"X-Foo":        {"1", "2"},
+		"Content-Type": {"text/plain"},
+	}
+
+	raw := rawHeader(header, []string{
+		"x-foo", "X-FOO", "content-type", "Content-Encoding",
+	})
+
+	assert.Equal(t, http.Header{
+		"x-foo":        {"1"},
+		"X-FOO":        {"2"},
+		"content-type": {"text/plain"},
+	}, raw)
+}
@@ -0,0 +1,163 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// RecordingHandler implements AssertionHandler that records every assertion,
+// both succeeded and failed.
+//
+// Records can be retrieved using Records and serialized to JSON, e.g. to
+// compare them with a golden snapshot and catch unintended changes in
+// performed assertions.
+//
+// Records don't include assertion durations, so that they're stable between
+// runs. Values are stored as JSON snapshots taken at the moment of assertion;
+// values that can't be encoded to JSON are stored as formatted strings.
+//
+// RecordingHandler doesn't report failures to testing.T by itself. To do it,
+// set Handler field, e.g. to DefaultAssertionHandler.
+//
+// Example:
+//
+//	recorder := httpexpect.NewRecordingHandler()
+//
+//	recorder.Handler = &httpexpect.DefaultAssertionHandler{
+//		Formatter: &httpexpect.DefaultFormatter{},
+//		Reporter:  httpexpect.NewAssertReporter(t),
+//	}
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		TestName:         t.Name(),
+//		BaseURL:          "http://example.com",
+//		AssertionHandler: recorder,
+//	})
+//
+//	// run assertions ...
+//
+//	snapshot, _ := json.MarshalIndent(recorder.Records(), "", "  ")
+type RecordingHandler struct {
+	// If non-nil, every assertion is also passed to this handler.
+	Handler AssertionHandler
+
+	mu      sync.Mutex
+	records []AssertionRecord
+}
+
+// AssertionRecord describes single assertion recorded by RecordingHandler.
+//
+// For succeeded assertion, only TestName, RequestName, Path, and Passed
+// are set.
+type AssertionRecord struct {
+	// Name of the running test, from AssertionContext.TestName
+	TestName string `json:"test_name,omitempty"`
+
+	// Name of request, from AssertionContext.RequestName
+	RequestName string `json:"request_name,omitempty"`
+
+	// Chain of nested assertion names, from AssertionContext.Path
+	Path []string `json:"path"`
+
+	// Whether assertion succeeded
+	Passed bool `json:"passed"`
+
+	// Failure severity, e.g. "SeverityError"
+	Severity string `json:"severity,omitempty"`
+
+	// Failure type, e.g. "AssertEqual"
+	Type string `json:"type,omitempty"`
+
+	// Failure values; nil if not present in AssertionFailure
+	Actual    json.RawMessage `json:"actual,omitempty"`
+	Expected  json.RawMessage `json:"expected,omitempty"`
+	Reference json.RawMessage `json:"reference,omitempty"`
+	Delta     json.RawMessage `json:"delta,omitempty"`
+
+	// Failure error messages
+	Errors []string `json:"errors,omitempty"`
+}
+
+// NewRecordingHandler returns a new empty RecordingHandler.
+func NewRecordingHandler() *RecordingHandler {
+	return &RecordingHandler{}
+}
+
+// Success implements AssertionHandler.Success.
+func (h *RecordingHandler) Success(ctx *AssertionContext) {
+	rec := newAssertionRecord(ctx)
+	rec.Passed = true
+
+	h.mu.Lock()
+	h.records = append(h.records, rec)
+	h.mu.Unlock()
+
+	if h.Handler != nil {
+		h.Handler.Success(ctx)
+	}
+}
+
+// Failure implements AssertionHandler.Failure.
+func (h *RecordingHandler) Failure(ctx *AssertionContext, failure *AssertionFailure) {
+	rec := newAssertionRecord(ctx)
+
+	rec.Severity = failure.Severity.String()
+	rec.Type = failure.Type.String()
+
+	rec.Actual = recordValue(failure.Actual)
+	rec.Expected = recordValue(failure.Expected)
+	rec.Reference = recordValue(failure.Reference)
+	rec.Delta = recordValue(failure.Delta)
+
+	for _, err := range failure.Errors {
+		if !refIsNil(err) {
+			rec.Errors = append(rec.Errors, err.Error())
+		}
+	}
+
+	h.mu.Lock()
+	h.records = append(h.records, rec)
+	h.mu.Unlock()
+
+	if h.Handler != nil {
+		h.Handler.Failure(ctx, failure)
+	}
+}
+
+// Records returns a copy of all assertions recorded so far, in order.
+func (h *RecordingHandler) Records() []AssertionRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]AssertionRecord{}, h.records...)
+}
+
+// Reset removes all recorded assertions.
+func (h *RecordingHandler) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.records = nil
+}
+
+func newAssertionRecord(ctx *AssertionContext) AssertionRecord {
+	return AssertionRecord{
+		TestName:    ctx.TestName,
+		RequestName: ctx.RequestName,
+		Path:        append([]string{}, ctx.Path...),
+	}
+}
+
+func recordValue(value *AssertionValue) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+
+	if b, err := json.Marshal(value.Value); err == nil {
+		return b
+	}
+
+	b, _ := json.Marshal(fmt.Sprintf("%v", value.Value))
+	return b
+}
@@ -0,0 +1,157 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordingHandler_Basic(t *testing.T) {
+	recorder := NewRecordingHandler()
+
+	config := Config{
+		TestName:         "TestFoo",
+		AssertionHandler: recorder,
+	}
+
+	NewValueC(config, 123).Number().IsEqual(123)
+	NewValueC(config, "foo").String().IsEqual("bar")
+
+	records := recorder.Records()
+	require.NotEqual(t, 0, len(records))
+
+	var passed, failed []AssertionRecord
+	for _, rec := range records {
+		assert.Equal(t, "TestFoo", rec.TestName)
+		assert.NotEqual(t, 0, len(rec.Path))
+
+		if rec.Passed {
+			passed = append(passed, rec)
+		} else {
+			failed = append(failed, rec)
+		}
+	}
+
+	assert.NotEqual(t, 0, len(passed))
+	require.Equal(t, 1, len(failed))
+
+	assert.Equal(t, []string{"Value()", "String()", "IsEqual()"}, failed[0].Path)
+	assert.Equal(t, "SeverityError", failed[0].Severity)
+	assert.Equal(t, "AssertEqual", failed[0].Type)
+	assert.JSONEq(t, `"foo"`, string(failed[0].Actual))
+	assert.JSONEq(t, `"bar"`, string(failed[0].Expected))
+	assert.Nil(t, failed[0].Reference)
+	assert.Nil(t, failed[0].Delta)
+	assert.Equal(t, []string{"expected: strings are equal"}, failed[0].Errors)
+}
+
+func TestRecordingHandler_Handler(t *testing.T) {
+	recorder := NewRecordingHandler()
+
+	handler := &mockAssertionHandler{}
+	recorder.Handler = handler
+
+	config := Config{
+		TestName:         "TestFoo",
+		AssertionHandler: recorder,
+	}
+
+	NewValueC(config, 123).Number().IsEqual(123)
+	assert.NotNil(t, handler.ctx)
+	assert.Nil(t, handler.failure)
+
+	NewValueC(config, 123).Number().IsEqual(456)
+	assert.NotNil(t, handler.ctx)
+	assert.NotNil(t, handler.failure)
+}
+
+func TestRecordingHandler_Values(t *testing.T) {
+	recorder := NewRecordingHandler()
+
+	ctx := &AssertionContext{
+		TestName:    "TestFoo",
+		RequestName: "req",
+		Path:        []string{"foo", "bar"},
+	}
+
+	recorder.Failure(ctx, &AssertionFailure{
+		Type:      AssertEqual,
+		Severity:  SeverityLog,
+		Actual:    &AssertionValue{nil},
+		Expected:  &AssertionValue{map[string]int{"a": 1}},
+		Reference: &AssertionValue{func() {}},
+		Delta:     &AssertionValue{0.5},
+		Errors: []error{
+			errors.New("foo"),
+			nil,
+			errors.New("bar"),
+		},
+	})
+
+	ctx.Path[0] = "baz"
+
+	records := recorder.Records()
+	require.Equal(t, 1, len(records))
+
+	rec := records[0]
+
+	assert.Equal(t, "req", rec.RequestName)
+	assert.Equal(t, []string{"foo", "bar"}, rec.Path)
+	assert.False(t, rec.Passed)
+	assert.Equal(t, "SeverityLog", rec.Severity)
+	assert.Equal(t, "AssertEqual", rec.Type)
+	assert.JSONEq(t, `null`, string(rec.Actual))
+	assert.JSONEq(t, `{"a":1}`, string(rec.Expected))
+	assert.Contains(t, string(rec.Reference), "0x")
+	assert.JSONEq(t, `0.5`, string(rec.Delta))
+	assert.Equal(t, []string{"foo", "bar"}, rec.Errors)
+}
+
+func TestRecordingHandler_JSON(t *testing.T) {
+	recorder := NewRecordingHandler()
+
+	config := Config{
+		TestName:         "TestFoo",
+		AssertionHandler: recorder,
+	}
+
+	NewValueC(config, 123).Number().IsEqual(456)
+
+	records := recorder.Records()
+
+	b, err := json.Marshal(records)
+	require.NoError(t, err)
+
+	var decoded []AssertionRecord
+	err = json.Unmarshal(b, &decoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, len(records), len(decoded))
+
+	b2, err := json.Marshal(decoded)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(b), string(b2))
+}
+
+func TestRecordingHandler_Reset(t *testing.T) {
+	recorder := NewRecordingHandler()
+
+	config := Config{
+		AssertionHandler: recorder,
+	}
+
+	NewValueC(config, 123).Number().IsEqual(123)
+
+	records := recorder.Records()
+	assert.NotEqual(t, 0, len(records))
+
+	recorder.Reset()
+	assert.Equal(t, 0, len(recorder.Records()))
+
+	// Should not affect previously returned records
+	assert.NotEqual(t, 0, len(records))
+}
@@ -2,6 +2,8 @@ package httpexpect
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,11 +14,12 @@ import (
 // package. Failures are non-fatal with this reporter.
 type AssertReporter struct {
 	backend *assert.Assertions
+	t       assert.TestingT
 }
 
 // NewAssertReporter returns a new AssertReporter object.
 func NewAssertReporter(t assert.TestingT) *AssertReporter {
-	return &AssertReporter{assert.New(t)}
+	return &AssertReporter{assert.New(t), t}
 }
 
 // Errorf implements Reporter.Errorf.
@@ -28,11 +31,12 @@ func (r *AssertReporter) Errorf(message string, args ...interface{}) {
 // package. Failures are fatal with this reporter.
 type RequireReporter struct {
 	backend *require.Assertions
+	t       require.TestingT
 }
 
 // NewRequireReporter returns a new RequireReporter object.
 func NewRequireReporter(t require.TestingT) *RequireReporter {
-	return &RequireReporter{require.New(t)}
+	return &RequireReporter{require.New(t), t}
 }
 
 // Errorf implements Reporter.Errorf.
@@ -72,3 +76,62 @@ func NewPanicReporter() *PanicReporter {
 func (r *PanicReporter) Errorf(message string, args ...interface{}) {
 	panic(fmt.Sprintf(message, args...))
 }
+
+// softReporter collects failures and passes them to the wrapped reporter
+// as a single consolidated report when flushed.
+// Used when Config.SoftAssertions is enabled.
+type softReporter struct {
+	mu       sync.Mutex
+	backend  Reporter
+	messages []string
+}
+
+// newSoftReporter wraps reporter into softReporter, if it's possible to
+// flush it at the end of the test. Otherwise, returns nil.
+func newSoftReporter(reporter Reporter) *softReporter {
+	var t interface{} = reporter
+
+	switch r := reporter.(type) {
+	case *AssertReporter:
+		t = r.t
+	case *RequireReporter:
+		t = r.t
+	case *FatalReporter:
+		t = r.backend
+	}
+
+	tb, ok := t.(interface{ Cleanup(func()) })
+	if !ok {
+		return nil
+	}
+
+	r := &softReporter{backend: reporter}
+	tb.Cleanup(r.flush)
+
+	return r
+}
+
+// Errorf implements Reporter.Errorf.
+func (r *softReporter) Errorf(message string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.messages = append(r.messages, fmt.Sprintf(message, args...))
+}
+
+func (r *softReporter) flush() {
+	r.mu.Lock()
+	messages := r.messages
+	r.messages = nil
+	r.mu.Unlock()
+
+	switch len(messages) {
+	case 0:
+		return
+	case 1:
+		r.backend.Errorf("%s", messages[0])
+	default:
+		r.backend.Errorf("%d assertions failed:\n\n%s",
+			len(messages), strings.Join(messages, "\n\n"))
+	}
+}
@@ -1,9 +1,11 @@
 package httpexpect
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockT struct {
@@ -63,3 +65,74 @@ func TestReporter_PanicReporter(t *testing.T) {
 		reporter.Errorf("test")
 	})
 }
+
+type mockCleanupT struct {
+	messages []string
+	cleanups []func()
+}
+
+func (m *mockCleanupT) Errorf(format string, args ...interface{}) {
+	m.messages = append(m.messages, fmt.Sprintf(format, args...))
+}
+
+func (m *mockCleanupT) Cleanup(fn func()) {
+	m.cleanups = append(m.cleanups, fn)
+}
+
+func (m *mockCleanupT) runCleanups() {
+	for _, fn := range m.cleanups {
+		fn()
+	}
+}
+
+func TestReporter_SoftReporter(t *testing.T) {
+	t.Run("no failures", func(t *testing.T) {
+		mockBackend := &mockCleanupT{}
+
+		reporter := newSoftReporter(NewAssertReporter(mockBackend))
+		require.NotNil(t, reporter)
+
+		mockBackend.runCleanups()
+		assert.Empty(t, mockBackend.messages)
+	})
+
+	t.Run("single failure", func(t *testing.T) {
+		mockBackend := &mockCleanupT{}
+
+		reporter := newSoftReporter(NewAssertReporter(mockBackend))
+		require.NotNil(t, reporter)
+
+		reporter.Errorf("first %d", 1)
+		assert.Empty(t, mockBackend.messages)
+
+		mockBackend.runCleanups()
+		require.Equal(t, 1, len(mockBackend.messages))
+		assert.Contains(t, mockBackend.messages[0], "first 1")
+	})
+
+	t.Run("multiple failures", func(t *testing.T) {
+		mockBackend := &mockCleanupT{}
+
+		reporter := newSoftReporter(mockBackend)
+		require.NotNil(t, reporter)
+
+		reporter.Errorf("first")
+		reporter.Errorf("second")
+		assert.Empty(t, mockBackend.messages)
+
+		mockBackend.runCleanups()
+		assert.Equal(t,
+			[]string{"2 assertions failed:\n\nfirst\n\nsecond"},
+			mockBackend.messages)
+
+		// Should not report same failures twice
+		reporter.flush()
+		assert.Equal(t, 1, len(mockBackend.messages))
+	})
+
+	t.Run("no cleanup", func(t *testing.T) {
+		assert.Nil(t, newSoftReporter(newMockReporter(t)))
+		assert.Nil(t, newSoftReporter(NewAssertReporter(&mockAssertT{})))
+		assert.Nil(t, newSoftReporter(NewPanicReporter()))
+	})
+}
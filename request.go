@@ -3,27 +3,37 @@ package httpexpect
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"io/ioutil"
+	"mime"
 	"mime/multipart"
 	"net"
 	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
 	"net/url"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/ajg/form"
 	"github.com/fatih/structs"
 	"github.com/google/go-querystring/query"
 	"github.com/gorilla/websocket"
 	"github.com/imkira/go-interpol"
+	"golang.org/x/net/http/httpguts"
+	"gopkg.in/yaml.v2"
 )
 
 // Request provides methods to incrementally build http.Request object,
@@ -37,28 +47,68 @@ type Request struct {
 	redirectPolicy RedirectPolicy
 	maxRedirects   int
 
+	proxyURL   *url.URL
+	unixSocket string
+
+	tlsClientCert *tls.Certificate
+	tlsCertSetter string
+
+	transportCleanup func()
+
 	retryPolicy   RetryPolicy
+	retryPolicyFn func(*http.Response, error) bool
+	retryBackoff  func(attempt int) time.Duration
 	maxRetries    int
 	minRetryDelay time.Duration
 	maxRetryDelay time.Duration
 	sleepFn       func(d time.Duration) <-chan time.Time
+	attempts      int
+
+	timeout       time.Duration
+	timeoutSetter string
+	deadline      time.Time
+
+	// deadline of current attempt derived from timeout
+	timeoutDeadline time.Time
+
+	maxResponseSize int64
+
+	noBodyBuffering bool
+
+	tracer *traceRecorder
 
-	timeout time.Duration
+	rawHeaders *rawHeaderRecorder
 
 	httpReq *http.Request
 	path    string
 	query   url.Values
 
-	form      url.Values
-	formbuf   *bytes.Buffer
-	multipart *multipart.Writer
+	querySetter string
+	rawQuery    string
+	rawQuerySet bool
 
+	headerFuncs []headerFunc
+
+	pathSetter     string
+	pathTimeLayout string
+	absoluteURL    *url.URL
+
+	form         url.Values
+	formbuf      *bytes.Buffer
+	multipart    *multipart.Writer
+	multipartOut *switchWriter
+	fileStreams  []fileStream
+
+	methodSetter string
 	bodySetter   string
 	typeSetter   string
 	forceType    bool
 	expectCalled bool
 
-	wsUpgrade bool
+	gzip bool
+
+	wsUpgrade      bool
+	wsSubprotocols []string
 
 	transformers []func(*http.Request)
 	matchers     []func(*Response)
@@ -127,6 +177,14 @@ func newRequest(
 		sleepFn: func(d time.Duration) <-chan time.Time {
 			return time.After(d)
 		},
+
+		timeout:       config.Timeout,
+		timeoutSetter: "Config.Timeout",
+
+		tlsClientCert: config.TLSClientCert,
+		tlsCertSetter: "Config.TLSClientCert",
+
+		pathTimeLayout: time.RFC3339,
 	}
 
 	opChain := r.chain.enter("")
@@ -154,7 +212,7 @@ func (r *Request) initPath(opChain *chain, path string, pathargs ...interface{})
 					},
 				})
 			} else {
-				mustWrite(w, fmt.Sprint(pathargs[n]))
+				mustWrite(w, formatPathValue(pathargs[n], r.pathTimeLayout))
 			}
 		} else {
 			mustWrite(w, "{")
@@ -186,10 +244,28 @@ func (r *Request) initReq(opChain *chain, method string) {
 		opChain.fail(AssertionFailure{
 			Type: AssertOperation,
 			Errors: []error{
-				errors.New("failed to create http request"),
+				fmt.Errorf("failed to create http request (method %q, url %q)",
+					method, r.config.BaseURL),
 				err,
 			},
 		})
+		return
+	}
+
+	if httpReq == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to create http request (method %q, url %q)",
+					method, r.config.BaseURL),
+				errors.New("RequestFactory returned nil request"),
+			},
+		})
+		return
+	}
+
+	if httpReq.Header == nil {
+		httpReq.Header = make(http.Header)
 	}
 
 	r.httpReq = httpReq
@@ -207,14 +283,233 @@ func (r *Request) Alias(name string) *Request {
 	return r
 }
 
+// Clone returns a copy of request, that can be modified independently.
+//
+// Headers, cookies, query and form parameters, body, and all other
+// settings are copied, so that calls to WithXXX methods on the copy don't
+// affect original request, and vice versa. Both requests can be then
+// sent using Expect.
+//
+// If request body was set from a reader, e.g. using WithChunked, the
+// reader is read into memory, and both requests get their own readers
+// over the buffered contents.
+//
+// Bodies set using WithFileStream can't be buffered by design, so Clone
+// reports failure if it is used. Clone also reports failure if it's
+// called after Expect.
+//
+// Example:
+//
+//	base := NewRequestC(config, "POST", "/users").
+//		WithHeader("Authorization", "Bearer "+token)
+//
+//	base.Clone().WithJSON(user1).Expect().Status(http.StatusCreated)
+//	base.Clone().WithJSON(user2).Expect().Status(http.StatusCreated)
+func (r *Request) Clone() *Request {
+	opChain := r.chain.enter("Clone()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return &Request{config: r.config, chain: opChain.clone()}
+	}
+
+	if !r.checkOrder(opChain, "Clone()") {
+		return &Request{config: r.config, chain: opChain.clone()}
+	}
+
+	if len(r.fileStreams) != 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New(
+					"Clone() can't be used with WithFileStream():" +
+						" streamed body can't be copied"),
+			},
+		})
+		return &Request{config: r.config, chain: opChain.clone()}
+	}
+
+	clone := &Request{
+		config: r.config,
+		chain:  opChain.clone(),
+
+		redirectPolicy: r.redirectPolicy,
+		maxRedirects:   r.maxRedirects,
+
+		proxyURL:   r.proxyURL,
+		unixSocket: r.unixSocket,
+
+		tlsClientCert: r.tlsClientCert,
+		tlsCertSetter: r.tlsCertSetter,
+
+		retryPolicy:   r.retryPolicy,
+		retryPolicyFn: r.retryPolicyFn,
+		retryBackoff:  r.retryBackoff,
+		maxRetries:    r.maxRetries,
+		minRetryDelay: r.minRetryDelay,
+		maxRetryDelay: r.maxRetryDelay,
+		sleepFn:       r.sleepFn,
+
+		timeout:       r.timeout,
+		timeoutSetter: r.timeoutSetter,
+		deadline:      r.deadline,
+
+		maxResponseSize: r.maxResponseSize,
+
+		noBodyBuffering: r.noBodyBuffering,
+
+		httpReq: r.httpReq.Clone(r.httpReq.Context()),
+		path:    r.path,
+		query:   cloneValues(r.query),
+
+		querySetter: r.querySetter,
+		rawQuery:    r.rawQuery,
+		rawQuerySet: r.rawQuerySet,
+
+		pathSetter:     r.pathSetter,
+		pathTimeLayout: r.pathTimeLayout,
+
+		headerFuncs: append(([]headerFunc)(nil), r.headerFuncs...),
+
+		form: cloneValues(r.form),
+
+		methodSetter: r.methodSetter,
+		bodySetter:   r.bodySetter,
+		typeSetter:   r.typeSetter,
+		forceType:    r.forceType,
+
+		gzip: r.gzip,
+
+		wsUpgrade:      r.wsUpgrade,
+		wsSubprotocols: append(([]string)(nil), r.wsSubprotocols...),
+
+		transformers: append(([]func(*http.Request))(nil), r.transformers...),
+		matchers:     append(([]func(*Response))(nil), r.matchers...),
+	}
+
+	if r.tracer != nil {
+		clone.tracer = &traceRecorder{}
+	}
+
+	if r.rawHeaders != nil {
+		clone.rawHeaders = &rawHeaderRecorder{}
+	}
+
+	if r.absoluteURL != nil {
+		u := *r.absoluteURL
+		clone.absoluteURL = &u
+	}
+
+	if r.multipart != nil {
+		if !r.cloneMultipart(opChain, clone) {
+			return &Request{config: r.config, chain: opChain.clone()}
+		}
+	} else if r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
+		if !r.cloneBody(opChain, clone) {
+			return &Request{config: r.config, chain: opChain.clone()}
+		}
+	}
+
+	// chain inherited request pointer from original request, replace it;
+	// chain is not shared yet, so no locking is needed
+	clone.chain.context.Request = clone
+
+	return clone
+}
+
+func (r *Request) cloneBody(opChain *chain, clone *Request) bool {
+	b, err := ioutil.ReadAll(r.httpReq.Body)
+	if err != nil {
+		// put back what was read, so that original request is not affected
+		r.httpReq.Body = ioutil.NopCloser(
+			io.MultiReader(bytes.NewReader(b), r.httpReq.Body))
+
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to read request body"),
+				err,
+			},
+		})
+		return false
+	}
+
+	r.httpReq.Body = ioutil.NopCloser(bytes.NewReader(b))
+	clone.httpReq.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+	return true
+}
+
+func (r *Request) cloneMultipart(opChain *chain, clone *Request) bool {
+	clone.formbuf = bytes.NewBuffer(append([]byte(nil), r.formbuf.Bytes()...))
+	clone.multipartOut = &switchWriter{ioutil.Discard}
+	clone.multipart = multipart.NewWriter(clone.multipartOut)
+
+	err := clone.multipart.SetBoundary(r.multipart.Boundary())
+
+	// if some parts were already written, create a dummy part, so that
+	// writer will prepend delimiter to the next part
+	if err == nil && r.formbuf.Len() != 0 {
+		_, err = clone.multipart.CreatePart(nil)
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to copy multipart form"),
+				err,
+			},
+		})
+		return false
+	}
+
+	clone.multipartOut.w = clone.formbuf
+	clone.httpReq.Body = ioutil.NopCloser(clone.formbuf)
+
+	return true
+}
+
+func cloneValues(v url.Values) url.Values {
+	if v == nil {
+		return nil
+	}
+
+	c := make(url.Values, len(v))
+	for key, values := range v {
+		c[key] = append(([]string)(nil), values...)
+	}
+
+	return c
+}
+
 // WithName sets convenient request name.
 // This name will be included in assertion reports for this request.
 // It does not affect assertion chain path, inlike Alias.
 //
+// The name is stored in AssertionContext.RequestName of every assertion
+// on this request and on objects derived from it (response, body, JSON
+// values, etc.), so it is available to formatters, reporters, and
+// assertion handlers. AssertionContext.TestName is not changed.
+//
+// It is useful to distinguish failures of similar requests sent in a loop
+// within one test.
+//
 // Example:
 //
 //	req := NewRequestC(config, "POST", "/api/login")
 //	req.WithName("Login Request")
+//
+//	for i, user := range users {
+//		e.POST("/api/login").
+//			WithName(fmt.Sprintf("Login %d", i)).
+//			WithJSON(user).
+//			Expect().
+//			Status(http.StatusOK)
+//	}
 func (r *Request) WithName(name string) *Request {
 	opChain := r.chain.enter("WithName()")
 	defer opChain.leave()
@@ -404,21 +699,29 @@ func (r *Request) WithHandler(handler http.Handler) *Request {
 	return r
 }
 
-// WithContext sets the context.
+// WithProxy configures request to be sent via given proxy.
 //
-// Config.Context will be overwritten.
+// Proxy URL should be absolute and use "http", "https", "socks5", or "socks5h"
+// scheme. Proxy from WithProxy overrides proxy settings from environment
+// (HTTP_PROXY, HTTPS_PROXY, NO_PROXY) and from Client transport.
 //
-// Any retries will stop after one is cancelled.
-// If the intended behavior is to continue any further retries, use WithTimeout.
+// Only this request is affected: Client and its Transport are copied and the
+// copies are modified, so other requests continue to use original Client.
+// Connections of the copied Transport are not reused by other requests and
+// are closed when response body is read or closed.
+//
+// This method can be used only if Client interface points to *http.Client
+// struct with nil Transport or Transport of type *http.Transport.
+// For websocket requests, WebsocketDialer should point to *websocket.Dialer,
+// and it is copied in the same way.
 //
 // Example:
 //
-//	ctx, _ = context.WithTimeout(context.Background(), time.Duration(3)*time.Second)
 //	req := NewRequestC(config, "GET", "/path")
-//	req.WithContext(ctx)
+//	req.WithProxy("http://proxy.example.com:3128")
 //	req.Expect().Status(http.StatusOK)
-func (r *Request) WithContext(ctx context.Context) *Request {
-	opChain := r.chain.enter("WithContext()")
+func (r *Request) WithProxy(proxyURL string) *Request {
+	opChain := r.chain.enter("WithProxy()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -428,114 +731,79 @@ func (r *Request) WithContext(ctx context.Context) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithContext()") {
+	if !r.checkOrder(opChain, "WithProxy()") {
 		return r
 	}
 
-	if ctx == nil {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertUsage,
 			Errors: []error{
-				errors.New("unexpected nil argument"),
+				fmt.Errorf("invalid proxy URL %q", proxyURL),
+				err,
 			},
 		})
 		return r
 	}
 
-	r.config.Context = ctx
-
-	return r
-}
-
-// WithTimeout sets a timeout duration for the request.
-//
-// Will attach to the request a context.WithTimeout around the Config.Context
-// or any context set WithContext. If these are nil, the new context will be
-// created on top of a context.Background().
-//
-// Any retries will continue after one is cancelled.
-// If the intended behavior is to stop any further retries, use WithContext or
-// Config.Context.
-//
-// Example:
-//
-//	req := NewRequestC(config, "GET", "/path")
-//	req.WithTimeout(time.Duration(3)*time.Second)
-//	req.Expect().Status(http.StatusOK)
-func (r *Request) WithTimeout(timeout time.Duration) *Request {
-	opChain := r.chain.enter("WithTimeout()")
-	defer opChain.leave()
-
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	if opChain.failed() {
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("invalid proxy URL %q", proxyURL),
+				fmt.Errorf("unsupported proxy scheme %q", u.Scheme),
+			},
+		})
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithTimeout()") {
+	if u.Host == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("invalid proxy URL %q", proxyURL),
+				errors.New("missing proxy host"),
+			},
+		})
 		return r
 	}
 
-	r.timeout = timeout
+	r.proxyURL = u
 
 	return r
 }
 
-// RedirectPolicy defines how redirection responses are handled.
-//
-// Status codes 307, 308 require resending body. They are followed only if
-// redirect policy is FollowAllRedirects.
-//
-// Status codes 301, 302, 303 don't require resending body. On such redirect,
-// http.Client automatically switches HTTP method to GET, if it's not GET or
-// HEAD already. These redirects are followed if redirect policy is either
-// FollowAllRedirects or FollowRedirectsWithoutBody.
+// WithUnixSocket configures request to be sent via given Unix domain socket.
 //
-// Default redirect policy is FollowRedirectsWithoutBody.
-type RedirectPolicy int
-
-const (
-	// indicates that WithRedirectPolicy was not called
-	defaultRedirectPolicy RedirectPolicy = iota
-
-	// DontFollowRedirects forbids following any redirects.
-	// Redirection response is returned to the user and can be inspected.
-	DontFollowRedirects
-
-	// FollowAllRedirects allows following any redirects, including those
-	// which require resending body.
-	FollowAllRedirects
-
-	// FollowRedirectsWithoutBody allows following only redirects which
-	// don't require resending body.
-	// If redirect requires resending body, it's not followed, and redirection
-	// response is returned instead.
-	FollowRedirectsWithoutBody
-)
-
-// WithRedirectPolicy sets policy for redirection response handling.
+// Connection is established to the socket at given path instead of the host
+// from request URL, but otherwise request is sent as usual; in particular,
+// Host header is still taken from request URL (or WithHost).
+// This is handy for services that listen on Unix socket, like sidecar and
+// admin endpoints.
 //
-// How redirect is handled depends on both response status code and
-// redirect policy. See comments for RedirectPolicy for details.
+// If path doesn't exist or is not a socket when request is sent, failure
+// is reported. WithUnixSocket can't be combined with WithProxy.
 //
-// Default redirect policy is defined by Client implementation.
-// Default behavior of http.Client corresponds to FollowRedirectsWithoutBody.
+// Only this request is affected: Client and its Transport are copied and the
+// copies are modified, so other requests continue to use original Client.
+// Connections of the copied Transport are closed when response body is read
+// or closed.
 //
-// This method can be used only if Client interface points to
-// *http.Client struct, since we rely on it in redirect handling.
+// This method can be used only if Client interface points to *http.Client
+// struct with nil Transport or Transport of type *http.Transport.
+// For websocket requests, WebsocketDialer should point to *websocket.Dialer,
+// and it is copied in the same way.
 //
 // Example:
 //
-//	req1 := NewRequestC(config, "POST", "/path")
-//	req1.WithRedirectPolicy(FollowAllRedirects)
-//	req1.Expect().Status(http.StatusOK)
-//
-//	req2 := NewRequestC(config, "POST", "/path")
-//	req2.WithRedirectPolicy(DontFollowRedirects)
-//	req2.Expect().Status(http.StatusPermanentRedirect)
-func (r *Request) WithRedirectPolicy(policy RedirectPolicy) *Request {
-	opChain := r.chain.enter("WithRedirectPolicy()")
+//	req := NewRequestC(config, "GET", "http://localhost/metrics")
+//	req.WithUnixSocket("/run/myservice/admin.sock")
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithUnixSocket(path string) *Request {
+	opChain := r.chain.enter("WithUnixSocket()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -545,32 +813,53 @@ func (r *Request) WithRedirectPolicy(policy RedirectPolicy) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithRedirectPolicy()") {
+	if !r.checkOrder(opChain, "WithUnixSocket()") {
 		return r
 	}
 
-	r.redirectPolicy = policy
+	if path == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty socket path"),
+			},
+		})
+		return r
+	}
+
+	r.unixSocket = path
 
 	return r
 }
 
-// WithMaxRedirects sets maximum number of redirects to follow.
+// WithTLSClientCert sets client certificate used for mutual TLS.
 //
-// If the number of redirects exceedes this limit, request is failed.
+// It overrides Config.TLSClientCert and any certificates configured in
+// TLSClientConfig of Client transport.
 //
-// Default limit is defined by Client implementation.
-// Default behavior of http.Client corresponds to maximum of 10-1 redirects.
+// Only this request is affected: Client and its Transport are copied and the
+// copies are modified, so other requests continue to use original Client.
+// Since the request uses its own transport, it doesn't reuse connections
+// established by other requests, and its connections are closed when
+// response body is read or closed. To share connections between requests,
+// use Config.TLSClientCert instead.
 //
-// This method can be used only if Client interface points to
-// *http.Client struct, since we rely on it in redirect handling.
+// WithClient can be used together with WithTLSClientCert; in this case
+// certificate is installed into the transport of the client passed to
+// WithClient. Just like WithProxy, this method can be used only if Client
+// interface points to *http.Client struct with nil Transport or Transport
+// of type *http.Transport. For websocket requests, WebsocketDialer should
+// point to *websocket.Dialer.
 //
 // Example:
 //
-//	req1 := NewRequestC(config, "POST", "/path")
-//	req1.WithMaxRedirects(1)
-//	req1.Expect().Status(http.StatusOK)
-func (r *Request) WithMaxRedirects(maxRedirects int) *Request {
-	opChain := r.chain.enter("WithMaxRedirects()")
+//	cert, _ := tls.LoadX509KeyPair("client.crt", "client.key")
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithTLSClientCert(cert)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithTLSClientCert(cert tls.Certificate) *Request {
+	opChain := r.chain.enter("WithTLSClientCert()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -580,74 +869,48 @@ func (r *Request) WithMaxRedirects(maxRedirects int) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithMaxRedirects()") {
+	if !r.checkOrder(opChain, "WithTLSClientCert()") {
 		return r
 	}
 
-	if maxRedirects < 0 {
+	if len(cert.Certificate) == 0 {
 		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{maxRedirects},
+			Type: AssertUsage,
 			Errors: []error{
-				errors.New("invalid negative argument"),
+				errors.New("unexpected empty certificate chain"),
 			},
 		})
 		return r
 	}
 
-	r.maxRedirects = maxRedirects
+	r.tlsClientCert = &cert
+	r.tlsCertSetter = "WithTLSClientCert()"
 
 	return r
 }
 
-// RetryPolicy defines how failed requests are retried.
+// WithContext sets the context.
 //
-// Whether a request is retried depends on error type (if any), response
-// status code (if any), and retry policy.
-type RetryPolicy int
-
-const (
-	// DontRetry disables retrying at all.
-	DontRetry RetryPolicy = iota
-
-	// Deprecated: use RetryTimeoutErrors instead.
-	RetryTemporaryNetworkErrors
-
-	// Deprecated: use RetryTimeoutAndServerErrors instead.
-	RetryTemporaryNetworkAndServerErrors
-
-	// RetryTimeoutErrors enables retrying of timeout errors.
-	// Retry happens if Client returns net.Error and its Timeout() method
-	// returns true.
-	RetryTimeoutErrors
-
-	// RetryTimeoutAndServerErrors enables retrying of network timeout errors,
-	// as well as 5xx status codes.
-	RetryTimeoutAndServerErrors
-
-	// RetryAllErrors enables retrying of any error or 4xx/5xx status code.
-	RetryAllErrors
-)
-
-// WithRetryPolicy sets policy for retries.
+// Config.Context will be overwritten.
 //
-// Whether a request is retried depends on error type (if any), response
-// status code (if any), and retry policy.
+// If context is already cancelled or expired when request is about to be
+// sent, Expect fails immediately without sending the request.
 //
-// How much retry attempts happens is defined by WithMaxRetries().
-// How much to wait between attempts is defined by WithRetryDelay().
+// Any retries will stop after one is cancelled.
+// If the intended behavior is to continue any further retries, use WithTimeout.
 //
-// Default retry policy is RetryTimeoutAndServerErrors, but
-// default maximum number of retries is zero, so no retries happen
-// unless WithMaxRetries() is called.
+// WithContext can be combined with WithTimeout. In this case, the request is
+// cancelled when either the context deadline or the timeout expires,
+// whichever comes first.
 //
 // Example:
 //
-//	req := NewRequestC(config, "POST", "/path")
-//	req.WithRetryPolicy(RetryAllErrors)
+//	ctx, _ = context.WithTimeout(context.Background(), time.Duration(3)*time.Second)
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithContext(ctx)
 //	req.Expect().Status(http.StatusOK)
-func (r *Request) WithRetryPolicy(policy RetryPolicy) *Request {
-	opChain := r.chain.enter("WithRetryPolicy()")
+func (r *Request) WithContext(ctx context.Context) *Request {
+	opChain := r.chain.enter("WithContext()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -657,32 +920,44 @@ func (r *Request) WithRetryPolicy(policy RetryPolicy) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithRetryPolicy()") {
+	if !r.checkOrder(opChain, "WithContext()") {
 		return r
 	}
 
-	r.retryPolicy = policy
+	if ctx == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.config.Context = ctx
 
 	return r
 }
 
-// WithMaxRetries sets maximum number of retry attempts.
+// WithTimeout sets a timeout duration for the request.
 //
-// After first request failure, additional retry attempts may happen,
-// depending on the retry policy.
+// Will attach to the request a context.WithTimeout around the Config.Context
+// or any context set WithContext. If these are nil, the new context will be
+// created on top of a context.Background().
 //
-// Setting this to zero disables retries, i.e. only one request is sent.
-// Setting this to N enables retries, and up to N+1 requests may be sent.
+// Overrides Config.Timeout. Zero timeout disables it.
 //
-// Default number of retries is zero, i.e. retries are disabled.
+// Any retries will continue after one is cancelled.
+// If the intended behavior is to stop any further retries, use WithContext,
+// Config.Context, or WithDeadline.
 //
 // Example:
 //
-//	req := NewRequestC(config, "POST", "/path")
-//	req.WithMaxRetries(1)
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithTimeout(time.Duration(3)*time.Second)
 //	req.Expect().Status(http.StatusOK)
-func (r *Request) WithMaxRetries(maxRetries int) *Request {
-	opChain := r.chain.enter("WithMaxRetries()")
+func (r *Request) WithTimeout(timeout time.Duration) *Request {
+	opChain := r.chain.enter("WithTimeout()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -692,40 +967,41 @@ func (r *Request) WithMaxRetries(maxRetries int) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithMaxRetries()") {
-		return r
-	}
-
-	if maxRetries < 0 {
-		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{maxRetries},
-			Errors: []error{
-				errors.New("invalid negative argument"),
-			},
-		})
+	if !r.checkOrder(opChain, "WithTimeout()") {
 		return r
 	}
 
-	r.maxRetries = maxRetries
+	r.timeout = timeout
+	r.timeoutSetter = "WithTimeout()"
 
 	return r
 }
 
-// WithRetryDelay sets minimum and maximum delay between retries.
+// WithDeadline sets an absolute deadline for the request.
 //
-// If multiple retry attempts happen, delay between attempts starts from
-// minDelay and then grows exponentionally until it reaches maxDelay.
+// Unlike WithTimeout, which limits duration of every attempt, deadline
+// is a fixed moment in time shared by all attempts, including retries.
+// Once deadline is exceeded, no more retries are made.
 //
-// Default delay range is [50ms; 5s].
+// Deadline can be combined with WithTimeout or Config.Timeout. In this
+// case, every attempt is cancelled at whichever moment comes first, and
+// failure message tells whether the deadline or the timeout fired.
+//
+// Zero deadline disables it.
 //
 // Example:
 //
-//	req := NewRequestC(config, "POST", "/path")
-//	req.WithRetryDelay(time.Second, time.Minute)
-//	req.Expect().Status(http.StatusOK)
-func (r *Request) WithRetryDelay(minDelay, maxDelay time.Duration) *Request {
-	opChain := r.chain.enter("WithRetryDelay()")
+//	deadline := time.Now().Add(10 * time.Second)
+//
+//	req1 := NewRequestC(config, "GET", "/path1")
+//	req1.WithDeadline(deadline)
+//	req1.Expect().Status(http.StatusOK)
+//
+//	req2 := NewRequestC(config, "GET", "/path2")
+//	req2.WithDeadline(deadline)
+//	req2.Expect().Status(http.StatusOK)
+func (r *Request) WithDeadline(deadline time.Time) *Request {
+	opChain := r.chain.enter("WithDeadline()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -735,51 +1011,32 @@ func (r *Request) WithRetryDelay(minDelay, maxDelay time.Duration) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithRetryDelay()") {
-		return r
-	}
-
-	if !(minDelay <= maxDelay) {
-		opChain.fail(AssertionFailure{
-			Type: AssertValid,
-			Actual: &AssertionValue{
-				[2]time.Duration{minDelay, maxDelay},
-			},
-			Errors: []error{
-				errors.New("invalid delay range"),
-			},
-		})
+	if !r.checkOrder(opChain, "WithDeadline()") {
 		return r
 	}
 
-	r.minRetryDelay = minDelay
-	r.maxRetryDelay = maxDelay
+	r.deadline = deadline
 
 	return r
 }
 
-// WithWebsocketUpgrade enables upgrades the connection to websocket.
+// WithMaxResponseSize sets maximum size of response body, in bytes.
 //
-// At least the following fields are added to the request header:
-//
-//	Upgrade: websocket
-//	Connection: Upgrade
+// If response has Content-Length greater than given size, failure is
+// reported without reading the body. Otherwise, at most given number of
+// bytes is read from response body; if body turns out to be larger,
+// failure is reported when response body is accessed, e.g. by Body()
+// or JSON(), before decoding it.
 //
-// The actual set of header fields is define by the protocol implementation
-// in the gorilla/websocket package.
-//
-// The user should then call the Response.Websocket() method which returns
-// the Websocket instance. This instance can be used to send messages to the
-// server, to inspect the received messages, and to close the websocket.
+// By default, response size is not limited.
 //
 // Example:
 //
 //	req := NewRequestC(config, "GET", "/path")
-//	req.WithWebsocketUpgrade()
-//	ws := req.Expect().Status(http.StatusSwitchingProtocols).Websocket()
-//	defer ws.Disconnect()
-func (r *Request) WithWebsocketUpgrade() *Request {
-	opChain := r.chain.enter("WithWebsocketUpgrade()")
+//	req.WithMaxResponseSize(1 << 20)
+//	req.Expect().JSON()
+func (r *Request) WithMaxResponseSize(size int64) *Request {
+	opChain := r.chain.enter("WithMaxResponseSize()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -789,31 +1046,52 @@ func (r *Request) WithWebsocketUpgrade() *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithWebsocketUpgrade()") {
+	if !r.checkOrder(opChain, "WithMaxResponseSize()") {
 		return r
 	}
 
-	r.wsUpgrade = true
+	if size <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-positive size %d", size),
+			},
+		})
+		return r
+	}
+
+	r.maxResponseSize = size
 
 	return r
 }
 
-// WithWebsocketDialer sets the custom websocket dialer.
+// WithoutBodyBuffering disables buffering of response body.
 //
-// The new dialer overwrites Config.WebsocketDialer. It will be used once to establish
-// the WebSocket connection and receive a response of handshake result.
+// By default, response body is read into memory, so that it can be
+// inspected multiple times, e.g. by calling both Body() and JSON().
+// It's wasteful when the body is large and only status and headers
+// are checked.
+//
+// When buffering is disabled, response body is left as a raw stream.
+// Methods that read body (Body, Text, JSON, Form, etc.) consume it
+// once; the second such call reports "body already consumed" failure.
+// Response.Trailer reads body too, but keeps it in memory until it's
+// consumed by one of these methods.
+// Printers don't print response body. Body of the response returned
+// by Response.Raw() is the same stream.
+//
+// If body is never read, it is closed when Response is garbage
+// collected; you can close it earlier via Response.Raw().Body.Close().
 //
 // Example:
 //
-//	req := NewRequestC(config, "GET", "/path")
-//	req.WithWebsocketUpgrade()
-//	req.WithWebsocketDialer(&websocket.Dialer{
-//	  EnableCompression: false,
-//	})
-//	ws := req.Expect().Status(http.StatusSwitchingProtocols).Websocket()
-//	defer ws.Disconnect()
-func (r *Request) WithWebsocketDialer(dialer WebsocketDialer) *Request {
-	opChain := r.chain.enter("WithWebsocketDialer()")
+//	req := NewRequestC(config, "GET", "/large-file")
+//	req.WithoutBodyBuffering()
+//	resp := req.Expect()
+//	resp.Status(http.StatusOK)
+//	resp.Header("Content-Type").IsEqual("application/octet-stream")
+func (r *Request) WithoutBodyBuffering() *Request {
+	opChain := r.chain.enter("WithoutBodyBuffering()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -823,40 +1101,31 @@ func (r *Request) WithWebsocketDialer(dialer WebsocketDialer) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithWebsocketDialer()") {
-		return r
-	}
-
-	if dialer == nil {
-		opChain.fail(AssertionFailure{
-			Type: AssertUsage,
-			Errors: []error{
-				errors.New("unexpected nil argument"),
-			},
-		})
+	if !r.checkOrder(opChain, "WithoutBodyBuffering()") {
 		return r
 	}
 
-	r.config.WebsocketDialer = dialer
+	r.noBodyBuffering = true
 
 	return r
 }
 
-// WithPath substitutes named parameters in url path.
+// WithTrace enables collecting timestamps of request phases, like DNS lookup,
+// connect, TLS handshake, and receiving first byte of response.
 //
-// value is converted to string using fmt.Sprint(). If there is no named
-// parameter '{key}' in url path, failure is reported.
-//
-// Named parameters are case-insensitive.
+// Timestamps are collected using net/http/httptrace and can be inspected
+// via Response.Trace().
 //
 // Example:
 //
-//	req := NewRequestC(config, "POST", "/repos/{user}/{repo}")
-//	req.WithPath("user", "gavv")
-//	req.WithPath("repo", "httpexpect")
-//	// path will be "/repos/gavv/httpexpect"
-func (r *Request) WithPath(key string, value interface{}) *Request {
-	opChain := r.chain.enter("WithPath()")
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithTrace()
+//
+//	resp := req.Expect()
+//	resp.Trace().TLSHandshake().Lt(100 * time.Millisecond)
+//	resp.Trace().FirstByte().Lt(time.Second)
+func (r *Request) WithTrace() *Request {
+	opChain := r.chain.enter("WithTrace()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -866,53 +1135,41 @@ func (r *Request) WithPath(key string, value interface{}) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithPath()") {
-		return r
-	}
-
-	if value == nil {
-		opChain.fail(AssertionFailure{
-			Type: AssertUsage,
-			Errors: []error{
-				errors.New("unexpected nil argument"),
-			},
-		})
+	if !r.checkOrder(opChain, "WithTrace()") {
 		return r
 	}
 
-	r.withPath(opChain, key, value)
+	r.tracer = &traceRecorder{}
 
 	return r
 }
 
-// WithPathObject substitutes multiple named parameters in url path.
+// WithRawHeaders enables recording of response header names as they were
+// received, before canonicalization performed by http.Transport.
 //
-// object should be map or struct. If object is struct, it's converted
-// to map using https://github.com/fatih/structs. Structs may contain
-// "path" struct tag, similar to "json" struct tag for json.Marshal().
+// Recorded names can be checked using Response.HeaderRaw. Names are recorded
+// by wrapping connections of the transport, and only for HTTP/1.x responses;
+// with this option, HTTP/2 is not negotiated over TLS. Names are not recorded
+// for https requests sent via proxy.
 //
-// Each map value is converted to string using fmt.Sprint(). If there
-// is no named parameter for some map '{key}' in url path, failure is
-// reported.
+// Only this request is affected: Client and its Transport are copied and the
+// copies are modified, so other requests continue to use original Client.
+// Connections of the copied Transport are closed when response body is read
+// or closed.
 //
-// Named parameters are case-insensitive.
+// This method can be used only if Client interface points to *http.Client
+// struct with nil Transport or Transport of type *http.Transport.
+// It has no effect on websocket requests.
 //
 // Example:
 //
-//	type MyPath struct {
-//		Login string `path:"user"`
-//		Repo  string
-//	}
-//
-//	req := NewRequestC(config, "POST", "/repos/{user}/{repo}")
-//	req.WithPathObject(MyPath{"gavv", "httpexpect"})
-//	// path will be "/repos/gavv/httpexpect"
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithRawHeaders()
 //
-//	req := NewRequestC(config, "POST", "/repos/{user}/{repo}")
-//	req.WithPathObject(map[string]string{"user": "gavv", "repo": "httpexpect"})
-//	// path will be "/repos/gavv/httpexpect"
-func (r *Request) WithPathObject(object interface{}) *Request {
-	opChain := r.chain.enter("WithPathObject()")
+//	resp := req.Expect()
+//	resp.HeaderRaw("x-request-id").NotEmpty()
+func (r *Request) WithRawHeaders() *Request {
+	opChain := r.chain.enter("WithRawHeaders()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -922,50 +1179,825 @@ func (r *Request) WithPathObject(object interface{}) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithPathObject()") {
-		return r
-	}
-
-	if object == nil {
+	if !r.checkOrder(opChain, "WithRawHeaders()") {
 		return r
 	}
 
-	var (
-		m  map[string]interface{}
-		ok bool
-	)
-	if reflect.Indirect(reflect.ValueOf(object)).Kind() == reflect.Struct {
-		s := structs.New(object)
-		s.TagName = "path"
-		m = s.Map()
-	} else {
-		m, ok = canonMap(opChain, object)
-		if !ok {
-			return r
-		}
-	}
-
-	for key, value := range m {
-		r.withPath(opChain, key, value)
-	}
+	r.rawHeaders = &rawHeaderRecorder{}
 
 	return r
 }
 
-func (r *Request) withPath(opChain *chain, key string, value interface{}) {
-	found := false
-
-	path, err := interpol.WithFunc(r.path, func(k string, w io.Writer) error {
-		if strings.EqualFold(k, key) {
-			if value == nil {
-				opChain.fail(AssertionFailure{
-					Type: AssertUsage,
+// RedirectPolicy defines how redirection responses are handled.
+//
+// Status codes 307, 308 require resending body. They are followed only if
+// redirect policy is FollowAllRedirects.
+//
+// Status codes 301, 302, 303 don't require resending body. On such redirect,
+// http.Client automatically switches HTTP method to GET, if it's not GET or
+// HEAD already. These redirects are followed if redirect policy is either
+// FollowAllRedirects or FollowRedirectsWithoutBody.
+//
+// Default redirect policy is FollowRedirectsWithoutBody.
+type RedirectPolicy int
+
+const (
+	// indicates that WithRedirectPolicy was not called
+	defaultRedirectPolicy RedirectPolicy = iota
+
+	// DontFollowRedirects forbids following any redirects.
+	// Redirection response is returned to the user and can be inspected.
+	DontFollowRedirects
+
+	// FollowAllRedirects allows following any redirects, including those
+	// which require resending body.
+	FollowAllRedirects
+
+	// FollowRedirectsWithoutBody allows following only redirects which
+	// don't require resending body.
+	// If redirect requires resending body, it's not followed, and redirection
+	// response is returned instead.
+	FollowRedirectsWithoutBody
+)
+
+// WithRedirectPolicy sets policy for redirection response handling.
+//
+// How redirect is handled depends on both response status code and
+// redirect policy. See comments for RedirectPolicy for details.
+//
+// Default redirect policy is defined by Client implementation.
+// Default behavior of http.Client corresponds to FollowRedirectsWithoutBody.
+//
+// This method can be used only if Client interface points to
+// *http.Client struct, since we rely on it in redirect handling.
+//
+// Example:
+//
+//	req1 := NewRequestC(config, "POST", "/path")
+//	req1.WithRedirectPolicy(FollowAllRedirects)
+//	req1.Expect().Status(http.StatusOK)
+//
+//	req2 := NewRequestC(config, "POST", "/path")
+//	req2.WithRedirectPolicy(DontFollowRedirects)
+//	req2.Expect().Status(http.StatusPermanentRedirect)
+func (r *Request) WithRedirectPolicy(policy RedirectPolicy) *Request {
+	opChain := r.chain.enter("WithRedirectPolicy()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithRedirectPolicy()") {
+		return r
+	}
+
+	r.redirectPolicy = policy
+
+	return r
+}
+
+// WithMaxRedirects sets maximum number of redirects to follow.
+//
+// If the number of redirects exceedes this limit, request is failed.
+// Failure message lists the redirect chain, i.e. the sequence of URLs
+// starting from the original request. Negative limit is not allowed.
+//
+// Zero limit means that redirects are not followed, like with
+// DontFollowRedirects policy: the first redirect response is returned.
+//
+// Default limit is defined by Client implementation.
+// Default behavior of http.Client corresponds to maximum of 10-1 redirects.
+//
+// If Client already has CheckRedirect function, it is still invoked
+// for redirects that don't exceed the limit.
+//
+// This method can be used only if Client interface points to
+// *http.Client struct, since we rely on it in redirect handling.
+//
+// Example:
+//
+//	req1 := NewRequestC(config, "POST", "/path")
+//	req1.WithMaxRedirects(1)
+//	req1.Expect().Status(http.StatusOK)
+func (r *Request) WithMaxRedirects(maxRedirects int) *Request {
+	opChain := r.chain.enter("WithMaxRedirects()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithMaxRedirects()") {
+		return r
+	}
+
+	if maxRedirects < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected negative argument: %d", maxRedirects),
+			},
+		})
+		return r
+	}
+
+	r.maxRedirects = maxRedirects
+
+	return r
+}
+
+// RetryPolicy defines how failed requests are retried.
+//
+// Whether a request is retried depends on error type (if any), response
+// status code (if any), and retry policy.
+type RetryPolicy int
+
+const (
+	// DontRetry disables retrying at all.
+	DontRetry RetryPolicy = iota
+
+	// Deprecated: use RetryTimeoutErrors instead.
+	RetryTemporaryNetworkErrors
+
+	// Deprecated: use RetryTimeoutAndServerErrors instead.
+	RetryTemporaryNetworkAndServerErrors
+
+	// RetryTimeoutErrors enables retrying of timeout errors.
+	// Retry happens if Client returns net.Error and its Timeout() method
+	// returns true.
+	RetryTimeoutErrors
+
+	// RetryTimeoutAndServerErrors enables retrying of network timeout errors,
+	// as well as 5xx status codes.
+	RetryTimeoutAndServerErrors
+
+	// RetryAllErrors enables retrying of any error or 4xx/5xx status code.
+	RetryAllErrors
+)
+
+// WithRetryPolicy sets policy for retries.
+//
+// Whether a request is retried depends on error type (if any), response
+// status code (if any), and retry policy.
+//
+// How much retry attempts happens is defined by WithMaxRetries().
+// How much to wait between attempts is defined by WithRetryDelay().
+//
+// Default retry policy is RetryTimeoutAndServerErrors, but
+// default maximum number of retries is zero, so no retries happen
+// unless WithMaxRetries() is called.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/path")
+//	req.WithRetryPolicy(RetryAllErrors)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithRetryPolicy(policy RetryPolicy) *Request {
+	opChain := r.chain.enter("WithRetryPolicy()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithRetryPolicy()") {
+		return r
+	}
+
+	r.retryPolicy = policy
+	r.retryPolicyFn = nil
+
+	return r
+}
+
+// WithRetryPolicyFunc sets a function to decide whether request should be
+// retried.
+//
+// The function is invoked after every failed or successful attempt, with
+// the received response (or nil) and error (or nil). If it returns true and
+// the maximum number of retries is not reached yet, request is retried.
+//
+// WithRetryPolicyFunc overrides policy set by WithRetryPolicy(), and vice
+// versa. As with WithRetryPolicy(), the number of retries is defined by
+// WithMaxRetries() or WithMaxAttempts(), and delay between them is defined
+// by WithRetryDelay() or WithRetryBackoff().
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/path")
+//	req.WithRetryPolicyFunc(func(resp *http.Response, err error) bool {
+//		return err != nil || resp.StatusCode == http.StatusTooManyRequests
+//	})
+//	req.WithMaxRetries(3)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithRetryPolicyFunc(
+	fn func(resp *http.Response, err error) bool,
+) *Request {
+	opChain := r.chain.enter("WithRetryPolicyFunc()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithRetryPolicyFunc()") {
+		return r
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.retryPolicyFn = fn
+
+	return r
+}
+
+// WithMaxRetries sets maximum number of retry attempts.
+//
+// After first request failure, additional retry attempts may happen,
+// depending on the retry policy.
+//
+// Setting this to zero disables retries, i.e. only one request is sent.
+// Setting this to N enables retries, and up to N+1 requests may be sent.
+//
+// Default number of retries is zero, i.e. retries are disabled.
+//
+// See also WithMaxAttempts, which sets the same limit counting the first
+// attempt too.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/path")
+//	req.WithMaxRetries(1)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithMaxRetries(maxRetries int) *Request {
+	opChain := r.chain.enter("WithMaxRetries()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithMaxRetries()") {
+		return r
+	}
+
+	if maxRetries < 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{maxRetries},
+			Errors: []error{
+				errors.New("invalid negative argument"),
+			},
+		})
+		return r
+	}
+
+	r.maxRetries = maxRetries
+
+	return r
+}
+
+// WithMaxAttempts sets maximum number of attempts to send request,
+// including the first one.
+//
+// WithMaxAttempts(N) is equivalent to WithMaxRetries(N-1). Setting this
+// to 1 disables retries. Setting this to zero or negative value is an error.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/path")
+//	req.WithMaxAttempts(3)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithMaxAttempts(maxAttempts int) *Request {
+	opChain := r.chain.enter("WithMaxAttempts()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithMaxAttempts()") {
+		return r
+	}
+
+	if maxAttempts < 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-positive argument: %d", maxAttempts),
+			},
+		})
+		return r
+	}
+
+	r.maxRetries = maxAttempts - 1
+
+	return r
+}
+
+// WithRetryDelay sets minimum and maximum delay between retries.
+//
+// If multiple retry attempts happen, delay between attempts starts from
+// minDelay and then grows exponentionally until it reaches maxDelay.
+//
+// Default delay range is [50ms; 5s].
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/path")
+//	req.WithRetryDelay(time.Second, time.Minute)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithRetryDelay(minDelay, maxDelay time.Duration) *Request {
+	opChain := r.chain.enter("WithRetryDelay()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithRetryDelay()") {
+		return r
+	}
+
+	if !(minDelay <= maxDelay) {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				[2]time.Duration{minDelay, maxDelay},
+			},
+			Errors: []error{
+				errors.New("invalid delay range"),
+			},
+		})
+		return r
+	}
+
+	r.minRetryDelay = minDelay
+	r.maxRetryDelay = maxDelay
+	r.retryBackoff = nil
+
+	return r
+}
+
+// WithRetryBackoff sets a function to compute delay before every retry.
+//
+// The function is invoked with the number of retry, starting from 1 for
+// the first retry (i.e. the second attempt), and returns how long to wait
+// before it. Negative delays are treated as zero.
+//
+// WithRetryBackoff overrides delays set by WithRetryDelay(), and vice versa.
+// Whether request is retried is defined by WithRetryPolicy() or
+// WithRetryPolicyFunc(), and the number of retries by WithMaxRetries()
+// or WithMaxAttempts().
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/path")
+//	req.WithMaxAttempts(5)
+//	req.WithRetryBackoff(func(attempt int) time.Duration {
+//		return time.Duration(attempt) * 100 * time.Millisecond
+//	})
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithRetryBackoff(fn func(attempt int) time.Duration) *Request {
+	opChain := r.chain.enter("WithRetryBackoff()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithRetryBackoff()") {
+		return r
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.retryBackoff = fn
+
+	return r
+}
+
+// WithWebsocketUpgrade enables upgrades the connection to websocket.
+//
+// At least the following fields are added to the request header:
+//
+//	Upgrade: websocket
+//	Connection: Upgrade
+//
+// The actual set of header fields is define by the protocol implementation
+// in the gorilla/websocket package.
+//
+// The user should then call the Response.Websocket() method which returns
+// the Websocket instance. This instance can be used to send messages to the
+// server, to inspect the received messages, and to close the websocket.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithWebsocketUpgrade()
+//	ws := req.Expect().Status(http.StatusSwitchingProtocols).Websocket()
+//	defer ws.Disconnect()
+func (r *Request) WithWebsocketUpgrade() *Request {
+	opChain := r.chain.enter("WithWebsocketUpgrade()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithWebsocketUpgrade()") {
+		return r
+	}
+
+	r.wsUpgrade = true
+
+	return r
+}
+
+// WithWebsocketSubprotocols sets the list of websocket subprotocols
+// advertised by client.
+//
+// The list is sent in Sec-WebSocket-Protocol header, in order of preference.
+// If server selects a subprotocol that is not in the list, request fails.
+// Server is allowed to select no subprotocol; negotiated subprotocol can be
+// then checked using Websocket.Subprotocol().
+//
+// This method can be used only together with WithWebsocketUpgrade.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithWebsocketUpgrade()
+//	req.WithWebsocketSubprotocols("v2.chat", "v1.chat")
+//	ws := req.Expect().Status(http.StatusSwitchingProtocols).Websocket()
+//	ws.Subprotocol().IsEqual("v2.chat")
+//	defer ws.Disconnect()
+func (r *Request) WithWebsocketSubprotocols(protocols ...string) *Request {
+	opChain := r.chain.enter("WithWebsocketSubprotocols()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithWebsocketSubprotocols()") {
+		return r
+	}
+
+	if len(protocols) == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty list of subprotocols"),
+			},
+		})
+		return r
+	}
+
+	for _, protocol := range protocols {
+		if !httpguts.ValidHeaderFieldName(protocol) {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("invalid websocket subprotocol %q", protocol),
+				},
+			})
+			return r
+		}
+	}
+
+	r.wsSubprotocols = append(([]string)(nil), protocols...)
+
+	return r
+}
+
+// WithWebsocketDialer sets the custom websocket dialer.
+//
+// The new dialer overwrites Config.WebsocketDialer. It will be used once to establish
+// the WebSocket connection and receive a response of handshake result.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithWebsocketUpgrade()
+//	req.WithWebsocketDialer(&websocket.Dialer{
+//	  EnableCompression: false,
+//	})
+//	ws := req.Expect().Status(http.StatusSwitchingProtocols).Websocket()
+//	defer ws.Disconnect()
+func (r *Request) WithWebsocketDialer(dialer WebsocketDialer) *Request {
+	opChain := r.chain.enter("WithWebsocketDialer()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithWebsocketDialer()") {
+		return r
+	}
+
+	if dialer == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.config.WebsocketDialer = dialer
+
+	return r
+}
+
+// WithPath substitutes named parameters in url path.
+//
+// value is converted to string as follows:
+//   - time.Time is formatted using layout set by WithPathTimeLayout
+//     (time.RFC3339 by default)
+//   - fmt.Stringer is converted using its String() method
+//   - floating point numbers are formatted without exponent
+//   - other values are converted using fmt.Sprint()
+//
+// If there is no named parameter '{key}' in url path, failure is reported.
+//
+// Named parameters are case-insensitive.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/repos/{user}/{repo}")
+//	req.WithPath("user", "gavv")
+//	req.WithPath("repo", "httpexpect")
+//	// path will be "/repos/gavv/httpexpect"
+func (r *Request) WithPath(key string, value interface{}) *Request {
+	opChain := r.chain.enter("WithPath()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithPath()") {
+		return r
+	}
+
+	if value == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	if !r.checkAbsoluteURL(opChain, "WithPath()") {
+		return r
+	}
+
+	r.withPath(opChain, key, value)
+
+	return r
+}
+
+// WithPathObject substitutes multiple named parameters in url path.
+//
+// object should be map or struct. If object is struct, it's converted
+// to map using https://github.com/fatih/structs. Structs may contain
+// "path" struct tag, similar to "json" struct tag for json.Marshal().
+//
+// Each map value is converted to string in the same way as in WithPath.
+// If there is no named parameter for some map '{key}' in url path, failure
+// is reported. If some named parameters in url path remain unsubstituted
+// after substituting all map keys, failure is reported too.
+//
+// Named parameters are case-insensitive.
+//
+// Example:
+//
+//	type MyPath struct {
+//		Login string `path:"user"`
+//		Repo  string
+//	}
+//
+//	req := NewRequestC(config, "POST", "/repos/{user}/{repo}")
+//	req.WithPathObject(MyPath{"gavv", "httpexpect"})
+//	// path will be "/repos/gavv/httpexpect"
+//
+//	req := NewRequestC(config, "POST", "/repos/{user}/{repo}")
+//	req.WithPathObject(map[string]string{"user": "gavv", "repo": "httpexpect"})
+//	// path will be "/repos/gavv/httpexpect"
+//
+//	req := NewRequestC(config, "GET", "/users/{id}/events/{date}")
+//	req.WithPathTimeLayout("2006-01-02")
+//	req.WithPathObject(map[string]interface{}{
+//		"id":   1000000,
+//		"date": time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+//	})
+//	// path will be "/users/1000000/events/2023-01-02"
+func (r *Request) WithPathObject(object interface{}) *Request {
+	opChain := r.chain.enter("WithPathObject()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithPathObject()") {
+		return r
+	}
+
+	if object == nil {
+		return r
+	}
+
+	if !r.checkAbsoluteURL(opChain, "WithPathObject()") {
+		return r
+	}
+
+	var (
+		m  map[string]interface{}
+		ok bool
+	)
+	if reflect.Indirect(reflect.ValueOf(object)).Kind() == reflect.Struct {
+		s := structs.New(object)
+		s.TagName = "path"
+		m = s.Map()
+	} else if v := reflect.ValueOf(object); v.Kind() == reflect.Map &&
+		v.Type().Key().Kind() == reflect.String {
+		// use original values instead of canonical form, so that
+		// they can be formatted according to their types
+		m = make(map[string]interface{}, v.Len())
+		for _, key := range v.MapKeys() {
+			m[key.String()] = v.MapIndex(key).Interface()
+		}
+	} else {
+		m, ok = canonMap(opChain, object)
+		if !ok {
+			return r
+		}
+	}
+
+	for key, value := range m {
+		r.withPath(opChain, key, value)
+	}
+
+	if missing := pathParams(r.path); len(missing) != 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("missing keys for path parameters %q", missing),
+			},
+		})
+		return r
+	}
+
+	return r
+}
+
+// Returns names of named parameters remaining in path.
+func pathParams(path string) []string {
+	var params []string
+
+	_, _ = interpol.WithFunc(path, func(k string, w io.Writer) error {
+		params = append(params, k)
+		return nil
+	})
+
+	return params
+}
+
+// WithPathTimeLayout sets layout used to format time.Time values
+// substituted into url path by subsequent WithPath and WithPathObject
+// calls. Default layout is time.RFC3339.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/events/{date}")
+//	req.WithPathTimeLayout("2006-01-02")
+//	req.WithPath("date", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+//	// path will be "/events/2023-01-02"
+func (r *Request) WithPathTimeLayout(layout string) *Request {
+	opChain := r.chain.enter("WithPathTimeLayout()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithPathTimeLayout()") {
+		return r
+	}
+
+	if layout == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty layout"),
+			},
+		})
+		return r
+	}
+
+	r.pathTimeLayout = layout
+
+	return r
+}
+
+func formatPathValue(value interface{}, timeLayout string) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(timeLayout)
+	case *time.Time:
+		if v != nil {
+			return v.Format(timeLayout)
+		}
+	case fmt.Stringer:
+		return v.String()
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(value)
+}
+
+func (r *Request) withPath(opChain *chain, key string, value interface{}) {
+	found := false
+
+	path, err := interpol.WithFunc(r.path, func(k string, w io.Writer) error {
+		if strings.EqualFold(k, key) {
+			if value == nil {
+				opChain.fail(AssertionFailure{
+					Type: AssertUsage,
 					Errors: []error{
 						errors.New("unexpected nil interpol argument"),
 					},
 				})
 			} else {
-				mustWrite(w, fmt.Sprint(value))
+				mustWrite(w, formatPathValue(value, r.pathTimeLayout))
 				found = true
 			}
 		} else {
@@ -973,46 +2005,600 @@ func (r *Request) withPath(opChain *chain, key string, value interface{}) {
 			mustWrite(w, k)
 			mustWrite(w, "}")
 		}
-		return nil
-	})
+		return nil
+	})
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{path},
+			Errors: []error{
+				errors.New("invalid interpol string"),
+				err,
+			},
+		})
+		return
+	}
+
+	if !found {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("key %q not found in interpol string", key),
+			},
+		})
+		return
+	}
+
+	r.path = path
+}
+
+// WithQuery adds query parameter to request URL.
+//
+// value is converted to string using fmt.Sprint() and urlencoded.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithQuery("a", 123)
+//	req.WithQuery("b", "foo")
+//	// URL is now http://example.com/path?a=123&b=foo
+func (r *Request) WithQuery(key string, value interface{}) *Request {
+	opChain := r.chain.enter("WithQuery()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithQuery()") {
+		return r
+	}
+
+	if value == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	if !r.initQuery(opChain, "WithQuery()") {
+		return r
+	}
+	r.query.Add(key, fmt.Sprint(value))
+
+	return r
+}
+
+// WithQueryObject adds multiple query parameters to request URL.
+//
+// object is converted to query string using github.com/google/go-querystring
+// if it's a struct or pointer to struct, or github.com/ajg/form otherwise.
+// If object is nil, nil pointer, or nil map, nothing is added.
+//
+// If a struct field implements query.Encoder and fails, reported failure
+// includes the name and type of that field.
+//
+// Various object types are supported. Structs may contain "url" struct tag,
+// similar to "json" struct tag for json.Marshal().
+//
+// Example:
+//
+//	type MyURL struct {
+//		A int    `url:"a"`
+//		B string `url:"b"`
+//	}
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithQueryObject(MyURL{A: 123, B: "foo"})
+//	// URL is now http://example.com/path?a=123&b=foo
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithQueryObject(map[string]interface{}{"a": 123, "b": "foo"})
+//	// URL is now http://example.com/path?a=123&b=foo
+func (r *Request) WithQueryObject(object interface{}) *Request {
+	opChain := r.chain.enter("WithQueryObject()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithQueryObject()") {
+		return r
+	}
+
+	if object == nil {
+		return r
+	}
+
+	switch v := reflect.ValueOf(object); v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Interface:
+		if v.IsNil() {
+			return r
+		}
+	}
+
+	var (
+		q   url.Values
+		err error
+	)
+	if reflect.Indirect(reflect.ValueOf(object)).Kind() == reflect.Struct {
+		q, err = query.Values(object)
+		if err != nil {
+			errs := []error{
+				errors.New("invalid query object"),
+			}
+			if fieldErr := findQueryFieldError(
+				reflect.Indirect(reflect.ValueOf(object))); fieldErr != nil {
+				errs = append(errs, fieldErr)
+			}
+			errs = append(errs, err)
+
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{object},
+				Errors: errs,
+			})
+			return r
+		}
+	} else {
+		q, err = form.EncodeToValues(object)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{object},
+				Errors: []error{
+					errors.New("invalid query object"),
+					err,
+				},
+			})
+			return r
+		}
+	}
+
+	if !r.initQuery(opChain, "WithQueryObject()") {
+		return r
+	}
+	for k, v := range q {
+		r.query[k] = append(r.query[k], v...)
+	}
+
+	return r
+}
+
+// WithQueryString parses given query string and adds it to request URL.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithQuery("a", 11)
+//	req.WithQueryString("b=22&c=33")
+//	// URL is now http://example.com/path?a=11&bb=22&c=33
+func (r *Request) WithQueryString(query string) *Request {
+	opChain := r.chain.enter("WithQueryString()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithQueryString()") {
+		return r
+	}
+
+	v, err := url.ParseQuery(query)
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{query},
+			Errors: []error{
+				errors.New("invalid query string"),
+				err,
+			},
+		})
+		return r
+	}
+
+	if !r.initQuery(opChain, "WithQueryString()") {
+		return r
+	}
+	for k, v := range v {
+		r.query[k] = append(r.query[k], v...)
+	}
+
+	return r
+}
+
+// WithRawQuery sets query string of request URL verbatim, without parsing
+// and re-encoding it.
+//
+// Unlike WithQueryString, order of parameters and escaping are preserved
+// exactly as given. It replaces query string from Config.BaseURL,
+// WithURL, or WithAbsoluteURL, if any. Subsequent WithRawQuery calls
+// replace previously set raw query.
+//
+// WithRawQuery can't be combined with WithQuery, WithQueryObject, and
+// WithQueryString; such combination causes failure.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithRawQuery("b=2&a=1&sig=AbC%2f")
+//	// URL is now http://example.com/path?b=2&a=1&sig=AbC%2f
+func (r *Request) WithRawQuery(query string) *Request {
+	opChain := r.chain.enter("WithRawQuery()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithRawQuery()") {
+		return r
+	}
+
+	if strings.ContainsAny(query, "# ") || strings.IndexFunc(query, unicode.IsControl) >= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("invalid raw query %q", query),
+			},
+		})
+		return r
+	}
+
+	if r.query != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(queryErr, r.querySetter, "WithRawQuery()"),
+			},
+		})
+		return r
+	}
+
+	r.rawQuery = query
+	r.rawQuerySet = true
+
+	return r
+}
+
+var queryErr = `ambiguous request query:
+  first set by %s
+  then set by %s`
+
+func (r *Request) initQuery(opChain *chain, setter string) bool {
+	if r.rawQuerySet {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(queryErr, "WithRawQuery()", setter),
+			},
+		})
+		return false
+	}
+
+	if r.query == nil {
+		r.query = make(url.Values)
+		r.querySetter = setter
+	}
+
+	return true
+}
+
+// WithURL sets request URL.
+//
+// This URL overwrites Config.BaseURL. Request path passed to request constructor
+// is appended to this URL, separated by slash if necessary.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "/path")
+//	req.WithURL("http://example.com")
+//	// URL is now http://example.com/path
+func (r *Request) WithURL(urlStr string) *Request {
+	opChain := r.chain.enter("WithURL()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithURL()") {
+		return r
+	}
+
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{urlStr},
+			Errors: []error{
+				errors.New("invalid url string"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.httpReq.URL = u
+
+	return r
+}
+
+// WithAbsoluteURL sets full request URL.
+//
+// Unlike WithURL, this URL replaces the whole request URL: Config.BaseURL,
+// path passed to request constructor, and URL set by WithURL are ignored.
+// It's useful for following links returned by server, e.g. signed upload
+// URLs. Query string of the given URL is preserved, and parameters added
+// by WithQuery are appended to it.
+//
+// urlStr should be an absolute URL with scheme and host, otherwise failure
+// is reported. Path parameters can't be used together with absolute URL,
+// so if WithPath or WithPathObject is called before or after WithAbsoluteURL,
+// failure is reported too.
+//
+// Example:
+//
+//	uploadURL := e.POST("/uploads").Expect().
+//		JSON().Object().Value("url").String().Raw()
+//
+//	e.PUT("").WithAbsoluteURL(uploadURL).
+//		WithBytes(data).
+//		Expect().
+//		Status(http.StatusOK)
+func (r *Request) WithAbsoluteURL(urlStr string) *Request {
+	opChain := r.chain.enter("WithAbsoluteURL()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithAbsoluteURL()") {
+		return r
+	}
+
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("invalid url string %q", urlStr),
+				err,
+			},
+		})
+		return r
+	}
+
+	if !u.IsAbs() || u.Host == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("expected absolute url with scheme and host, got %q", urlStr),
+			},
+		})
+		return r
+	}
+
+	if r.pathSetter != "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(absoluteURLErr, r.pathSetter),
+			},
+		})
+		return r
+	}
+
+	// Host derived from base URL should not override host of absolute URL
+	if r.httpReq.Host == r.httpReq.URL.Host {
+		r.httpReq.Host = ""
+	}
+
+	r.absoluteURL = u
+
+	return r
+}
+
+var absoluteURLErr = `absolute request URL can not be used with path parameters:
+  path parameters were set by %s
+  absolute URL was set by WithAbsoluteURL()`
+
+func (r *Request) checkAbsoluteURL(opChain *chain, setter string) bool {
+	if r.absoluteURL != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(absoluteURLErr, setter),
+			},
+		})
+		return false
+	}
+
+	if r.pathSetter == "" {
+		r.pathSetter = setter
+	}
+
+	return true
+}
+
+// WithHeaders adds given headers to request.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithHeaders(map[string]string{
+//		"Content-Type": "application/json",
+//	})
+func (r *Request) WithHeaders(headers map[string]string) *Request {
+	opChain := r.chain.enter("WithHeaders()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithHeaders()") {
+		return r
+	}
+
+	for k, v := range headers {
+		r.withHeader(k, v)
+	}
+
+	return r
+}
+
+// WithHeader adds given single header to request.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithHeader("Content-Type", "application/json")
+func (r *Request) WithHeader(k, v string) *Request {
+	opChain := r.chain.enter("WithHeader()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithHeader()") {
+		return r
+	}
+
+	r.withHeader(k, v)
+
+	return r
+}
+
+func (r *Request) withHeader(k, v string) {
+	switch http.CanonicalHeaderKey(k) {
+	case "Host":
+		r.httpReq.Host = v
+
+	case "Content-Type":
+		if !r.forceType {
+			delete(r.httpReq.Header, "Content-Type")
+		}
+		r.forceType = true
+		r.typeSetter = "WithHeader()"
+		r.httpReq.Header.Add(k, v)
 
-	if err != nil {
+	default:
+		r.httpReq.Header.Add(k, v)
+	}
+}
+
+// WithTrailer adds given single trailer to request.
+//
+// Trailers are sent after request body, so request body should be set
+// and non-empty. When trailers are present, request is sent using chunked
+// transfer encoding.
+//
+// Some header fields, like Content-Length or Host, are not allowed
+// in trailers; using them causes failure.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "http://example.com/path")
+//	req.WithText("payload")
+//	req.WithTrailer("Checksum", "abcdef")
+func (r *Request) WithTrailer(k, v string) *Request {
+	opChain := r.chain.enter("WithTrailer()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithTrailer()") {
+		return r
+	}
+
+	if !httpguts.ValidHeaderFieldName(k) || !httpguts.ValidTrailerHeader(k) {
 		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{path},
+			Type: AssertUsage,
 			Errors: []error{
-				errors.New("invalid interpol string"),
-				err,
+				fmt.Errorf("invalid trailer name %q", k),
 			},
 		})
-		return
+		return r
 	}
 
-	if !found {
+	if !httpguts.ValidHeaderFieldValue(v) {
 		opChain.fail(AssertionFailure{
 			Type: AssertUsage,
 			Errors: []error{
-				fmt.Errorf("key %q not found in interpol string", key),
+				fmt.Errorf("invalid value %q for trailer %q", v, k),
 			},
 		})
-		return
+		return r
 	}
 
-	r.path = path
+	if r.httpReq.Trailer == nil {
+		r.httpReq.Trailer = make(http.Header)
+	}
+
+	r.httpReq.Trailer.Add(k, v)
+
+	return r
 }
 
-// WithQuery adds query parameter to request URL.
+// WithHeaderFn adds given single header to request, with value computed
+// by given function when request is sent.
 //
-// value is converted to string using fmt.Sprint() and urlencoded.
+// The function is invoked before every attempt to send request, so if
+// request is retried (see WithMaxRetries), header value is recomputed for
+// every retry. This is useful for time-based signatures and other values
+// that should be as fresh as possible.
+//
+// Values returned by functions replace values of same header set by
+// WithHeader or WithHeaders. If WithHeaderFn is called several times with
+// the same name, all returned values are added, in order of calls.
 //
 // Example:
 //
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithQuery("a", 123)
-//	req.WithQuery("b", "foo")
-//	// URL is now http://example.com/path?a=123&b=foo
-func (r *Request) WithQuery(key string, value interface{}) *Request {
-	opChain := r.chain.enter("WithQuery()")
+//	req.WithHeaderFn("X-Timestamp", func() string {
+//		return strconv.FormatInt(time.Now().Unix(), 10)
+//	})
+func (r *Request) WithHeaderFn(k string, fn func() string) *Request {
+	opChain := r.chain.enter("WithHeaderFn()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1022,11 +2608,11 @@ func (r *Request) WithQuery(key string, value interface{}) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithQuery()") {
+	if !r.checkOrder(opChain, "WithHeaderFn()") {
 		return r
 	}
 
-	if value == nil {
+	if fn == nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertUsage,
 			Errors: []error{
@@ -1036,38 +2622,53 @@ func (r *Request) WithQuery(key string, value interface{}) *Request {
 		return r
 	}
 
-	if r.query == nil {
-		r.query = make(url.Values)
+	if k == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty header name"),
+			},
+		})
+		return r
 	}
-	r.query.Add(key, fmt.Sprint(value))
+
+	r.headerFuncs = append(r.headerFuncs, headerFunc{
+		name: http.CanonicalHeaderKey(k),
+		fn:   fn,
+	})
 
 	return r
 }
 
-// WithQueryObject adds multiple query parameters to request URL.
-//
-// object is converted to query string using github.com/google/go-querystring
-// if it's a struct or pointer to struct, or github.com/ajg/form otherwise.
+type headerFunc struct {
+	name string
+	fn   func() string
+}
+
+func (r *Request) applyHeaderFuncs() {
+	for _, hf := range r.headerFuncs {
+		r.httpReq.Header.Del(hf.name)
+	}
+
+	for _, hf := range r.headerFuncs {
+		r.httpReq.Header.Add(hf.name, hf.fn())
+	}
+}
+
+// WithCookies adds given cookies to request.
 //
-// Various object types are supported. Structs may contain "url" struct tag,
-// similar to "json" struct tag for json.Marshal().
+// If client has a cookie jar with cookies of the same names, these cookies
+// from jar are not sent with this request. See Config.CookieJar.
 //
 // Example:
 //
-//	type MyURL struct {
-//		A int    `url:"a"`
-//		B string `url:"b"`
-//	}
-//
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithQueryObject(MyURL{A: 123, B: "foo"})
-//	// URL is now http://example.com/path?a=123&b=foo
-//
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithQueryObject(map[string]interface{}{"a": 123, "b": "foo"})
-//	// URL is now http://example.com/path?a=123&b=foo
-func (r *Request) WithQueryObject(object interface{}) *Request {
-	opChain := r.chain.enter("WithQueryObject()")
+//	req.WithCookies(map[string]string{
+//		"foo": "aa",
+//		"bar": "bb",
+//	})
+func (r *Request) WithCookies(cookies map[string]string) *Request {
+	opChain := r.chain.enter("WithCookies()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1077,66 +2678,31 @@ func (r *Request) WithQueryObject(object interface{}) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithQueryObject()") {
-		return r
-	}
-
-	if object == nil {
+	if !r.checkOrder(opChain, "WithCookies()") {
 		return r
 	}
 
-	var (
-		q   url.Values
-		err error
-	)
-	if reflect.Indirect(reflect.ValueOf(object)).Kind() == reflect.Struct {
-		q, err = query.Values(object)
-		if err != nil {
-			opChain.fail(AssertionFailure{
-				Type:   AssertValid,
-				Actual: &AssertionValue{object},
-				Errors: []error{
-					errors.New("invalid query object"),
-					err,
-				},
-			})
-			return r
-		}
-	} else {
-		q, err = form.EncodeToValues(object)
-		if err != nil {
-			opChain.fail(AssertionFailure{
-				Type:   AssertValid,
-				Actual: &AssertionValue{object},
-				Errors: []error{
-					errors.New("invalid query object"),
-					err,
-				},
-			})
-			return r
-		}
-	}
-
-	if r.query == nil {
-		r.query = make(url.Values)
-	}
-	for k, v := range q {
-		r.query[k] = append(r.query[k], v...)
+	for k, v := range cookies {
+		r.httpReq.AddCookie(&http.Cookie{
+			Name:  k,
+			Value: v,
+		})
 	}
 
 	return r
 }
 
-// WithQueryString parses given query string and adds it to request URL.
+// WithCookie adds given single cookie to request.
+//
+// If client has a cookie jar with a cookie of the same name, that cookie
+// from jar is not sent with this request. See Config.CookieJar.
 //
 // Example:
 //
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithQuery("a", 11)
-//	req.WithQueryString("b=22&c=33")
-//	// URL is now http://example.com/path?a=11&bb=22&c=33
-func (r *Request) WithQueryString(query string) *Request {
-	opChain := r.chain.enter("WithQueryString()")
+//	req.WithCookie("name", "value")
+func (r *Request) WithCookie(k, v string) *Request {
+	opChain := r.chain.enter("WithCookie()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1146,46 +2712,73 @@ func (r *Request) WithQueryString(query string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithQueryString()") {
+	if !r.checkOrder(opChain, "WithCookie()") {
 		return r
 	}
 
-	v, err := url.ParseQuery(query)
+	r.httpReq.AddCookie(&http.Cookie{
+		Name:  k,
+		Value: v,
+	})
 
-	if err != nil {
-		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{query},
-			Errors: []error{
-				errors.New("invalid query string"),
-				err,
-			},
-		})
+	return r
+}
+
+// WithETagFrom sets If-Match header to the value of ETag header of
+// the given response.
+//
+// Useful for testing optimistic concurrency, when a resource is updated
+// only if it wasn't modified since it was retrieved.
+//
+// If the response has no ETag header, failure is reported.
+//
+// Example:
+//
+//	resp := e.GET("/resource").Expect().Status(http.StatusOK)
+//
+//	e.PUT("/resource").
+//		WithETagFrom(resp).
+//		WithJSON(data).
+//		Expect().
+//		Status(http.StatusOK)
+func (r *Request) WithETagFrom(resp *Response) *Request {
+	opChain := r.chain.enter("WithETagFrom()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
 		return r
 	}
 
-	if r.query == nil {
-		r.query = make(url.Values)
-	}
-	for k, v := range v {
-		r.query[k] = append(r.query[k], v...)
+	if !r.checkOrder(opChain, "WithETagFrom()") {
+		return r
 	}
 
+	r.withETagFrom(opChain, resp, "If-Match")
+
 	return r
 }
 
-// WithURL sets request URL.
+// WithIfNoneMatchFrom sets If-None-Match header to the value of ETag header
+// of the given response.
 //
-// This URL overwrites Config.BaseURL. Request path passed to request constructor
-// is appended to this URL, separated by slash if necessary.
+// Useful for testing conditional requests, when server should respond with
+// 304 Not Modified if the resource wasn't modified.
+//
+// If the response has no ETag header, failure is reported.
 //
 // Example:
 //
-//	req := NewRequestC(config, "PUT", "/path")
-//	req.WithURL("http://example.com")
-//	// URL is now http://example.com/path
-func (r *Request) WithURL(urlStr string) *Request {
-	opChain := r.chain.enter("WithURL()")
+//	resp := e.GET("/resource").Expect().Status(http.StatusOK)
+//
+//	e.GET("/resource").
+//		WithIfNoneMatchFrom(resp).
+//		Expect().
+//		Status(http.StatusNotModified)
+func (r *Request) WithIfNoneMatchFrom(resp *Response) *Request {
+	opChain := r.chain.enter("WithIfNoneMatchFrom()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1195,38 +2788,71 @@ func (r *Request) WithURL(urlStr string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithURL()") {
+	if !r.checkOrder(opChain, "WithIfNoneMatchFrom()") {
 		return r
 	}
 
-	u, err := url.Parse(urlStr)
-	if err != nil {
+	r.withETagFrom(opChain, resp, "If-None-Match")
+
+	return r
+}
+
+func (r *Request) withETagFrom(opChain *chain, resp *Response, header string) {
+	if resp == nil {
 		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{urlStr},
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return
+	}
+
+	if resp.httpResp == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected response without http.Response"),
+			},
+		})
+		return
+	}
+
+	etag := resp.httpResp.Header.Get("ETag")
+
+	if etag == "" {
+		opChain.fail(AssertionFailure{
+			Type:   AssertContainsKey,
+			Actual: &AssertionValue{resp.httpResp.Header},
+			Expected: &AssertionValue{
+				"ETag",
+			},
 			Errors: []error{
-				errors.New("invalid url string"),
-				err,
+				fmt.Errorf("expected: response has ETag header to copy into %s",
+					header),
 			},
 		})
-		return r
+		return
 	}
 
-	r.httpReq.URL = u
-
-	return r
+	r.httpReq.Header.Set(header, etag)
 }
 
-// WithHeaders adds given headers to request.
+// WithBasicAuth sets the request's Authorization header to use HTTP
+// Basic Authentication with the provided username and password.
+//
+// With HTTP Basic Authentication the provided username and password
+// are not encrypted.
+//
+// If Authorization header is already set, e.g. by previous WithBasicAuth
+// call, it is overwritten.
 //
 // Example:
 //
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithHeaders(map[string]string{
-//		"Content-Type": "application/json",
-//	})
-func (r *Request) WithHeaders(headers map[string]string) *Request {
-	opChain := r.chain.enter("WithHeaders()")
+//	req.WithBasicAuth("john", "secret")
+func (r *Request) WithBasicAuth(username, password string) *Request {
+	opChain := r.chain.enter("WithBasicAuth()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1236,25 +2862,27 @@ func (r *Request) WithHeaders(headers map[string]string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithHeaders()") {
+	if !r.checkOrder(opChain, "WithBasicAuth()") {
 		return r
 	}
 
-	for k, v := range headers {
-		r.withHeader(k, v)
-	}
+	r.httpReq.SetBasicAuth(username, password)
 
 	return r
 }
 
-// WithHeader adds given single header to request.
+// WithoutBasicAuth removes HTTP Basic Authentication from the request.
+//
+// Authorization header is removed only if it uses Basic scheme, e.g. was
+// set by WithBasicAuth. Authorization header with other schemes is kept.
 //
 // Example:
 //
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithHeader("Content-Type", "application/json")
-func (r *Request) WithHeader(k, v string) *Request {
-	opChain := r.chain.enter("WithHeader()")
+//	req.WithBasicAuth("john", "secret")
+//	req.WithoutBasicAuth()
+func (r *Request) WithoutBasicAuth() *Request {
+	opChain := r.chain.enter("WithoutBasicAuth()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1264,44 +2892,29 @@ func (r *Request) WithHeader(k, v string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithHeader()") {
+	if !r.checkOrder(opChain, "WithoutBasicAuth()") {
 		return r
 	}
 
-	r.withHeader(k, v)
+	if _, _, ok := r.httpReq.BasicAuth(); ok {
+		r.httpReq.Header.Del("Authorization")
+	}
 
 	return r
 }
 
-func (r *Request) withHeader(k, v string) {
-	switch http.CanonicalHeaderKey(k) {
-	case "Host":
-		r.httpReq.Host = v
-
-	case "Content-Type":
-		if !r.forceType {
-			delete(r.httpReq.Header, "Content-Type")
-		}
-		r.forceType = true
-		r.typeSetter = "WithHeader()"
-		r.httpReq.Header.Add(k, v)
-
-	default:
-		r.httpReq.Header.Add(k, v)
-	}
-}
-
-// WithCookies adds given cookies to request.
+// WithBearerToken sets the request's Authorization header to use
+// Bearer Authentication with the provided token.
+//
+// If Authorization header is already set, e.g. by WithBasicAuth,
+// it is overwritten.
 //
 // Example:
 //
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithCookies(map[string]string{
-//		"foo": "aa",
-//		"bar": "bb",
-//	})
-func (r *Request) WithCookies(cookies map[string]string) *Request {
-	opChain := r.chain.enter("WithCookies()")
+//	req.WithBearerToken("token")
+func (r *Request) WithBearerToken(token string) *Request {
+	opChain := r.chain.enter("WithBearerToken()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1311,28 +2924,36 @@ func (r *Request) WithCookies(cookies map[string]string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithCookies()") {
+	if !r.checkOrder(opChain, "WithBearerToken()") {
 		return r
 	}
 
-	for k, v := range cookies {
-		r.httpReq.AddCookie(&http.Cookie{
-			Name:  k,
-			Value: v,
-		})
-	}
+	r.httpReq.Header.Set("Authorization", "Bearer "+token)
 
 	return r
 }
 
-// WithCookie adds given single cookie to request.
+// WithMethod overrides HTTP method of the request.
+//
+// method should be a valid HTTP token, otherwise failure is reported.
+// Method names are case-sensitive and are not converted to upper case.
+//
+// Unusual methods (not defined by RFC 7231 and RFC 5789) are reported
+// as non-fatal failures (with SeverityLog). If the request is sent with
+// GET or HEAD method set by WithMethod and has a body, non-fatal failure
+// is reported too, regardless of whether the body was set before or
+// after WithMethod.
+//
+// Request name in assertion path, e.g. Request("GET"), is updated to
+// the new method.
 //
 // Example:
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithCookie("name", "value")
-func (r *Request) WithCookie(k, v string) *Request {
-	opChain := r.chain.enter("WithCookie()")
+//	req := NewRequestC(config, "GET", "http://example.com/path")
+//	req.WithMethod("POST")
+//	req.WithJSON(map[string]interface{}{"foo": 123})
+func (r *Request) WithMethod(method string) *Request {
+	opChain := r.chain.enter("WithMethod()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1342,30 +2963,59 @@ func (r *Request) WithCookie(k, v string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithCookie()") {
+	if !r.checkOrder(opChain, "WithMethod()") {
 		return r
 	}
 
-	r.httpReq.AddCookie(&http.Cookie{
-		Name:  k,
-		Value: v,
-	})
+	if !httpguts.ValidHeaderFieldName(method) {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("invalid HTTP method %q", method),
+			},
+		})
+		return r
+	}
+
+	if !isStandardMethod(method) {
+		r.reportWarning(opChain,
+			fmt.Errorf("unusual HTTP method %q", method))
+	}
+
+	r.chain.rename(
+		fmt.Sprintf("Request(%q)", r.httpReq.Method),
+		fmt.Sprintf("Request(%q)", method))
+
+	r.httpReq.Method = method
+	r.methodSetter = "WithMethod()"
 
 	return r
 }
 
-// WithBasicAuth sets the request's Authorization header to use HTTP
-// Basic Authentication with the provided username and password.
-//
-// With HTTP Basic Authentication the provided username and password
-// are not encrypted.
+func isStandardMethod(method string) bool {
+	switch method {
+	case http.MethodGet,
+		http.MethodHead,
+		http.MethodPost,
+		http.MethodPut,
+		http.MethodPatch,
+		http.MethodDelete,
+		http.MethodConnect,
+		http.MethodOptions,
+		http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// WithHost sets request host to given string.
 //
 // Example:
 //
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithBasicAuth("john", "secret")
-func (r *Request) WithBasicAuth(username, password string) *Request {
-	opChain := r.chain.enter("WithBasicAuth()")
+//	req.WithHost("example.com")
+func (r *Request) WithHost(host string) *Request {
+	opChain := r.chain.enter("WithHost()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1375,23 +3025,33 @@ func (r *Request) WithBasicAuth(username, password string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithBasicAuth()") {
+	if !r.checkOrder(opChain, "WithHost()") {
 		return r
 	}
 
-	r.httpReq.SetBasicAuth(username, password)
+	r.httpReq.Host = host
 
 	return r
 }
 
-// WithHost sets request host to given string.
+// WithConnectionClose forces connection to be closed after the request.
+//
+// It sets http.Request.Close and adds "Connection: close" header, so that
+// the connection is not reused for subsequent requests, neither by client
+// nor by server. This is handy to test server behavior without connection
+// reuse, e.g. to verify that per-connection state is isolated.
+//
+// When redirects are followed, every redirect hop closes connection as well.
+//
+// WithConnectionClose can't be combined with WithWebsocketUpgrade.
 //
 // Example:
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithHost("example.com")
-func (r *Request) WithHost(host string) *Request {
-	opChain := r.chain.enter("WithHost()")
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithConnectionClose()
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithConnectionClose() *Request {
+	opChain := r.chain.enter("WithConnectionClose()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1401,11 +3061,12 @@ func (r *Request) WithHost(host string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithHost()") {
+	if !r.checkOrder(opChain, "WithConnectionClose()") {
 		return r
 	}
 
-	r.httpReq.Host = host
+	r.httpReq.Close = true
+	r.httpReq.Header.Set("Connection", "close")
 
 	return r
 }
@@ -1500,6 +3161,95 @@ func (r *Request) WithChunked(reader io.Reader) *Request {
 	return r
 }
 
+// WithReader sets request body reader with known length.
+//
+// Unlike WithBytes, body is not read into memory: it's streamed from the
+// reader while request is being sent. If length is non-negative, it is
+// used as Content-Length and reader should provide exactly that number
+// of bytes, otherwise request fails. If length is -1, Content-Length is
+// not set and "chunked" Transfer-Encoding is used, like in WithChunked.
+//
+// The reader can be consumed only once, hence WithReader() can't be
+// combined with WithMaxRetries() and with FollowAllRedirects policy,
+// which require resending body. Printers don't print streamed body.
+//
+// WithReader can't be combined with other methods that set body, like
+// WithJSON or WithForm.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/upload")
+//	fh, _ := os.Open("data.bin")
+//	defer fh.Close()
+//	st, _ := fh.Stat()
+//	req.WithHeader("Content-Type", "application/octet-stream")
+//	req.WithReader(fh, st.Size())
+func (r *Request) WithReader(reader io.Reader, length int64) *Request {
+	opChain := r.chain.enter("WithReader()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithReader()") {
+		return r
+	}
+
+	if reader == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	if length < -1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected negative length %d", length),
+			},
+		})
+		return r
+	}
+
+	if length == -1 && !r.httpReq.ProtoAtLeast(1, 1) {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					`chunked Transfer-Encoding requires at least "HTTP/1.1",`+
+						` but "HTTP/%d.%d" is used`,
+					r.httpReq.ProtoMajor, r.httpReq.ProtoMinor),
+			},
+		})
+		return r
+	}
+
+	if length == 0 {
+		r.setBody(opChain, "WithReader()", nil, 0, false)
+		return r
+	}
+
+	r.setBody(opChain, "WithReader()", reader, length, false)
+
+	if r.bodySetter == "WithReader()" {
+		// use stream instead of plain reader, so that body is not buffered
+		r.httpReq.Body = newBodyStream(func(w io.Writer) error {
+			_, err := io.Copy(w, reader)
+			return err
+		})
+	}
+
+	return r
+}
+
 // WithBytes sets request body to given slice of bytes.
 //
 // Example:
@@ -1525,7 +3275,7 @@ func (r *Request) WithBytes(b []byte) *Request {
 	if b == nil {
 		r.setBody(opChain, "WithBytes()", nil, 0, false)
 	} else {
-		r.setBody(opChain, "WithBytes()", bytes.NewReader(b), len(b), false)
+		r.setBody(opChain, "WithBytes()", bytes.NewReader(b), int64(len(b)), false)
 	}
 
 	return r
@@ -1554,7 +3304,7 @@ func (r *Request) WithText(s string) *Request {
 	}
 
 	r.setType(opChain, "WithText()", "text/plain; charset=utf-8", false)
-	r.setBody(opChain, "WithText()", strings.NewReader(s), len(s), false)
+	r.setBody(opChain, "WithText()", strings.NewReader(s), int64(len(s)), false)
 
 	return r
 }
@@ -1603,11 +3353,77 @@ func (r *Request) WithJSON(object interface{}) *Request {
 	}
 
 	r.setType(opChain, "WithJSON()", "application/json; charset=utf-8", false)
-	r.setBody(opChain, "WithJSON()", bytes.NewReader(b), len(b), false)
+	r.setBody(opChain, "WithJSON()", bytes.NewReader(b), int64(len(b)), false)
+
+	return r
+}
+
+// WithYAML sets Content-Type header to "application/yaml" and sets body
+// to object, marshaled using yaml.Marshal().
+//
+// If object is nil, body is set to an empty document.
+//
+// Example:
+//
+//	type MyYAML struct {
+//		Foo int `yaml:"foo"`
+//	}
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithYAML(MyYAML{Foo: 123})
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithYAML(map[string]interface{}{"foo": 123})
+func (r *Request) WithYAML(object interface{}) *Request {
+	opChain := r.chain.enter("WithYAML()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithYAML()") {
+		return r
+	}
+
+	var b []byte
+
+	if object != nil {
+		var err error
+		b, err = marshalYAML(object)
+
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{object},
+				Errors: []error{
+					errors.New("invalid yaml object"),
+					err,
+				},
+			})
+			return r
+		}
+	}
+
+	r.setType(opChain, "WithYAML()", "application/yaml", false)
+	r.setBody(opChain, "WithYAML()", bytes.NewReader(b), int64(len(b)), false)
 
 	return r
 }
 
+// yaml.Marshal reports errors by panicking, convert them to error
+func marshalYAML(object interface{}) (b []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			b, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
+	return yaml.Marshal(object)
+}
+
 // WithForm sets Content-Type header to "application/x-www-form-urlencoded"
 // or (if WithMultipart() was called) "multipart/form-data", converts given
 // object to url.Values using github.com/ajg/form, and adds it to request body.
@@ -1696,7 +3512,13 @@ func (r *Request) WithForm(object interface{}) *Request {
 
 // WithFormField sets Content-Type header to "application/x-www-form-urlencoded"
 // or (if WithMultipart() was called) "multipart/form-data", converts given
-// value to string using fmt.Sprint(), and adds it to request body.
+// values to strings using fmt.Sprint(), and adds them to request body.
+//
+// If several values are given, or WithFormField() is called several times
+// with the same key, all values are added, so the key is repeated in the
+// body, e.g. "tag=a&tag=b". Values are appended to values added by
+// WithForm(), so WithForm() can set base fields and WithFormField() can add
+// more values for them.
 //
 // Multiple WithForm(), WithFormField(), and WithFile() calls may be combined.
 // If WithMultipart() is called, it should be called first.
@@ -1705,8 +3527,9 @@ func (r *Request) WithForm(object interface{}) *Request {
 //
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
 //	req.WithFormField("foo", 123).
-//		WithFormField("bar", 456)
-func (r *Request) WithFormField(key string, value interface{}) *Request {
+//		WithFormField("bar", 456).
+//		WithFormField("tag", "a", "b")
+func (r *Request) WithFormField(key string, values ...interface{}) *Request {
 	opChain := r.chain.enter("WithFormField()")
 	defer opChain.leave()
 
@@ -1721,19 +3544,31 @@ func (r *Request) WithFormField(key string, value interface{}) *Request {
 		return r
 	}
 
+	if len(values) == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty values list"),
+			},
+		})
+		return r
+	}
+
 	if r.multipart != nil {
 		r.setType(opChain, "WithFormField()", "multipart/form-data", false)
 
-		err := r.multipart.WriteField(key, fmt.Sprint(value))
-		if err != nil {
-			opChain.fail(AssertionFailure{
-				Type: AssertOperation,
-				Errors: []error{
-					fmt.Errorf("failed to write multipart form field %q", key),
-					err,
-				},
-			})
-			return r
+		for _, value := range values {
+			err := r.multipart.WriteField(key, fmt.Sprint(value))
+			if err != nil {
+				opChain.fail(AssertionFailure{
+					Type: AssertOperation,
+					Errors: []error{
+						fmt.Errorf("failed to write multipart form field %q", key),
+						err,
+					},
+				})
+				return r
+			}
 		}
 	} else {
 		r.setType(opChain, "WithFormField()", "application/x-www-form-urlencoded", false)
@@ -1741,7 +3576,9 @@ func (r *Request) WithFormField(key string, value interface{}) *Request {
 		if r.form == nil {
 			r.form = make(url.Values)
 		}
-		r.form[key] = append(r.form[key], fmt.Sprint(value))
+		for _, value := range values {
+			r.form[key] = append(r.form[key], fmt.Sprint(value))
+		}
 	}
 
 	return r
@@ -1753,6 +3590,8 @@ func (r *Request) WithFormField(key string, value interface{}) *Request {
 // If reader is given, it's used to read file contents. Otherwise, os.Open()
 // is used to read a file with given path.
 //
+// Content-Type of the file part is "application/octet-stream".
+//
 // Multiple WithForm(), WithFormField(), and WithFile() calls may be combined.
 // WithMultipart() should be called before WithFile(), otherwise WithFile()
 // fails.
@@ -1792,7 +3631,7 @@ func (r *Request) WithFile(key, path string, reader ...io.Reader) *Request {
 		return r
 	}
 
-	r.withFile(opChain, "WithFile()", key, path, reader...)
+	r.withFile(opChain, "WithFile()", key, path, "", reader...)
 
 	return r
 }
@@ -1800,15 +3639,20 @@ func (r *Request) WithFile(key, path string, reader ...io.Reader) *Request {
 // WithFileBytes is like WithFile, but uses given slice of bytes as the
 // file contents.
 //
+// If contentType is given, it's used as Content-Type of the file part.
+// Otherwise, Content-Type is "application/octet-stream", like in WithFile.
+//
 // Example:
 //
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
 //	fh, _ := os.Open("./john.png")
 //	b, _ := ioutil.ReadAll(fh)
 //	req.WithMultipart().
-//		WithFileBytes("avatar", "john.png", b)
+//		WithFileBytes("avatar", "john.png", b, "image/png")
 //	fh.Close()
-func (r *Request) WithFileBytes(key, path string, data []byte) *Request {
+func (r *Request) WithFileBytes(
+	key, path string, data []byte, contentType ...string,
+) *Request {
 	opChain := r.chain.enter("WithFileBytes()")
 	defer opChain.leave()
 
@@ -1823,13 +3667,164 @@ func (r *Request) WithFileBytes(key, path string, data []byte) *Request {
 		return r
 	}
 
-	r.withFile(opChain, "WithFileBytes()", key, path, bytes.NewReader(data))
+	if len(contentType) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple contentType arguments"),
+			},
+		})
+		return r
+	}
+
+	var partType string
+	if len(contentType) != 0 {
+		partType = contentType[0]
+	}
+
+	r.withFile(opChain, "WithFileBytes()", key, path, partType, bytes.NewReader(data))
+
+	return r
+}
+
+// WithFileFS is like WithFile, but reads file with given path from given
+// file system instead of disk. It can be used with embed.FS or fstest.MapFS.
+//
+// Path should be valid according to fs.ValidPath.
+//
+// Unlike WithFile, Content-Type of the file part is guessed from the file
+// extension, and defaults to "application/octet-stream" if extension is
+// unknown.
+//
+// Example:
+//
+//	//go:embed testdata
+//	var testdata embed.FS
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithMultipart().
+//		WithFileFS(testdata, "avatar", "testdata/john.png")
+func (r *Request) WithFileFS(fsys fs.FS, key, path string) *Request {
+	opChain := r.chain.enter("WithFileFS()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithFileFS()") {
+		return r
+	}
+
+	if fsys == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	f, err := fsys.Open(path)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to open file %q", path),
+				err,
+			},
+		})
+		return r
+	}
+	defer f.Close()
+
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+
+	r.withFile(opChain, "WithFileFS()", key, path, contentType, f)
+
+	return r
+}
+
+// WithFileStream is like WithFile, but doesn't read file contents into
+// memory. Instead, multipart body is produced on the fly while request
+// is being sent, so memory usage stays bounded even for huge files.
+//
+// Since the length of the body is unknown in advance, request is sent
+// using chunked transfer encoding.
+//
+// Streamed files are written after all other form fields and files,
+// in the order of WithFileStream() calls.
+//
+// The reader can be consumed only once, hence WithFileStream() can't be
+// combined with WithMaxRetries() and with FollowAllRedirects policy,
+// which require resending body. Printers don't print streamed body.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	fh, _ := os.Open("./huge.bin")
+//	req.WithMultipart().
+//		WithFileStream("data", "huge.bin", fh)
+//	req.Expect().Status(http.StatusOK)
+//	fh.Close()
+func (r *Request) WithFileStream(key, path string, reader io.Reader) *Request {
+	opChain := r.chain.enter("WithFileStream()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithFileStream()") {
+		return r
+	}
+
+	if reader == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.setType(opChain, "WithFileStream()", "multipart/form-data", false)
+
+	if r.multipart == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("WithFileStream() requires WithMultipart() to be called first"),
+			},
+		})
+		return r
+	}
+
+	r.fileStreams = append(r.fileStreams, fileStream{
+		key:    key,
+		path:   path,
+		reader: reader,
+	})
 
 	return r
 }
 
+type fileStream struct {
+	key    string
+	path   string
+	reader io.Reader
+}
+
 func (r *Request) withFile(
-	opChain *chain, method, key, path string, reader ...io.Reader,
+	opChain *chain, method, key, path, contentType string, reader ...io.Reader,
 ) {
 	r.setType(opChain, method, "multipart/form-data", false)
 
@@ -1843,7 +3838,7 @@ func (r *Request) withFile(
 		return
 	}
 
-	wr, err := r.multipart.CreateFormFile(key, path)
+	wr, err := createFormFile(r.multipart, key, path, contentType)
 	if err != nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertOperation,
@@ -1888,6 +3883,26 @@ func (r *Request) withFile(
 	}
 }
 
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// createFormFile is like multipart.Writer.CreateFormFile, but allows to
+// specify Content-Type of the part; empty means "application/octet-stream".
+func createFormFile(
+	mw *multipart.Writer, key, path, contentType string,
+) (io.Writer, error) {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition",
+		fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+			quoteEscaper.Replace(key), quoteEscaper.Replace(path)))
+	h.Set("Content-Type", contentType)
+
+	return mw.CreatePart(h)
+}
+
 // WithMultipart sets Content-Type header to "multipart/form-data".
 //
 // After this call, WithForm() and WithFormField() switch to multipart
@@ -1922,66 +3937,292 @@ func (r *Request) WithMultipart() *Request {
 
 	if r.multipart == nil {
 		r.formbuf = &bytes.Buffer{}
-		r.multipart = multipart.NewWriter(r.formbuf)
+		r.multipartOut = &switchWriter{r.formbuf}
+		r.multipart = multipart.NewWriter(r.multipartOut)
+		_ = r.multipart.SetBoundary(randomBoundary(r.config.Rand))
 		r.setBody(opChain, "WithMultipart()", r.formbuf, 0, false)
 	}
 
 	return r
 }
 
-// Expect constructs http.Request, sends it, receives http.Response, and
-// returns a new Response instance.
-//
-// Request is sent using Client interface, or WebsocketDialer in case of
-// WebSocket request.
-//
-// After calling Expect, there should not be any more calls of Expect or
-// other WithXXX methods on the same Request instance.
-//
-// Example:
-//
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithJSON(map[string]interface{}{"foo": 123})
-//	resp := req.Expect()
-//	resp.Status(http.StatusOK)
-func (r *Request) Expect() *Response {
-	opChain := r.chain.enter("Expect()")
-	defer opChain.leave()
+// WithGzip enables gzip compression of request body.
+//
+// Body is compressed in Expect(), after it's fully constructed, so WithGzip()
+// may be called before or after WithJSON(), WithForm(), WithBytes(), etc.
+// Content-Encoding header is set to "gzip", and Content-Length is set to the
+// length of the compressed body.
+//
+// If body is streamed (see WithReader and WithChunked), it is compressed
+// on the fly while being sent, so its length is unknown in advance, and
+// request is sent using chunked transfer encoding.
+//
+// If request has no body, compression is skipped and Content-Encoding header
+// is not set.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithJSON(map[string]interface{}{"foo": 123})
+//	req.WithGzip()
+func (r *Request) WithGzip() *Request {
+	opChain := r.chain.enter("WithGzip()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithGzip()") {
+		return r
+	}
+
+	r.gzip = true
+
+	return r
+}
+
+// Expect constructs http.Request, sends it, receives http.Response, and
+// returns a new Response instance.
+//
+// Request is sent using Client interface, or WebsocketDialer in case of
+// WebSocket request.
+//
+// After calling Expect, there should not be any more calls of Expect or
+// other WithXXX methods on the same Request instance.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithJSON(map[string]interface{}{"foo": 123})
+//	resp := req.Expect()
+//	resp.Status(http.StatusOK)
+func (r *Request) Expect() *Response {
+	opChain := r.chain.enter("Expect()")
+	defer opChain.leave()
+
+	resp := r.expect(opChain)
+
+	if resp == nil {
+		resp = newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	return resp
+}
+
+func (r *Request) expect(opChain *chain) *Response {
+	if !r.prepare(opChain, "Expect()") {
+		return nil
+	}
+
+	// after return from prepare(), all subsequent calls to WithXXX and Expect will
+	// abort early due to checkOrder(); so we can safely proceed without a lock
+
+	resp := r.execute(opChain)
+
+	if resp == nil {
+		return nil
+	}
+
+	for _, matcher := range r.matchers {
+		matcher(resp)
+	}
+
+	return resp
+}
+
+// Poll sends request repeatedly until given condition is met or timeout
+// elapses, and returns the last received Response.
+//
+// After every attempt, the until function is invoked with the received
+// response. If it returns true, polling stops and the response is returned.
+// Otherwise, Poll waits for the given interval and sends the request again.
+//
+// Assertions made on response inside until are not reported as test
+// failures, only logged, and until has no way to check whether they
+// succeeded. Hence, until should inspect the response using Raw() methods,
+// e.g. resp.Raw().StatusCode or resp.JSON().Raw(), and assertions should
+// be made on the returned response instead.
+//
+// If condition is not met before timeout, Poll reports failure with the
+// number of attempts and the status of the last received response.
+//
+// Polling is independent of retries: if WithMaxRetries() is used, every
+// attempt may be additionally retried on errors according to retry policy.
+// If request fails to be sent, Poll fails immediately.
+//
+// Request body is resent on every attempt, so Poll can't be used with
+// streamed bodies (WithReader and WithFileStream). It can't be used with
+// WithoutBodyBuffering() and WithWebsocketUpgrade() as well.
+//
+// Like Expect, Poll should be the last method called on Request.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/jobs/123")
+//	resp := req.Poll(time.Second, time.Minute, func(resp *Response) bool {
+//		return resp.Raw().StatusCode == http.StatusOK
+//	})
+//	resp.JSON().Object().HasValue("state", "done")
+func (r *Request) Poll(
+	interval, timeout time.Duration, until func(resp *Response) bool,
+) *Response {
+	opChain := r.chain.enter("Poll()")
+	defer opChain.leave()
+
+	resp := r.poll(opChain, interval, timeout, until)
+
+	if resp == nil {
+		resp = newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	return resp
+}
+
+func (r *Request) poll(
+	opChain *chain,
+	interval, timeout time.Duration, until func(resp *Response) bool,
+) *Response {
+	if !r.prepare(opChain, "Poll()") {
+		return nil
+	}
+
+	if !r.checkPoll(opChain, interval, timeout, until) {
+		return nil
+	}
+
+	if !r.encode(opChain) {
+		return nil
+	}
+
+	if _, ok := r.httpReq.Body.(*bodyStream); ok {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("Poll() can't be used with streamed body:" +
+					" streamed body can't be resent"),
+			},
+		})
+		return nil
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	// request context, as set by Config.Context or WithContext; send()
+	// replaces it with per-attempt context, so it's restored every time
+	ctx := r.httpReq.Context()
+
+	for attempt := 1; ; attempt++ {
+		// failures of assertions made inside until are logged, but
+		// not propagated to request chain
+		attemptChain := opChain.clone()
+		attemptChain.setRoot()
+		attemptChain.setSeverity(SeverityLog)
+
+		r.httpReq = r.httpReq.WithContext(ctx)
+
+		resp := r.send(opChain, attemptChain)
+		if resp == nil {
+			return nil
+		}
+
+		if until(resp) {
+			return r.pollResult(opChain, resp)
+		}
+
+		if resp.httpResp.Body != nil {
+			_ = resp.httpResp.Body.Close()
+		}
+
+		if !time.Now().Add(interval).Before(deadline) {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("polling condition was not met within %v", timeout),
+					fmt.Errorf("made %d attempt(s), last response status: %s",
+						attempt, statusCodeText(resp.httpResp.StatusCode)),
+				},
+			})
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("request context is done while polling"),
+					ctx.Err(),
+				},
+			})
+			return nil
+		case <-r.sleepFn(interval):
+		}
+	}
+}
+
+func (r *Request) checkPoll(
+	opChain *chain,
+	interval, timeout time.Duration, until func(resp *Response) bool,
+) bool {
+	var err error
 
-	resp := r.expect(opChain)
+	switch {
+	case interval <= 0:
+		err = fmt.Errorf("unexpected non-positive interval argument: %v", interval)
 
-	if resp == nil {
-		resp = newResponse(responseOpts{
-			config: r.config,
-			chain:  opChain,
-		})
-	}
+	case timeout <= 0:
+		err = fmt.Errorf("unexpected non-positive timeout argument: %v", timeout)
 
-	return resp
-}
+	case until == nil:
+		err = errors.New("unexpected nil until argument")
 
-func (r *Request) expect(opChain *chain) *Response {
-	if !r.prepare(opChain) {
-		return nil
+	case r.wsUpgrade:
+		err = errors.New("Poll() can't be combined with WithWebsocketUpgrade()")
+
+	case r.noBodyBuffering:
+		err = errors.New("Poll() can't be combined with WithoutBodyBuffering():" +
+			" response body is inspected on every attempt")
 	}
 
-	// after return from prepare(), all subsequent calls to WithXXX and Expect will
-	// abort early due to checkOrder(); so we can safely proceed without a lock
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertUsage,
+			Errors: []error{err},
+		})
+		return false
+	}
 
-	resp := r.execute(opChain)
+	return true
+}
 
-	if resp == nil {
-		return nil
-	}
+// pollResult re-creates response of the last attempt on request chain.
+func (r *Request) pollResult(opChain *chain, resp *Response) *Response {
+	result := newResponse(responseOpts{
+		config:   r.config,
+		chain:    opChain,
+		httpResp: resp.httpResp,
+		rtt:      []time.Duration{*resp.rtt},
+		trace:    resp.trace,
+	})
 
 	for _, matcher := range r.matchers {
-		matcher(resp)
+		matcher(result)
 	}
 
-	return resp
+	return result
 }
 
-func (r *Request) prepare(opChain *chain) bool {
+func (r *Request) prepare(opChain *chain, funcCall string) bool {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -1989,7 +4230,7 @@ func (r *Request) prepare(opChain *chain) bool {
 		return false
 	}
 
-	if !r.checkOrder(opChain, "Expect()") {
+	if !r.checkOrder(opChain, funcCall) {
 		return false
 	}
 
@@ -1999,24 +4240,76 @@ func (r *Request) prepare(opChain *chain) bool {
 }
 
 func (r *Request) execute(opChain *chain) *Response {
-	if !r.encodeRequest(opChain) {
+	if !r.encode(opChain) {
 		return nil
 	}
 
+	return r.send(opChain, opChain)
+}
+
+func (r *Request) encode(opChain *chain) bool {
+	if r.methodSetter != "" && r.bodySetter != "" &&
+		(r.httpReq.Method == http.MethodGet || r.httpReq.Method == http.MethodHead) {
+		r.reportWarning(opChain,
+			fmt.Errorf("HTTP method %q set by %s is used with body set by %s",
+				r.httpReq.Method, r.methodSetter, r.bodySetter))
+	}
+
+	if !r.encodeRequest(opChain) {
+		return false
+	}
+
+	if r.wsUpgrade && r.httpReq.Close {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("WithConnectionClose() can't be combined" +
+					" with WithWebsocketUpgrade()"),
+			},
+		})
+		return false
+	}
+
 	if r.wsUpgrade {
 		if !r.encodeWebsocketRequest(opChain) {
-			return nil
+			return false
 		}
+	} else if len(r.wsSubprotocols) != 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("WithWebsocketSubprotocols() can be used only" +
+					" together with WithWebsocketUpgrade()"),
+			},
+		})
+		return false
 	}
 
 	for _, transform := range r.transformers {
 		transform(r.httpReq)
 
 		if opChain.failed() {
-			return nil
+			return false
 		}
 	}
 
+	return true
+}
+
+// send sends encoded request; failures are reported to opChain,
+// and returned response is attached to respChain.
+func (r *Request) send(opChain *chain, respChain *chain) *Response {
+	if ctx := r.config.Context; ctx != nil && ctx.Err() != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("request context is done before sending request"),
+				ctx.Err(),
+			},
+		})
+		return nil
+	}
+
 	var (
 		httpResp *http.Response
 		websock  *websocket.Conn
@@ -2032,23 +4325,71 @@ func (r *Request) execute(opChain *chain) *Response {
 		return nil
 	}
 
+	if r.maxResponseSize > 0 && httpResp.ContentLength > r.maxResponseSize {
+		if httpResp.Body != nil {
+			_ = httpResp.Body.Close()
+		}
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf(
+					"response body size %d exceeds configured limit of %d bytes",
+					httpResp.ContentLength, r.maxResponseSize),
+			},
+		})
+		return nil
+	}
+
+	var trace *TraceInfo
+	if r.tracer != nil {
+		trace = r.tracer.snapshot()
+	}
+
+	var rawHeader http.Header
+	if r.rawHeaders != nil {
+		rawHeader = r.rawHeaders.header(httpResp)
+	}
+
 	return newResponse(responseOpts{
-		config:    r.config,
-		chain:     opChain,
-		httpResp:  httpResp,
-		websocket: websock,
-		rtt:       []time.Duration{elapsed},
+		config:      r.config,
+		chain:       respChain,
+		httpResp:    httpResp,
+		websocket:   websock,
+		rtt:         []time.Duration{elapsed},
+		trace:       trace,
+		rawHeader:   rawHeader,
+		noBuffering: r.noBodyBuffering,
 	})
 }
 
 func (r *Request) encodeRequest(opChain *chain) bool {
-	r.httpReq.URL.Path = concatPaths(r.httpReq.URL.Path, r.path)
+	if r.absoluteURL != nil {
+		r.httpReq.URL = r.absoluteURL
 
-	if r.query != nil {
-		r.httpReq.URL.RawQuery = r.query.Encode()
+		if r.query != nil {
+			if r.httpReq.URL.RawQuery != "" {
+				r.httpReq.URL.RawQuery += "&" + r.query.Encode()
+			} else {
+				r.httpReq.URL.RawQuery = r.query.Encode()
+			}
+		}
+	} else {
+		r.httpReq.URL.Path = concatPaths(r.httpReq.URL.Path, r.path)
+
+		if r.query != nil {
+			r.httpReq.URL.RawQuery = r.query.Encode()
+		}
 	}
 
-	if r.multipart != nil {
+	if r.rawQuerySet {
+		r.httpReq.URL.RawQuery = r.rawQuery
+	}
+
+	if r.multipart != nil && len(r.fileStreams) != 0 {
+		if !r.encodeFileStreams(opChain) {
+			return false
+		}
+	} else if r.multipart != nil {
 		if err := r.multipart.Close(); err != nil {
 			opChain.fail(AssertionFailure{
 				Type: AssertOperation,
@@ -2061,11 +4402,29 @@ func (r *Request) encodeRequest(opChain *chain) bool {
 		}
 
 		r.setType(opChain, "Expect()", r.multipart.FormDataContentType(), true)
-		r.setBody(opChain, "Expect()", r.formbuf, r.formbuf.Len(), true)
+		r.setBody(opChain, "Expect()", r.formbuf, int64(r.formbuf.Len()), true)
 	} else if r.form != nil {
 		s := r.form.Encode()
 		r.setBody(opChain,
-			"WithForm() or WithFormField()", strings.NewReader(s), len(s), false)
+			"WithForm() or WithFormField()", strings.NewReader(s), int64(len(s)), false)
+	}
+
+	if _, ok := r.httpReq.Body.(*bodyStream); ok && r.bodySetter == "WithReader()" {
+		if !r.checkStreamedBody(opChain, "WithReader()") {
+			return false
+		}
+	}
+
+	if r.gzip {
+		if !r.encodeGzip(opChain) {
+			return false
+		}
+	}
+
+	if len(r.httpReq.Trailer) != 0 {
+		if !r.encodeTrailers(opChain) {
+			return false
+		}
 	}
 
 	if r.httpReq.Body == nil {
@@ -2076,7 +4435,129 @@ func (r *Request) encodeRequest(opChain *chain) bool {
 		r.httpReq = r.httpReq.WithContext(r.config.Context)
 	}
 
+	if r.tracer != nil || r.rawHeaders != nil {
+		r.httpReq = r.httpReq.WithContext(r.withTrace(r.httpReq.Context()))
+	}
+
+	if !r.setupTransport(opChain) {
+		return false
+	}
+
 	r.setupRedirects(opChain)
+	r.setupCookies()
+
+	return true
+}
+
+func (r *Request) encodeTrailers(opChain *chain) bool {
+	if r.httpReq.Body == nil || r.httpReq.Body == http.NoBody ||
+		r.httpReq.ContentLength == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("WithTrailer() requires non-empty request body"),
+			},
+		})
+		return false
+	}
+
+	// trailers can be sent only with chunked encoding
+	r.httpReq.ContentLength = -1
+
+	return true
+}
+
+func (r *Request) encodeFileStreams(opChain *chain) bool {
+	if !r.checkStreamedBody(opChain, "WithFileStream()") {
+		return false
+	}
+
+	r.setType(opChain, "Expect()", r.multipart.FormDataContentType(), true)
+
+	r.httpReq.Body = newBodyStream(func(w io.Writer) error {
+		if _, err := r.formbuf.WriteTo(w); err != nil {
+			return err
+		}
+
+		r.multipartOut.w = w
+
+		for _, stream := range r.fileStreams {
+			wr, err := createFormFile(r.multipart, stream.key, stream.path, "")
+			if err != nil {
+				return err
+			}
+
+			if _, err := io.Copy(wr, stream.reader); err != nil {
+				return err
+			}
+		}
+
+		return r.multipart.Close()
+	})
+	r.httpReq.ContentLength = -1
+
+	return true
+}
+
+func (r *Request) checkStreamedBody(opChain *chain, setter string) bool {
+	if r.maxRetries > 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					"%s can't be combined with WithMaxRetries():"+
+						" streamed body can't be resent", setter),
+			},
+		})
+		return false
+	}
+
+	if r.redirectPolicy == FollowAllRedirects {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					"%s can't be combined with FollowAllRedirects policy:"+
+						" streamed body can't be resent", setter),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+func (r *Request) encodeGzip(opChain *chain) bool {
+	if r.httpReq.Body == nil || r.httpReq.Body == http.NoBody {
+		return true
+	}
+
+	if _, ok := r.httpReq.Body.(*bodyStream); ok {
+		// keep streamed body streamed, so that it's not buffered for resending
+		r.httpReq.Body = newBodyStream(gzipWriteFn(r.httpReq.Body))
+		r.httpReq.ContentLength = -1
+		r.httpReq.Header.Set("Content-Encoding", "gzip")
+
+		return true
+	}
+
+	var buf bytes.Buffer
+
+	err := gzipWriteFn(r.httpReq.Body)(&buf)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to compress request body"),
+				err,
+			},
+		})
+		return false
+	}
+
+	r.httpReq.Body = ioutil.NopCloser(bytes.NewReader(buf.Bytes()))
+	r.httpReq.ContentLength = int64(buf.Len())
+	r.httpReq.Header.Set("Content-Encoding", "gzip")
 
 	return true
 }
@@ -2103,6 +4584,11 @@ func (r *Request) encodeWebsocketRequest(opChain *chain) bool {
 		r.httpReq.URL.Scheme = "ws"
 	}
 
+	if len(r.wsSubprotocols) != 0 {
+		r.httpReq.Header.Set("Sec-WebSocket-Protocol",
+			strings.Join(r.wsSubprotocols, ", "))
+	}
+
 	return true
 }
 
@@ -2114,71 +4600,201 @@ func (r *Request) sendRequest(opChain *chain) (*http.Response, time.Duration) {
 	if err != nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertOperation,
-			Errors: []error{
+			Errors: r.sendErrors(
 				errors.New("failed to send http request"),
 				err,
-			},
+			),
 		})
 		return nil, 0
 	}
 
-	return resp, elapsed
+	return resp, elapsed
+}
+
+func (r *Request) sendWebsocketRequest(opChain *chain) (
+	*http.Response, *websocket.Conn, time.Duration,
+) {
+	var conn *websocket.Conn
+	resp, elapsed, err := r.retryRequest(func() (resp *http.Response, err error) {
+		if dialer, ok := r.config.WebsocketDialer.(*websocket.Dialer); ok {
+			conn, resp, err = dialer.DialContext(r.httpReq.Context(),
+				r.httpReq.URL.String(), r.httpReq.Header)
+		} else {
+			conn, resp, err = r.config.WebsocketDialer.Dial(
+				r.httpReq.URL.String(), r.httpReq.Header)
+		}
+		return resp, err
+	})
+
+	if err != nil && err != websocket.ErrBadHandshake {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: r.sendErrors(
+				errors.New("failed to send websocket request"),
+				err,
+			),
+		})
+		return nil, nil, 0
+	}
+
+	if conn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to upgrade connection to websocket"),
+			},
+		})
+		return nil, nil, 0
+	}
+
+	if !r.checkWebsocketSubprotocol(opChain, conn.Subprotocol()) {
+		_ = conn.Close()
+		return nil, nil, 0
+	}
+
+	return resp, conn, elapsed
+}
+
+func (r *Request) checkWebsocketSubprotocol(opChain *chain, protocol string) bool {
+	if protocol == "" || len(r.wsSubprotocols) == 0 {
+		return true
+	}
+
+	for _, p := range r.wsSubprotocols {
+		if p == protocol {
+			return true
+		}
+	}
+
+	var protocolList []interface{}
+	for _, p := range r.wsSubprotocols {
+		protocolList = append(protocolList, p)
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:     AssertBelongs,
+		Actual:   &AssertionValue{protocol},
+		Expected: &AssertionValue{AssertionList(protocolList)},
+		Errors: []error{
+			errors.New(
+				"expected: server selects one of advertised websocket subprotocols"),
+		},
+	})
+
+	return false
+}
+
+// sendErrors returns given errors, adding messages about timeout and
+// retries if needed.
+func (r *Request) sendErrors(msg error, err error) []error {
+	errs := r.timeoutErrors(msg, err)
+
+	if r.attempts > 1 {
+		errs = append(errs, fmt.Errorf("request failed after %d attempts", r.attempts))
+	}
+
+	return errs
 }
 
-func (r *Request) sendWebsocketRequest(opChain *chain) (
-	*http.Response, *websocket.Conn, time.Duration,
-) {
-	var conn *websocket.Conn
-	resp, elapsed, err := r.retryRequest(func() (resp *http.Response, err error) {
-		conn, resp, err = r.config.WebsocketDialer.Dial(
-			r.httpReq.URL.String(), r.httpReq.Header)
-		return resp, err
-	})
+// timeoutErrors returns given errors and, if err was caused by the request
+// timeout or deadline, adds a message about it.
+func (r *Request) timeoutErrors(msg error, err error) []error {
+	if r.timeout <= 0 && r.deadline.IsZero() {
+		return []error{msg, err}
+	}
 
-	if err != nil && err != websocket.ErrBadHandshake {
-		opChain.fail(AssertionFailure{
-			Type: AssertOperation,
-			Errors: []error{
-				errors.New("failed to send websocket request"),
-				err,
-			},
-		})
-		return nil, nil, 0
+	if r.config.Context != nil && r.config.Context.Err() != nil {
+		return []error{msg, err}
 	}
 
-	if conn == nil {
-		opChain.fail(AssertionFailure{
-			Type: AssertOperation,
-			Errors: []error{
-				errors.New("failed to upgrade connection to websocket"),
-			},
-		})
-		return nil, nil, 0
+	deadline, ok := r.httpReq.Context().Deadline()
+	if !ok || time.Now().Before(deadline) {
+		return []error{msg, err}
 	}
 
-	return resp, conn, elapsed
+	if !r.deadline.IsZero() && deadline.Equal(r.deadline) {
+		return []error{
+			msg,
+			fmt.Errorf("request exceeded configured deadline of %v",
+				r.deadline.Format(time.RFC3339Nano)),
+			err,
+		}
+	}
+
+	// deadline may be also inherited from context set by WithContext
+	if r.timeout > 0 && deadline.Equal(r.timeoutDeadline) {
+		return []error{
+			msg,
+			fmt.Errorf("request exceeded timeout of %v set by %s",
+				r.timeout, r.timeoutSetter),
+			err,
+		}
+	}
+
+	return []error{msg, err}
+}
+
+// requestDeadline returns the earliest of configured deadline and
+// timeout counted from now; returns false if neither is set.
+// Remembers deadline derived from timeout for timeoutErrors.
+func (r *Request) requestDeadline() (time.Time, bool) {
+	deadline := r.deadline
+
+	r.timeoutDeadline = time.Time{}
+
+	if r.timeout > 0 {
+		timeoutDeadline := time.Now().Add(r.timeout)
+		if deadline.IsZero() || timeoutDeadline.Before(deadline) {
+			deadline = timeoutDeadline
+			r.timeoutDeadline = timeoutDeadline
+		}
+	}
+
+	return deadline, !deadline.IsZero()
 }
 
 func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 	*http.Response, time.Duration, error,
 ) {
 	if r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
-		if _, ok := r.httpReq.Body.(*bodyWrapper); !ok {
+		switch r.httpReq.Body.(type) {
+		case *bodyWrapper:
+			// already wrapped
+		case *bodyStream:
+			// streamed body can't be read multiple times
+		default:
 			r.httpReq.Body = newBodyWrapper(r.httpReq.Body, nil)
 		}
 	}
 
 	reqBody, _ := r.httpReq.Body.(*bodyWrapper)
+	_, isStream := r.httpReq.Body.(*bodyStream)
 
 	delay := r.minRetryDelay
 	i := 0
 
+	// context of every attempt is derived from the base context, so that
+	// attempt number and deadline of previous attempts are not inherited
+	baseCtx := r.httpReq.Context()
+
 	for {
+		r.attempts = i + 1
+		r.httpReq = r.httpReq.WithContext(
+			withRequestAttempt(baseCtx, r.attempts))
+
+		r.applyHeaderFuncs()
+
 		for _, printer := range r.config.Printers {
 			if reqBody != nil {
 				reqBody.Rewind()
 			}
-			printer.Request(r.httpReq)
+			if isStream {
+				printReq := *r.httpReq
+				printReq.Body = http.NoBody
+				printer.Request(&printReq)
+			} else {
+				printer.Request(r.httpReq)
+			}
 		}
 
 		if reqBody != nil {
@@ -2187,13 +4803,11 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 
 		var cancelFn context.CancelFunc
 
-		if r.timeout > 0 {
+		if deadline, ok := r.requestDeadline(); ok {
 			var ctx context.Context
-			if r.config.Context != nil {
-				ctx, cancelFn = context.WithTimeout(r.config.Context, r.timeout)
-			} else {
-				ctx, cancelFn = context.WithTimeout(context.Background(), r.timeout)
-			}
+			ctx, cancelFn = context.WithDeadline(baseCtx, deadline)
+
+			ctx = withRequestAttempt(ctx, r.attempts)
 
 			r.httpReq = r.httpReq.WithContext(ctx)
 		}
@@ -2202,18 +4816,38 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 		resp, err := reqFunc()
 		elapsed := time.Since(start)
 
+		if cleanup := r.transportCleanup; cleanup != nil {
+			cancelFn = withCleanup(cancelFn, cleanup)
+		}
+
 		if resp != nil && resp.Body != nil {
-			resp.Body = newBodyWrapper(resp.Body, cancelFn)
+			body := resp.Body
+			if r.maxResponseSize > 0 {
+				body = newLimitedBody(body, resp.ContentLength, r.maxResponseSize)
+			}
+			if r.noBodyBuffering {
+				resp.Body = newUnbufferedBody(body, cancelFn)
+			} else {
+				resp.Body = newBodyWrapper(body, cancelFn)
+			}
 		} else if cancelFn != nil {
 			cancelFn()
 		}
 
 		if resp != nil {
 			for _, printer := range r.config.Printers {
-				if resp.Body != nil {
-					resp.Body.(*bodyWrapper).Rewind()
+				switch body := resp.Body.(type) {
+				case *bodyWrapper:
+					body.Rewind()
+					printer.Response(resp, elapsed)
+				case *unbufferedBody:
+					// don't let printer consume unbuffered body
+					respCopy := *resp
+					respCopy.Body = http.NoBody
+					printer.Response(&respCopy, elapsed)
+				default:
+					printer.Response(resp, elapsed)
 				}
-				printer.Response(resp, elapsed)
 			}
 		}
 
@@ -2226,10 +4860,21 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 			return resp, elapsed, err
 		}
 
+		if !r.deadline.IsZero() && !time.Now().Before(r.deadline) {
+			return resp, elapsed, err
+		}
+
 		if resp != nil && resp.Body != nil {
 			resp.Body.Close()
 		}
 
+		if r.retryBackoff != nil {
+			delay = r.retryBackoff(i)
+			if delay < 0 {
+				delay = 0
+			}
+		}
+
 		if configCtx := r.config.Context; configCtx != nil {
 			select {
 			case <-configCtx.Done():
@@ -2247,7 +4892,22 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 	}
 }
 
+// withCleanup returns function that calls cancelFn (if non-nil)
+// and then cleanup.
+func withCleanup(cancelFn context.CancelFunc, cleanup func()) context.CancelFunc {
+	return func() {
+		if cancelFn != nil {
+			cancelFn()
+		}
+		cleanup()
+	}
+}
+
 func (r *Request) shouldRetry(resp *http.Response, err error) bool {
+	if r.retryPolicyFn != nil {
+		return r.retryPolicyFn(resp, err)
+	}
+
 	var (
 		isTemporaryNetworkError bool // Deprecated
 		isTimeoutError          bool
@@ -2289,6 +4949,246 @@ func (r *Request) shouldRetry(resp *http.Response, err error) bool {
 	return false
 }
 
+func (r *Request) withTrace(ctx context.Context) context.Context {
+	if r.tracer != nil {
+		ctx = httptrace.WithClientTrace(ctx, r.tracer.clientTrace())
+	}
+
+	if r.rawHeaders != nil {
+		ctx = httptrace.WithClientTrace(ctx, r.rawHeaders.clientTrace())
+	}
+
+	return ctx
+}
+
+func (r *Request) setupCookies() {
+	httpClient, _ := r.config.Client.(*http.Client)
+
+	if httpClient == nil || httpClient.Jar == nil {
+		return
+	}
+
+	cookies := r.httpReq.Cookies()
+	if len(cookies) == 0 {
+		return
+	}
+
+	names := make(map[string]struct{}, len(cookies))
+	for _, c := range cookies {
+		names[c.Name] = struct{}{}
+	}
+
+	clientCopy := *httpClient
+	clientCopy.Jar = &cookieOverrideJar{
+		jar:   httpClient.Jar,
+		names: names,
+	}
+	r.config.Client = &clientCopy
+}
+
+func (r *Request) setupTransport(opChain *chain) bool {
+	if r.tlsClientCert != nil && r.tlsClientCert == r.config.TLSClientCert &&
+		r.hasConfigClientCert() {
+		// certificate is already installed, see Config.withClientCert
+		r.tlsClientCert = nil
+	}
+
+	// raw headers are recorded only for http requests
+	rawHeaders := r.rawHeaders != nil && !r.wsUpgrade
+
+	if r.proxyURL == nil && r.unixSocket == "" && r.tlsClientCert == nil &&
+		!rawHeaders {
+		return true
+	}
+
+	if r.unixSocket != "" && !r.checkUnixSocket(opChain) {
+		return false
+	}
+
+	var setters []string
+	if r.proxyURL != nil {
+		setters = append(setters, "WithProxy()")
+	}
+	if r.unixSocket != "" {
+		setters = append(setters, "WithUnixSocket()")
+	}
+	if r.tlsClientCert != nil {
+		setters = append(setters, r.tlsCertSetter)
+	}
+	if rawHeaders {
+		setters = append(setters, "WithRawHeaders()")
+	}
+
+	if r.wsUpgrade {
+		return r.setupWebsocketDialer(opChain, strings.Join(setters, " and "))
+	}
+
+	return r.setupHTTPTransport(opChain, strings.Join(setters, " and "))
+}
+
+func (r *Request) hasConfigClientCert() bool {
+	if r.wsUpgrade {
+		dialer, _ := r.config.WebsocketDialer.(*websocket.Dialer)
+		return dialer != nil && dialer == r.config.tlsCertDialer
+	}
+
+	client, _ := r.config.Client.(*http.Client)
+	return client != nil && client == r.config.tlsCertClient
+}
+
+func (r *Request) setupHTTPTransport(opChain *chain, setter string) bool {
+	httpClient, _ := r.config.Client.(*http.Client)
+	if httpClient == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					"%s can be used only if Client is *http.Client", setter),
+			},
+		})
+		return false
+	}
+
+	var transport *http.Transport
+
+	switch tp := httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = tp.Clone()
+	default:
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					"%s can be used only if Client.Transport"+
+						" is nil or *http.Transport", setter),
+			},
+		})
+		return false
+	}
+
+	if r.proxyURL != nil {
+		transport.Proxy = http.ProxyURL(r.proxyURL)
+	}
+
+	if r.unixSocket != "" {
+		transport.Proxy = nil
+		transport.DialContext = r.dialUnixSocket
+	}
+
+	if r.tlsClientCert != nil {
+		transport.TLSClientConfig = withClientCert(
+			transport.TLSClientConfig, *r.tlsClientCert)
+	}
+
+	if r.rawHeaders != nil {
+		r.rawHeaders.setupDialers(transport)
+	}
+
+	clientCopy := *httpClient
+	clientCopy.Transport = transport
+	r.config.Client = &clientCopy
+
+	// transport copy is not used by other requests, so its idle connections
+	// should be closed when response is consumed, see retryRequest
+	r.transportCleanup = transport.CloseIdleConnections
+
+	return true
+}
+
+func (r *Request) setupWebsocketDialer(opChain *chain, setter string) bool {
+	dialer, _ := r.config.WebsocketDialer.(*websocket.Dialer)
+	if dialer == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					"%s can be used only if WebsocketDialer is *websocket.Dialer",
+					setter),
+			},
+		})
+		return false
+	}
+
+	dialerCopy := *dialer
+
+	if r.proxyURL != nil {
+		dialerCopy.Proxy = http.ProxyURL(r.proxyURL)
+	}
+
+	if r.unixSocket != "" {
+		dialerCopy.Proxy = nil
+		dialerCopy.NetDialContext = r.dialUnixSocket
+	}
+
+	if r.tlsClientCert != nil {
+		dialerCopy.TLSClientConfig = withClientCert(
+			dialerCopy.TLSClientConfig, *r.tlsClientCert)
+	}
+
+	r.config.WebsocketDialer = &dialerCopy
+
+	return true
+}
+
+func (r *Request) checkUnixSocket(opChain *chain) bool {
+	if r.proxyURL != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("WithUnixSocket() can't be combined with WithProxy()"),
+			},
+		})
+		return false
+	}
+
+	fi, err := os.Stat(r.unixSocket)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("unix socket %q is not available", r.unixSocket),
+				err,
+			},
+		})
+		return false
+	}
+
+	if fi.Mode()&os.ModeSocket == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("path %q is not a unix socket (mode %s)",
+					r.unixSocket, fi.Mode()),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+func (r *Request) dialUnixSocket(
+	ctx context.Context, _, _ string,
+) (net.Conn, error) {
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "unix", r.unixSocket)
+}
+
+func withClientCert(config *tls.Config, cert tls.Certificate) *tls.Config {
+	if config == nil {
+		config = &tls.Config{}
+	} else {
+		config = config.Clone()
+	}
+
+	config.Certificates = []tls.Certificate{cert}
+	config.GetClientCertificate = nil
+
+	return config
+}
+
 func (r *Request) setupRedirects(opChain *chain) {
 	httpClient, _ := r.config.Client.(*http.Client)
 
@@ -2315,21 +5215,28 @@ func (r *Request) setupRedirects(opChain *chain) {
 			return
 		}
 	} else {
-		if r.redirectPolicy != defaultRedirectPolicy || r.maxRedirects != -1 {
+		if r.redirectPolicy != defaultRedirectPolicy || r.maxRedirects != -1 ||
+			r.httpReq.Close {
 			clientCopy := *httpClient
 			httpClient = &clientCopy
 			r.config.Client = &clientCopy
 		}
 	}
 
-	if r.redirectPolicy == DontFollowRedirects {
+	if r.redirectPolicy == DontFollowRedirects || r.maxRedirects == 0 {
 		httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse
 		}
-	} else if r.maxRedirects >= 0 {
+	} else if r.maxRedirects > 0 {
+		checkRedirect := httpClient.CheckRedirect
+
 		httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
 			if len(via) > r.maxRedirects {
-				return fmt.Errorf("stopped after %d redirects", r.maxRedirects)
+				return fmt.Errorf("stopped after %d redirects: %s",
+					r.maxRedirects, formatRedirectChain(req, via))
+			}
+			if checkRedirect != nil {
+				return checkRedirect(req, via)
 			}
 			return nil
 		}
@@ -2337,6 +5244,23 @@ func (r *Request) setupRedirects(opChain *chain) {
 		httpClient.CheckRedirect = nil
 	}
 
+	if httpClient != nil && r.httpReq.Close && r.redirectPolicy != DontFollowRedirects {
+		checkRedirect := httpClient.CheckRedirect
+
+		// http.Client doesn't copy Close field to redirect requests
+		httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			req.Close = true
+			if checkRedirect != nil {
+				return checkRedirect(req, via)
+			}
+			// same as default check of http.Client
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		}
+	}
+
 	if r.redirectPolicy == FollowAllRedirects {
 		if r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
 			if _, ok := r.httpReq.Body.(*bodyWrapper); !ok {
@@ -2353,6 +5277,18 @@ func (r *Request) setupRedirects(opChain *chain) {
 	}
 }
 
+func formatRedirectChain(req *http.Request, via []*http.Request) string {
+	var chain []string
+
+	for _, r := range via {
+		chain = append(chain, r.URL.String())
+	}
+
+	chain = append(chain, req.URL.String())
+
+	return strings.Join(chain, " -> ")
+}
+
 var typeErr = `ambiguous request "Content-Type" header values:
   first set by %s:
     %q
@@ -2389,8 +5325,24 @@ var bodyErr = `ambiguous request body contents:
   first set by %s
   then replaced by %s`
 
+// Report non-fatal failure without failing the chain.
+func (r *Request) reportWarning(opChain *chain, err error) {
+	warnChain := opChain.clone()
+
+	warnChain.setRoot()
+	warnChain.setSeverity(SeverityLog)
+
+	warnOpChain := warnChain.enter("")
+	defer warnOpChain.leave()
+
+	warnOpChain.fail(AssertionFailure{
+		Type:   AssertUsage,
+		Errors: []error{err},
+	})
+}
+
 func (r *Request) setBody(
-	opChain *chain, setter string, reader io.Reader, len int, overwrite bool,
+	opChain *chain, setter string, reader io.Reader, len int64, overwrite bool,
 ) {
 	if !overwrite && r.bodySetter != "" {
 		opChain.fail(AssertionFailure{
@@ -2411,7 +5363,7 @@ func (r *Request) setBody(
 		r.httpReq.ContentLength = 0
 	} else {
 		r.httpReq.Body = ioutil.NopCloser(reader)
-		r.httpReq.ContentLength = int64(len)
+		r.httpReq.ContentLength = len
 	}
 
 	r.bodySetter = setter
@@ -2448,3 +5400,83 @@ func mustWrite(w io.Writer, s string) {
 		panic(err)
 	}
 }
+
+var queryEncoderType = reflect.TypeOf(new(query.Encoder)).Elem()
+
+type queryEncoderField struct {
+	path  string
+	value reflect.Value
+}
+
+// findQueryFieldError returns error naming struct field (possibly nested)
+// which implements query.Encoder and failed to encode itself.
+// If there is only one such field, it is reported without invoking its
+// encoder again; otherwise each encoder is invoked individually.
+func findQueryFieldError(v reflect.Value) error {
+	fields := findQueryEncoderFields(v, "")
+
+	if len(fields) == 1 {
+		return fmt.Errorf("failed to encode field %s of type %s",
+			fields[0].path, fields[0].value.Type())
+	}
+
+	for _, field := range fields {
+		fv := field.value
+
+		// same as go-querystring does for nil pointers
+		if !reflect.Indirect(fv).IsValid() && fv.Type().Elem().Implements(queryEncoderType) {
+			fv = reflect.New(fv.Type().Elem())
+		}
+
+		enc := fv.Interface().(query.Encoder)
+		if err := enc.EncodeValues(field.path, &url.Values{}); err != nil {
+			return fmt.Errorf("failed to encode field %s of type %s",
+				field.path, field.value.Type())
+		}
+	}
+
+	return nil
+}
+
+func findQueryEncoderFields(v reflect.Value, prefix string) []queryEncoderField {
+	var fields []queryEncoderField
+
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		tag := sf.Tag.Get("url")
+		if tag == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+
+		if strings.Contains(tag, ",omitempty") && fv.IsZero() {
+			continue
+		}
+
+		path := sf.Name
+		if prefix != "" {
+			path = prefix + "." + sf.Name
+		}
+
+		if fv.Type().Implements(queryEncoderType) {
+			// encoder of inaccessible field can't be invoked
+			if fv.CanInterface() {
+				fields = append(fields, queryEncoderField{path, fv})
+			}
+			continue
+		}
+
+		fv = reflect.Indirect(fv)
+		if fv.Kind() == reflect.Struct {
+			fields = append(fields, findQueryEncoderFields(fv, path)...)
+		}
+	}
+
+	return fields
+}
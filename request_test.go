@@ -2,17 +2,28 @@ package httpexpect
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"math/rand"
 	"mime"
 	"mime/multipart"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -40,40 +51,74 @@ func TestRequest_FailedChain(t *testing.T) {
 	req.WithHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
 	req.WithContext(context.TODO())
 	req.WithTimeout(0)
+	req.WithTrace()
+	req.WithRawHeaders()
+	req.WithMaxResponseSize(1)
 	req.WithRedirectPolicy(FollowAllRedirects)
 	req.WithMaxRedirects(1)
 	req.WithRetryPolicy(RetryAllErrors)
+	req.WithRetryPolicyFunc(func(*http.Response, error) bool { return true })
 	req.WithMaxRetries(1)
 	req.WithRetryDelay(time.Millisecond, time.Millisecond)
 	req.WithWebsocketUpgrade()
+	req.WithWebsocketSubprotocols("foo")
 	req.WithWebsocketDialer(
 		NewWebsocketDialer(
 			http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})))
 	req.WithPath("foo", "bar")
 	req.WithPathObject(map[string]interface{}{"foo": "bar"})
+	req.WithPathTimeLayout(time.RFC1123)
 	req.WithQuery("foo", "bar")
 	req.WithQueryObject(map[string]interface{}{"foo": "bar"})
 	req.WithQueryString("foo=bar")
+	req.WithRawQuery("foo=bar")
 	req.WithURL("http://example.com")
+	req.WithAbsoluteURL("http://example.com")
 	req.WithHeaders(map[string]string{"foo": "bar"})
 	req.WithHeader("foo", "bar")
+	req.WithTrailer("foo", "bar")
+	req.WithHeaderFn("foo", func() string { return "bar" })
 	req.WithCookies(map[string]string{"foo": "bar"})
 	req.WithCookie("foo", "bar")
+	req.WithETagFrom(&Response{})
+	req.Clone().chain.assertFailed(t)
+	req.WithIfNoneMatchFrom(&Response{})
 	req.WithBasicAuth("foo", "bar")
+	req.WithoutBasicAuth()
+	req.WithBearerToken("foo")
+	req.WithMethod("POST")
+	req.WithoutBodyBuffering()
+	req.WithDeadline(time.Now())
+	req.WithProxy("http://127.0.0.1:3128")
+	req.WithUnixSocket("/tmp/test.sock")
+	req.WithMaxAttempts(3)
+	req.WithRetryBackoff(func(int) time.Duration { return 0 })
+	req.WithTLSClientCert(tls.Certificate{Certificate: [][]byte{{1}}})
 	req.WithHost("127.0.0.1")
+	req.WithConnectionClose()
 	req.WithProto("HTTP/1.1")
 	req.WithChunked(strings.NewReader("foo"))
+	req.WithReader(strings.NewReader("foo"), 3)
 	req.WithBytes([]byte("foo"))
 	req.WithText("foo")
 	req.WithJSON(map[string]string{"foo": "bar"})
+	req.WithYAML(map[string]string{"foo": "bar"})
 	req.WithForm(map[string]string{"foo": "bar"})
 	req.WithFormField("foo", "bar")
 	req.WithFile("foo", "bar", strings.NewReader("baz"))
 	req.WithFileBytes("foo", "bar", []byte("baz"))
+	req.WithFileFS(fstest.MapFS{"bar": {Data: []byte("baz")}}, "foo", "bar")
+	req.WithFileStream("foo", "bar", strings.NewReader("baz"))
 	req.WithMultipart()
+	req.WithGzip()
 
 	resp := req.Expect()
 	resp.chain.assertFailed(t)
+
+	resp = req.Poll(time.Millisecond, time.Second, func(*Response) bool {
+		return true
+	})
+	resp.chain.assertFailed(t)
 }
 
 func TestRequest_Constructors(t *testing.T) {
@@ -201,6 +246,46 @@ func TestRequest_Alias(t *testing.T) {
 	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
 }
 
+func TestRequest_Name(t *testing.T) {
+	client := &mockClient{
+		resp: http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+			Body: newMockBody(`{"foo":123}`),
+		},
+	}
+
+	handler := &mockAssertionHandler{}
+
+	e := WithConfig(Config{
+		TestName:         "TestExample",
+		Client:           client,
+		AssertionHandler: handler,
+	})
+
+	e.GET("/").
+		WithName("Step 1").
+		Expect().
+		JSON().Object().Value("foo").Number().IsEqual(456)
+
+	require.NotNil(t, handler.failure)
+	assert.Equal(t, "TestExample", handler.ctx.TestName)
+	assert.Equal(t, "Step 1", handler.ctx.RequestName)
+
+	handler.failure = nil
+	client.resp.Body = newMockBody(`{"foo":123}`)
+
+	e.GET("/").
+		Expect().
+		JSON().Object().Value("foo").Number().IsEqual(456)
+
+	require.NotNil(t, handler.failure)
+	assert.Equal(t, "TestExample", handler.ctx.TestName)
+	assert.Equal(t, "", handler.ctx.RequestName)
+}
+
 func TestRequest_Basic(t *testing.T) {
 	t.Run("get", func(t *testing.T) {
 		client := &mockClient{}
@@ -248,6 +333,25 @@ func TestRequest_Basic(t *testing.T) {
 		}
 	})
 
+	t.Run("round trip time matches printer", func(t *testing.T) {
+		client := &mockClient{}
+		printer := &mockPrinter{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Printers: []Printer{printer},
+		}
+
+		req := NewRequestC(config, "GET", "/path")
+		resp := req.Expect()
+
+		rtt := resp.RoundTripTime()
+		rtt.chain.assertNotFailed(t)
+
+		assert.Equal(t, printer.rtt, rtt.Raw())
+	})
+
 	t.Run("client error", func(t *testing.T) {
 		client := &mockClient{
 			err: errors.New("error"),
@@ -264,6 +368,11 @@ func TestRequest_Basic(t *testing.T) {
 		resp.chain.assertFailed(t)
 
 		assert.Nil(t, resp.Raw())
+
+		rtt := resp.RoundTripTime()
+		rtt.chain.assertFailed(t)
+
+		assert.Equal(t, time.Duration(0), rtt.Raw())
 	})
 }
 
@@ -380,6 +489,40 @@ func TestRequest_Client(t *testing.T) {
 	req.chain.assertFailed(t)
 }
 
+func TestRequest_ClientOverride(t *testing.T) {
+	client1 := &mockClient{}
+	client2 := &mockClient{}
+
+	printer := &mockPrinter{}
+
+	e := WithConfig(Config{
+		Reporter: newMockReporter(t),
+		Client:   client1,
+		Printers: []Printer{printer},
+	})
+
+	e.POST("/").
+		WithClient(client2).
+		WithText("body2").
+		Expect().
+		chain.assertNotFailed(t)
+
+	assert.Nil(t, client1.req)
+	assert.NotNil(t, client2.req)
+	assert.Equal(t, "body2", string(printer.reqBody))
+
+	client2.req = nil
+
+	e.POST("/").
+		WithText("body1").
+		Expect().
+		chain.assertNotFailed(t)
+
+	assert.NotNil(t, client1.req)
+	assert.Nil(t, client2.req)
+	assert.Equal(t, "body1", string(printer.reqBody))
+}
+
 func TestRequest_Handler(t *testing.T) {
 	t.Run("basic", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -499,6 +642,152 @@ func TestRequest_Proto(t *testing.T) {
 	assert.Equal(t, 0, req.httpReq.ProtoMinor)
 }
 
+func TestRequest_Method(t *testing.T) {
+	t.Run("override", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/path")
+
+		req.WithMethod("POST")
+		req.chain.assertNotFailed(t)
+
+		req.Expect().chain.assertNotFailed(t)
+
+		assert.Equal(t, "POST", client.req.Method)
+		assert.Equal(t, "/path", client.req.URL.String())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, method := range []string{"", "BAD METHOD", "GET\n", "(GET)"} {
+			t.Run(strconv.Quote(method), func(t *testing.T) {
+				config := Config{
+					Client:   &mockClient{},
+					Reporter: newMockReporter(t),
+				}
+
+				req := NewRequestC(config, "GET", "/path")
+
+				req.WithMethod(method)
+				req.chain.assertFailed(t)
+
+				assert.Equal(t, "GET", req.httpReq.Method)
+			})
+		}
+	})
+
+	t.Run("unusual method", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, "GET", "/path")
+
+		req.WithMethod("PROPFIND")
+		req.chain.assertNotFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, SeverityLog, handler.failure.Severity)
+		assert.Equal(t, 1, handler.failureCount)
+
+		assert.Equal(t, "PROPFIND", req.httpReq.Method)
+	})
+
+	t.Run("body with get", func(t *testing.T) {
+		for _, bodyFirst := range []bool{true, false} {
+			t.Run(fmt.Sprintf("bodyFirst=%v", bodyFirst), func(t *testing.T) {
+				handler := &mockAssertionHandler{}
+
+				config := Config{
+					Client:           &mockClient{},
+					AssertionHandler: handler,
+				}
+
+				req := NewRequestC(config, "POST", "/path")
+
+				if bodyFirst {
+					req.WithText("foo")
+					req.WithMethod("GET")
+				} else {
+					req.WithMethod("GET")
+					req.WithText("foo")
+				}
+				req.chain.assertNotFailed(t)
+
+				assert.Nil(t, handler.failure)
+
+				req.Expect().chain.assertNotFailed(t)
+
+				require.NotNil(t, handler.failure)
+				assert.Equal(t, SeverityLog, handler.failure.Severity)
+				assert.Equal(t, 1, handler.failureCount)
+
+				assert.Equal(t, "GET", req.httpReq.Method)
+			})
+		}
+	})
+
+	t.Run("body with get without override", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, "GET", "/path")
+
+		req.WithText("foo")
+		req.Expect().chain.assertNotFailed(t)
+
+		assert.Nil(t, handler.failure)
+	})
+
+	t.Run("request name", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/path")
+
+		req.WithMethod("POST")
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, []string{`Request("POST")`}, req.chain.context.Path)
+		assert.Equal(t, []string{`Request("POST")`}, req.chain.context.AliasedPath)
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, []string{`Request("POST")`, "Expect()"}, resp.chain.context.Path)
+	})
+
+	t.Run("standard method", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, "GET", "/path")
+
+		req.WithMethod("DELETE")
+		req.chain.assertNotFailed(t)
+
+		assert.Nil(t, handler.failure)
+		assert.Equal(t, 0, handler.failureCount)
+	})
+}
+
 func TestRequest_URLConcatenate(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -710,6 +999,143 @@ func TestRequest_URLOverwrite(t *testing.T) {
 	}
 }
 
+func TestRequest_AbsoluteURL(t *testing.T) {
+	cases := []struct {
+		name        string
+		path        string
+		pathargs    []interface{}
+		absoluteURL string
+		prepFunc    func(req *Request)
+		expectedURL string
+	}{
+		{
+			name:        "replaces base url and path",
+			path:        "/path",
+			absoluteURL: "https://example.com/upload",
+			expectedURL: "https://example.com/upload",
+		},
+		{
+			name:        "ignores path arguments",
+			path:        "/path/{id}",
+			pathargs:    []interface{}{123},
+			absoluteURL: "https://example.com/upload",
+			expectedURL: "https://example.com/upload",
+		},
+		{
+			name:        "preserves query",
+			path:        "/path",
+			absoluteURL: "https://example.com/upload?sig=b%2Fc&a=1",
+			expectedURL: "https://example.com/upload?sig=b%2Fc&a=1",
+		},
+		{
+			name:        "appends query",
+			path:        "/path",
+			absoluteURL: "https://example.com/upload?sig=abc",
+			prepFunc: func(req *Request) {
+				req.WithQuery("foo", "bar")
+			},
+			expectedURL: "https://example.com/upload?sig=abc&foo=bar",
+		},
+		{
+			name:        "overrides WithURL",
+			path:        "/path",
+			absoluteURL: "https://example.com/upload",
+			prepFunc: func(req *Request) {
+				req.WithURL("http://other.com")
+			},
+			expectedURL: "https://example.com/upload",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockClient{}
+			reporter := newMockReporter(t)
+
+			req := NewRequestC(
+				Config{
+					BaseURL:  "http://foobar.com",
+					Client:   client,
+					Reporter: reporter,
+				},
+				"GET",
+				tc.path,
+				tc.pathargs...)
+
+			req.WithAbsoluteURL(tc.absoluteURL)
+
+			if tc.prepFunc != nil {
+				tc.prepFunc(req)
+			}
+
+			req.Expect().chain.assertNotFailed(t)
+			req.chain.assertNotFailed(t)
+
+			assert.Equal(t, tc.expectedURL, client.req.URL.String())
+			assert.Equal(t, "", client.req.Host)
+		})
+	}
+
+	t.Run("with host", func(t *testing.T) {
+		client := &mockClient{}
+		reporter := newMockReporter(t)
+
+		req := NewRequestC(
+			Config{
+				BaseURL:  "http://foobar.com",
+				Client:   client,
+				Reporter: reporter,
+			},
+			"GET",
+			"/path")
+
+		req.WithHost("myhost.com")
+		req.WithAbsoluteURL("https://example.com/upload")
+
+		req.Expect().chain.assertNotFailed(t)
+
+		assert.Equal(t, "https://example.com/upload", client.req.URL.String())
+		assert.Equal(t, "myhost.com", client.req.Host)
+	})
+
+	t.Run("path parameters", func(t *testing.T) {
+		newReq := func() *Request {
+			return NewRequestC(
+				Config{
+					BaseURL:  "http://foobar.com",
+					Client:   &mockClient{},
+					Reporter: newMockReporter(t),
+				},
+				"GET",
+				"/path/{id}")
+		}
+
+		req := newReq()
+		req.WithPath("id", 123)
+		req.chain.assertNotFailed(t)
+		req.WithAbsoluteURL("https://example.com/upload")
+		req.chain.assertFailed(t)
+
+		req = newReq()
+		req.WithPathObject(map[string]interface{}{"id": 123})
+		req.chain.assertNotFailed(t)
+		req.WithAbsoluteURL("https://example.com/upload")
+		req.chain.assertFailed(t)
+
+		req = newReq()
+		req.WithAbsoluteURL("https://example.com/upload")
+		req.chain.assertNotFailed(t)
+		req.WithPath("id", 123)
+		req.chain.assertFailed(t)
+
+		req = newReq()
+		req.WithAbsoluteURL("https://example.com/upload")
+		req.chain.assertNotFailed(t)
+		req.WithPathObject(map[string]interface{}{"id": 123})
+		req.chain.assertFailed(t)
+	})
+}
+
 func TestRequest_URLInterpolate(t *testing.T) {
 	client := &mockClient{}
 
@@ -790,45 +1216,158 @@ func TestRequest_URLInterpolate(t *testing.T) {
 	r10.chain.assertFailed(t)
 }
 
-func TestRequest_URLQuery(t *testing.T) {
-	client := &mockClient{}
+type testPathStringer struct {
+	id int
+}
 
-	config := Config{
-		BaseURL:  "http://example.com",
-		Client:   client,
-		Reporter: newMockReporter(t),
-	}
+func (s testPathStringer) String() string {
+	return fmt.Sprintf("id-%d", s.id)
+}
 
-	checkOK := func(req *Request, url string) {
-		client.req = nil
-		req.Expect()
-		req.chain.assertNotFailed(t)
-		assert.Equal(t, url, client.req.URL.String())
-	}
+func TestRequest_URLPathEncoding(t *testing.T) {
+	tm := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
 
-	checkFailed := func(req *Request) {
-		req.chain.assertFailed(t)
+	cases := []struct {
+		name  string
+		value interface{}
+		path  string
+	}{
+		{"string", "foo", "/foo"},
+		{"int", 123, "/123"},
+		{"large int", 1000000, "/1000000"},
+		{"float", 1.5, "/1.5"},
+		{"large float", 1e21, "/1000000000000000000000"},
+		{"bool", true, "/true"},
+		{"time", tm, "/2023-01-02T03:04:05Z"},
+		{"time pointer", &tm, "/2023-01-02T03:04:05Z"},
+		{"stringer", testPathStringer{123}, "/id-123"},
 	}
 
-	t.Run("WithQuery", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "/path").
-			WithQuery("aa", "foo").WithQuery("bb", 123).WithQuery("cc", "*&@")
-		checkOK(req,
-			"http://example.com/path?aa=foo&bb=123&cc=%2A%26%40")
-	})
-
-	t.Run("WithQueryObject map[string]interface", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "/path").
-			WithQuery("aa", "foo").
-			WithQueryObject(map[string]interface{}{
-				"bb": 123,
-				"cc": "*&@",
-			})
-		checkOK(req,
-			"http://example.com/path?aa=foo&bb=123&cc=%2A%26%40")
-	})
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockClient{}
 
-	type S struct {
+			config := Config{
+				Client:   client,
+				Reporter: newMockReporter(t),
+			}
+
+			req := NewRequestC(config, "GET", "/{arg}")
+			req.WithPath("arg", tc.value)
+			req.Expect().chain.assertNotFailed(t)
+			assert.Equal(t, tc.path, client.req.URL.Path)
+
+			req = NewRequestC(config, "GET", "/{arg}")
+			req.WithPathObject(map[string]interface{}{"arg": tc.value})
+			req.Expect().chain.assertNotFailed(t)
+			assert.Equal(t, tc.path, client.req.URL.Path)
+
+			req = NewRequestC(config, "GET", "/{arg}", tc.value)
+			req.Expect().chain.assertNotFailed(t)
+			assert.Equal(t, tc.path, client.req.URL.Path)
+		})
+	}
+
+	t.Run("time layout", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/users/{id}/events/{date}")
+		req.WithPathTimeLayout("2006-01-02")
+		req.WithPathObject(map[string]interface{}{
+			"id":   1000000,
+			"date": tm,
+		})
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, "/users/1000000/events/2023-01-02", client.req.URL.Path)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		type S struct {
+			ID   int       `path:"id"`
+			Date time.Time `path:"date"`
+		}
+
+		req := NewRequestC(config, "GET", "/users/{id}/events/{date}")
+		req.WithPathTimeLayout("2006-01-02")
+		req.WithPathObject(S{ID: 1000000, Date: tm})
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, "/users/1000000/events/2023-01-02", client.req.URL.Path)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/users/{id}/events/{date}")
+		req.WithPathObject(map[string]interface{}{"id": 123})
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("unused key", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/users/{id}")
+		req.WithPathObject(map[string]interface{}{"id": 123, "date": tm})
+		req.chain.assertFailed(t)
+	})
+}
+
+func TestRequest_URLQuery(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	checkOK := func(req *Request, url string) {
+		client.req = nil
+		req.Expect()
+		req.chain.assertNotFailed(t)
+		assert.Equal(t, url, client.req.URL.String())
+	}
+
+	checkFailed := func(req *Request) {
+		req.chain.assertFailed(t)
+	}
+
+	t.Run("WithQuery", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path").
+			WithQuery("aa", "foo").WithQuery("bb", 123).WithQuery("cc", "*&@")
+		checkOK(req,
+			"http://example.com/path?aa=foo&bb=123&cc=%2A%26%40")
+	})
+
+	t.Run("WithQueryObject map[string]interface", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path").
+			WithQuery("aa", "foo").
+			WithQueryObject(map[string]interface{}{
+				"bb": 123,
+				"cc": "*&@",
+			})
+		checkOK(req,
+			"http://example.com/path?aa=foo&bb=123&cc=%2A%26%40")
+	})
+
+	type S struct {
 		Bb int    `url:"bb"`
 		Cc string `url:"cc"`
 		Dd string `url:"-"`
@@ -895,6 +1434,219 @@ func TestRequest_URLQuery(t *testing.T) {
 			WithQueryObject(queryObj)
 		checkFailed(req)
 	})
+
+	t.Run("WithQueryObject invalid nested struct", func(t *testing.T) {
+		type innerSt struct {
+			Ok  string           `url:"ok"`
+			Bad mockQueryEncoder `url:"bad"`
+		}
+		type outerSt struct {
+			Inner *innerSt `url:"inner"`
+		}
+
+		handler := &mockAssertionHandler{}
+
+		req := NewRequestC(Config{
+			Client:           client,
+			AssertionHandler: handler,
+		}, "GET", "/path").
+			WithQueryObject(&outerSt{
+				Inner: &innerSt{
+					Ok:  "ok",
+					Bad: mockQueryEncoder("err"),
+				},
+			})
+		req.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("failed to encode field Inner.Bad"+
+				" of type httpexpect.mockQueryEncoder"))
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("encoding error"))
+	})
+
+	t.Run("WithQueryObject multiple encoders", func(t *testing.T) {
+		type St struct {
+			A mockQueryEncoder `url:"a"`
+			B mockQueryEncoder `url:"b"`
+		}
+
+		handler := &mockAssertionHandler{}
+
+		req := NewRequestC(Config{
+			Client:           client,
+			AssertionHandler: handler,
+		}, "GET", "/path").
+			WithQueryObject(St{
+				A: mockQueryEncoder("ok"),
+				B: mockQueryEncoder("err"),
+			})
+		req.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("failed to encode field B"+
+				" of type httpexpect.mockQueryEncoder"))
+		for _, err := range handler.failure.Errors {
+			assert.NotContains(t, err.Error(), "field A")
+		}
+	})
+
+	t.Run("WithQueryObject encoder is called once", func(t *testing.T) {
+		type St struct {
+			A *countingQueryEncoder `url:"a"`
+		}
+
+		handler := &mockAssertionHandler{}
+
+		enc := &countingQueryEncoder{}
+
+		req := NewRequestC(Config{
+			Client:           client,
+			AssertionHandler: handler,
+		}, "GET", "/path").
+			WithQueryObject(St{A: enc})
+		req.chain.assertFailed(t)
+
+		assert.Equal(t, 1, enc.calls)
+
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("failed to encode field A"+
+				" of type *httpexpect.countingQueryEncoder"))
+	})
+
+	t.Run("WithQueryObject unexported embedded struct", func(t *testing.T) {
+		type embeddedSt struct {
+			Bad   mockQueryEncoder `url:"bad"`
+			inner mockQueryEncoder
+		}
+		type St struct {
+			embeddedSt
+			hidden mockQueryEncoder
+		}
+
+		handler := &mockAssertionHandler{}
+
+		assert.NotPanics(t, func() {
+			NewRequestC(Config{
+				Client:           client,
+				AssertionHandler: handler,
+			}, "GET", "/path").
+				WithQueryObject(St{
+					embeddedSt: embeddedSt{
+						Bad:   mockQueryEncoder("err"),
+						inner: mockQueryEncoder("err"),
+					},
+					hidden: mockQueryEncoder("err"),
+				})
+		})
+
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("failed to encode field embeddedSt.Bad"+
+				" of type httpexpect.mockQueryEncoder"))
+	})
+
+	t.Run("WithQueryObject nil pointer", func(t *testing.T) {
+		type St struct {
+			A int `url:"a"`
+		}
+		var queryObj *St
+		req := NewRequestC(config, "GET", "/path").
+			WithQuery("foo", "bar").
+			WithQueryObject(queryObj)
+		checkOK(req,
+			"http://example.com/path?foo=bar")
+	})
+
+	t.Run("WithQueryObject nil map", func(t *testing.T) {
+		var queryObj map[string]interface{}
+		req := NewRequestC(config, "GET", "/path").
+			WithQuery("foo", "bar").
+			WithQueryObject(queryObj)
+		checkOK(req,
+			"http://example.com/path?foo=bar")
+	})
+}
+
+type countingQueryEncoder struct {
+	calls int
+}
+
+func (c *countingQueryEncoder) EncodeValues(key string, v *url.Values) error {
+	c.calls++
+	return errors.New("encoding error")
+}
+
+func TestRequest_URLRawQuery(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	check := func(req *Request, rawQuery string) {
+		client.req = nil
+		req.Expect()
+		req.chain.assertNotFailed(t)
+		assert.Equal(t, rawQuery, client.req.URL.RawQuery)
+	}
+
+	t.Run("verbatim", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path").
+			WithRawQuery("b=2&a=1&a=0&sig=AbC%2f+x&flag")
+		check(req, "b=2&a=1&a=0&sig=AbC%2f+x&flag")
+	})
+
+	t.Run("unescaped", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path").
+			WithRawQuery("a=[1,2]&b=*")
+		check(req, "a=[1,2]&b=*")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path?a=1").
+			WithRawQuery("")
+		check(req, "")
+	})
+
+	t.Run("replaces url query", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path").
+			WithURL("http://example.org/?a=1").
+			WithRawQuery("b=2")
+		check(req, "b=2")
+	})
+
+	t.Run("replaces absolute url query", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path").
+			WithAbsoluteURL("http://example.org/other?a=1").
+			WithRawQuery("b=2")
+		check(req, "b=2")
+	})
+
+	t.Run("last call wins", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path").
+			WithRawQuery("a=1").
+			WithRawQuery("b=2")
+		check(req, "b=2")
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path").
+			WithRawQuery("b=2&a=1").
+			Clone()
+		check(req, "b=2&a=1")
+
+		req = NewRequestC(config, "GET", "/path").
+			WithRawQuery("a=1").
+			Clone()
+		req.WithQuery("b", "2")
+		req.chain.assertFailed(t)
+	})
 }
 
 func TestRequest_Headers(t *testing.T) {
@@ -929,75 +1681,370 @@ func TestRequest_Headers(t *testing.T) {
 	assert.Equal(t, "url", client.req.URL.String())
 	assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
 
-	assert.Same(t, &client.resp, resp.Raw())
+	assert.Equal(t, client.resp.Header, resp.Raw().Header)
 }
 
-func TestRequest_Cookies(t *testing.T) {
+func TestRequest_Trailers(t *testing.T) {
 	client := &mockClient{}
 
+	reporter := newMockReporter(t)
+
 	config := Config{
 		Client:   client,
-		Reporter: newMockReporter(t),
+		Reporter: reporter,
 	}
 
-	req := NewRequestC(config, "GET", "url")
+	req := NewRequestC(config, "POST", "url").
+		WithText("body").
+		WithTrailer("First-Trailer", "foo").
+		WithTrailer("second-trailer", "bar").
+		WithTrailer("second-trailer", "baz")
 
-	req.WithCookie("foo", "1")
-	req.WithCookie("bar", "2 ")
+	req.Expect().chain.assertNotFailed(t)
 
-	req.WithCookies(map[string]string{
-		"baz": " 3",
+	assert.Equal(t, http.Header{
+		"First-Trailer":  {"foo"},
+		"Second-Trailer": {"bar", "baz"},
+	}, client.req.Trailer)
+
+	assert.Equal(t, int64(-1), client.req.ContentLength)
+}
+
+func TestRequest_HeaderFn(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		client := &mockClient{}
+
+		req := NewRequestC(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}, "GET", "url")
+
+		req.WithHeader("first-header", "foo")
+		req.WithHeaderFn("second-header", func() string {
+			return "bar"
+		})
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, http.Header{
+			"First-Header":  {"foo"},
+			"Second-Header": {"bar"},
+		}, client.req.Header)
 	})
 
-	expectedHeaders := map[string][]string{
-		"Cookie": {`foo=1; bar="2 "; baz=" 3"`},
-	}
+	t.Run("lazy", func(t *testing.T) {
+		client := &mockClient{}
 
-	resp := req.Expect()
-	resp.chain.assertNotFailed(t)
+		req := NewRequestC(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}, "GET", "url")
 
-	assert.Equal(t, "GET", client.req.Method)
-	assert.Equal(t, "url", client.req.URL.String())
-	assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
+		value := "before"
 
-	assert.Same(t, &client.resp, resp.Raw())
-}
+		req.WithHeaderFn("foo", func() string {
+			return value
+		})
 
-func TestRequest_BasicAuth(t *testing.T) {
-	client := &mockClient{}
+		value = "after"
 
-	config := Config{
-		Client:   client,
-		Reporter: newMockReporter(t),
-	}
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
 
-	req := NewRequestC(config, "GET", "url")
+		assert.Equal(t, "after", client.req.Header.Get("Foo"))
+	})
 
-	req.WithBasicAuth("Aladdin", "open sesame")
-	req.chain.assertNotFailed(t)
+	t.Run("override and order", func(t *testing.T) {
+		client := &mockClient{}
 
-	assert.Equal(t, "Basic QWxhZGRpbjpvcGVuIHNlc2FtZQ==",
-		req.httpReq.Header.Get("Authorization"))
-}
+		req := NewRequestC(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}, "GET", "url")
 
-func TestRequest_Host(t *testing.T) {
-	cases := []struct {
-		name         string
-		method       string
-		path         string
-		expectedHost string
-		setupFunc    func(req *Request)
-	}{
-		{
-			name:         "request with host and without host header",
-			method:       "GET",
-			path:         "url",
-			expectedHost: "example.com",
-			setupFunc: func(req *Request) {
-				req.WithHost("example.com")
-			},
-		},
-		{
+		req.WithHeader("foo", "static")
+		req.WithHeaderFn("foo", func() string {
+			return "dynamic1"
+		})
+		req.WithHeaderFn("FOO", func() string {
+			return "dynamic2"
+		})
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, []string{"dynamic1", "dynamic2"},
+			client.req.Header.Values("Foo"))
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		var (
+			counter int
+			values  []string
+		)
+
+		client := &mockClient{
+			resp: http.Response{
+				StatusCode: http.StatusInternalServerError,
+			},
+			cb: func(req *http.Request) {
+				values = append(values, req.Header.Values("X-Attempt")...)
+			},
+		}
+
+		req := NewRequestC(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}, "GET", "url")
+
+		req.WithHeaderFn("X-Attempt", func() string {
+			counter++
+			return strconv.Itoa(counter)
+		})
+		req.WithRetryPolicy(RetryAllErrors)
+		req.WithMaxRetries(2)
+		req.WithRetryDelay(0, 0)
+		req.sleepFn = func(time.Duration) <-chan time.Time {
+			return time.After(0)
+		}
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, 3, counter)
+		assert.Equal(t, []string{"1", "2", "3"}, values)
+	})
+}
+
+func TestRequest_Cookies(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	req := NewRequestC(config, "GET", "url")
+
+	req.WithCookie("foo", "1")
+	req.WithCookie("bar", "2 ")
+
+	req.WithCookies(map[string]string{
+		"baz": " 3",
+	})
+
+	expectedHeaders := map[string][]string{
+		"Cookie": {`foo=1; bar="2 "; baz=" 3"`},
+	}
+
+	resp := req.Expect()
+	resp.chain.assertNotFailed(t)
+
+	assert.Equal(t, "GET", client.req.Method)
+	assert.Equal(t, "url", client.req.URL.String())
+	assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
+
+	assert.Equal(t, client.resp.Header, resp.Raw().Header)
+}
+
+func TestRequest_ETagFrom(t *testing.T) {
+	newResp := func(header http.Header) *Response {
+		return NewResponse(newMockReporter(t), &http.Response{
+			Header: header,
+			Body:   http.NoBody,
+		})
+	}
+
+	t.Run("if-match", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		resp := newResp(http.Header{"Etag": {`"v1"`}})
+
+		req := NewRequestC(config, "PUT", "url").
+			WithETagFrom(resp)
+		req.chain.assert(t, success)
+
+		req.Expect().chain.assert(t, success)
+
+		assert.Equal(t, http.Header{"If-Match": {`"v1"`}}, client.req.Header)
+	})
+
+	t.Run("if-none-match", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		resp := newResp(http.Header{"Etag": {`W/"v2"`}})
+
+		req := NewRequestC(config, "GET", "url").
+			WithIfNoneMatchFrom(resp)
+		req.chain.assert(t, success)
+
+		req.Expect().chain.assert(t, success)
+
+		assert.Equal(t, http.Header{"If-None-Match": {`W/"v2"`}}, client.req.Header)
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "PUT", "url").
+			WithHeader("If-Match", `"v0"`).
+			WithETagFrom(newResp(http.Header{"Etag": {`"v1"`}}))
+		req.chain.assert(t, success)
+
+		req.Expect().chain.assert(t, success)
+
+		assert.Equal(t, http.Header{"If-Match": {`"v1"`}}, client.req.Header)
+	})
+
+	t.Run("no etag", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, "PUT", "url").
+			WithETagFrom(newResp(http.Header{"Foo": {"bar"}}))
+		req.chain.assert(t, failure)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertContainsKey, handler.failure.Type)
+		assert.Equal(t,
+			"expected: response has ETag header to copy into If-Match",
+			handler.failure.Errors[0].Error())
+	})
+
+	t.Run("no http response", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "PUT", "url").
+			WithIfNoneMatchFrom(&Response{})
+		req.chain.assert(t, failure)
+	})
+}
+
+func TestRequest_BasicAuth(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	req := NewRequestC(config, "GET", "url")
+
+	req.WithBasicAuth("Aladdin", "open sesame")
+	req.chain.assertNotFailed(t)
+
+	assert.Equal(t, "Basic QWxhZGRpbjpvcGVuIHNlc2FtZQ==",
+		req.httpReq.Header.Get("Authorization"))
+}
+
+func TestRequest_Auth(t *testing.T) {
+	config := Config{
+		Client:   &mockClient{},
+		Reporter: newMockReporter(t),
+	}
+
+	t.Run("basic auth overwrite", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithBasicAuth("foo", "bar")
+		req.WithBasicAuth("Aladdin", "open sesame")
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, []string{"Basic QWxhZGRpbjpvcGVuIHNlc2FtZQ=="},
+			req.httpReq.Header["Authorization"])
+	})
+
+	t.Run("without basic auth", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithBasicAuth("Aladdin", "open sesame")
+		req.WithoutBasicAuth()
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, "", req.httpReq.Header.Get("Authorization"))
+	})
+
+	t.Run("without basic auth when not set", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithoutBasicAuth()
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, "", req.httpReq.Header.Get("Authorization"))
+	})
+
+	t.Run("without basic auth keeps other schemes", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithBearerToken("token")
+		req.WithoutBasicAuth()
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, "Bearer token", req.httpReq.Header.Get("Authorization"))
+	})
+
+	t.Run("bearer token", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithBearerToken("token")
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, "Bearer token", req.httpReq.Header.Get("Authorization"))
+	})
+
+	t.Run("bearer token overwrites basic auth", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithBasicAuth("Aladdin", "open sesame")
+		req.WithBearerToken("token")
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, []string{"Bearer token"},
+			req.httpReq.Header["Authorization"])
+	})
+}
+
+func TestRequest_Host(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		path         string
+		expectedHost string
+		setupFunc    func(req *Request)
+	}{
+		{
+			name:         "request with host and without host header",
+			method:       "GET",
+			path:         "url",
+			expectedHost: "example.com",
+			setupFunc: func(req *Request) {
+				req.WithHost("example.com")
+			},
+		},
+		{
 			name:         "request with header before with host",
 			method:       "GET",
 			path:         "url",
@@ -1044,7 +2091,7 @@ func TestRequest_Host(t *testing.T) {
 			assert.Equal(t, tc.expectedHost, client.req.Host)
 			assert.Equal(t, tc.path, client.req.URL.String())
 
-			assert.Same(t, &client.resp, resp.Raw())
+			assert.Equal(t, client.resp.Header, resp.Raw().Header)
 		})
 	}
 }
@@ -1073,7 +2120,7 @@ func TestRequest_BodyChunked(t *testing.T) {
 		assert.Equal(t, make(http.Header), client.req.Header)
 		assert.Equal(t, "body", resp.Body().Raw())
 
-		assert.Same(t, &client.resp, resp.Raw())
+		assert.Equal(t, client.resp.Header, resp.Raw().Header)
 	})
 
 	t.Run("nil", func(t *testing.T) {
@@ -1112,7 +2159,7 @@ func TestRequest_BodyChunked(t *testing.T) {
 	})
 }
 
-func TestRequest_BodyBytes(t *testing.T) {
+func TestRequest_BodyReader(t *testing.T) {
 	client := &mockClient{}
 
 	config := Config{
@@ -1120,29 +2167,36 @@ func TestRequest_BodyBytes(t *testing.T) {
 		Reporter: newMockReporter(t),
 	}
 
-	t.Run("byte slice", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "/path")
+	t.Run("known length", func(t *testing.T) {
+		req := NewRequestC(config, "PUT", "url")
 
-		req.WithBytes([]byte("body"))
+		req.WithReader(strings.NewReader("body"), 4)
 
 		resp := req.Expect()
 		resp.chain.assertNotFailed(t)
 
 		assert.NotNil(t, client.req.Body)
-		assert.Equal(t, int64(len("body")), client.req.ContentLength)
-
-		assert.Equal(t, "GET", client.req.Method)
-		assert.Equal(t, "/path", client.req.URL.String())
-		assert.Equal(t, make(http.Header), client.req.Header)
+		assert.Equal(t, int64(4), client.req.ContentLength)
 		assert.Equal(t, "body", resp.Body().Raw())
+	})
 
-		assert.Same(t, &client.resp, resp.Raw())
+	t.Run("unknown length", func(t *testing.T) {
+		req := NewRequestC(config, "PUT", "url")
+
+		req.WithReader(strings.NewReader("body"), -1)
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.NotNil(t, client.req.Body)
+		assert.Equal(t, int64(-1), client.req.ContentLength)
+		assert.Equal(t, "body", resp.Body().Raw())
 	})
 
-	t.Run("nil", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "url")
+	t.Run("zero length", func(t *testing.T) {
+		req := NewRequestC(config, "PUT", "url")
 
-		req.WithBytes(nil)
+		req.WithReader(strings.NewReader(""), 0)
 
 		resp := req.Expect()
 		resp.chain.assertNotFailed(t)
@@ -1150,58 +2204,192 @@ func TestRequest_BodyBytes(t *testing.T) {
 		assert.Equal(t, http.NoBody, client.req.Body)
 		assert.Equal(t, int64(0), client.req.ContentLength)
 	})
-}
 
-func TestRequest_BodyText(t *testing.T) {
-	client := &mockClient{}
-
-	config := Config{
-		Client:   client,
-		Reporter: newMockReporter(t),
-	}
+	t.Run("large length", func(t *testing.T) {
+		req := NewRequestC(config, "PUT", "url")
 
-	expectedHeaders := map[string][]string{
-		"Content-Type": {"text/plain; charset=utf-8"},
-		"Some-Header":  {"foo"},
-	}
+		const length = int64(1)<<32 + 1
 
-	req := NewRequestC(config, "GET", "url")
+		req.WithReader(strings.NewReader("body"), length)
+		req.chain.assertNotFailed(t)
 
-	req.WithHeaders(map[string]string{
-		"Some-Header": "foo",
+		assert.Equal(t, length, req.httpReq.ContentLength)
 	})
 
-	req.WithText("some text")
+	t.Run("not buffered", func(t *testing.T) {
+		req := NewRequestC(config, "PUT", "url")
 
-	resp := req.Expect()
-	resp.chain.assertNotFailed(t)
+		req.WithReader(strings.NewReader("body"), 4)
+		req.chain.assertNotFailed(t)
 
-	assert.Equal(t, "GET", client.req.Method)
-	assert.Equal(t, "url", client.req.URL.String())
-	assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
-	assert.Equal(t, "some text", resp.Body().Raw())
+		_, ok := req.httpReq.Body.(*bodyStream)
+		assert.True(t, ok)
 
-	assert.Same(t, &client.resp, resp.Raw())
-}
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
 
-func TestRequest_BodyForm(t *testing.T) {
-	client := &mockClient{}
+		_, ok = client.req.Body.(*bodyStream)
+		assert.True(t, ok)
+	})
 
-	config := Config{
-		Client:   client,
-		Reporter: newMockReporter(t),
-	}
+	t.Run("server", func(t *testing.T) {
+		var (
+			gotBody   []byte
+			gotLength int64
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				gotBody, _ = ioutil.ReadAll(r.Body)
+				gotLength = r.ContentLength
+			}))
+		defer server.Close()
+
+		req := NewRequestC(Config{
+			BaseURL:  server.URL,
+			Client:   server.Client(),
+			Reporter: newMockReporter(t),
+		}, "PUT", "/")
 
-	t.Run("form", func(t *testing.T) {
-		expectedHeaders := map[string][]string{
-			"Content-Type": {"application/x-www-form-urlencoded"},
-			"Some-Header":  {"foo"},
-		}
+		req.WithReader(strings.NewReader("hello"), 5)
 
-		req := NewRequestC(config, "GET", "url")
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
 
-		req.WithHeaders(map[string]string{
-			"Some-Header": "foo",
+		assert.Equal(t, "hello", string(gotBody))
+		assert.Equal(t, int64(5), gotLength)
+	})
+
+	t.Run("proto 1.0", func(t *testing.T) {
+		req := NewRequestC(config, "PUT", "url")
+
+		req.WithProto("HTTP/1.0")
+		req.WithReader(strings.NewReader("body"), 4)
+		req.chain.assertNotFailed(t)
+
+		req = NewRequestC(config, "PUT", "url")
+
+		req.WithProto("HTTP/1.0")
+		req.WithReader(strings.NewReader("body"), -1)
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("with retries", func(t *testing.T) {
+		req := NewRequestC(config, "PUT", "url")
+
+		req.WithReader(strings.NewReader("body"), 4)
+		req.WithMaxRetries(1)
+		req.chain.assertNotFailed(t)
+
+		req.Expect()
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("with redirects", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Client:   &http.Client{Transport: newMockTransportRedirect()},
+			Reporter: newMockReporter(t),
+		}, "PUT", "url")
+
+		req.WithReader(strings.NewReader("body"), 4)
+		req.WithRedirectPolicy(FollowAllRedirects)
+		req.chain.assertNotFailed(t)
+
+		req.Expect()
+		req.chain.assertFailed(t)
+	})
+}
+
+func TestRequest_BodyBytes(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	t.Run("byte slice", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path")
+
+		req.WithBytes([]byte("body"))
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.NotNil(t, client.req.Body)
+		assert.Equal(t, int64(len("body")), client.req.ContentLength)
+
+		assert.Equal(t, "GET", client.req.Method)
+		assert.Equal(t, "/path", client.req.URL.String())
+		assert.Equal(t, make(http.Header), client.req.Header)
+		assert.Equal(t, "body", resp.Body().Raw())
+
+		assert.Equal(t, client.resp.Header, resp.Raw().Header)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithBytes(nil)
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, http.NoBody, client.req.Body)
+		assert.Equal(t, int64(0), client.req.ContentLength)
+	})
+}
+
+func TestRequest_BodyText(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	expectedHeaders := map[string][]string{
+		"Content-Type": {"text/plain; charset=utf-8"},
+		"Some-Header":  {"foo"},
+	}
+
+	req := NewRequestC(config, "GET", "url")
+
+	req.WithHeaders(map[string]string{
+		"Some-Header": "foo",
+	})
+
+	req.WithText("some text")
+
+	resp := req.Expect()
+	resp.chain.assertNotFailed(t)
+
+	assert.Equal(t, "GET", client.req.Method)
+	assert.Equal(t, "url", client.req.URL.String())
+	assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
+	assert.Equal(t, "some text", resp.Body().Raw())
+
+	assert.Equal(t, client.resp.Header, resp.Raw().Header)
+}
+
+func TestRequest_BodyForm(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	t.Run("form", func(t *testing.T) {
+		expectedHeaders := map[string][]string{
+			"Content-Type": {"application/x-www-form-urlencoded"},
+			"Some-Header":  {"foo"},
+		}
+
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithHeaders(map[string]string{
+			"Some-Header": "foo",
 		})
 
 		req.WithForm(map[string]interface{}{
@@ -1217,7 +2405,7 @@ func TestRequest_BodyForm(t *testing.T) {
 		assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
 		assert.Equal(t, `a=1&b=2`, resp.Body().Raw())
 
-		assert.Same(t, &client.resp, resp.Raw())
+		assert.Equal(t, client.resp.Header, resp.Raw().Header)
 	})
 
 	t.Run("form field", func(t *testing.T) {
@@ -1243,7 +2431,7 @@ func TestRequest_BodyForm(t *testing.T) {
 		assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
 		assert.Equal(t, `a=1&b=2`, resp.Body().Raw())
 
-		assert.Same(t, &client.resp, resp.Raw())
+		assert.Equal(t, client.resp.Header, resp.Raw().Header)
 	})
 
 	t.Run("form struct", func(t *testing.T) {
@@ -1269,7 +2457,7 @@ func TestRequest_BodyForm(t *testing.T) {
 		assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
 		assert.Equal(t, `a=1&b=2`, resp.Body().Raw())
 
-		assert.Same(t, &client.resp, resp.Raw())
+		assert.Equal(t, client.resp.Header, resp.Raw().Header)
 	})
 
 	t.Run("form combined", func(t *testing.T) {
@@ -1295,7 +2483,30 @@ func TestRequest_BodyForm(t *testing.T) {
 		assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
 		assert.Equal(t, `a=1&b=2&c=3`, resp.Body().Raw())
 
-		assert.Same(t, &client.resp, resp.Raw())
+		assert.Equal(t, client.resp.Header, resp.Raw().Header)
+	})
+
+	t.Run("form field repeated", func(t *testing.T) {
+		expectedHeaders := map[string][]string{
+			"Content-Type": {"application/x-www-form-urlencoded"},
+		}
+
+		req := NewRequestC(config, "GET", "url")
+
+		type S struct {
+			Tag  string `form:"tag"`
+			Name string `form:"name"`
+		}
+
+		req.WithForm(S{Tag: "a", Name: "x y"})
+		req.WithFormField("tag", "b", "c&d")
+		req.WithFormField("tag", 1)
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
+		assert.Equal(t, `name=x+y&tag=a&tag=b&tag=c%26d&tag=1`, resp.Body().Raw())
 	})
 
 	t.Run("marshal error", func(t *testing.T) {
@@ -1362,6 +2573,26 @@ func TestRequest_BodyMultipart(t *testing.T) {
 		assert.Nil(t, eof)
 	})
 
+	t.Run("multipart repeated field", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
+
+		req.WithMultipart()
+		req.WithFormField("a", 1, 2)
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		_, params, err := mime.ParseMediaType(client.req.Header.Get("Content-Type"))
+		assert.NoError(t, err)
+
+		reader := multipart.NewReader(strings.NewReader(resp.Body().Raw()),
+			params["boundary"])
+
+		form, err := reader.ReadForm(1 << 20)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, form.Value["a"])
+	})
+
 	t.Run("multipart file", func(t *testing.T) {
 		req := NewRequestC(config, "POST", "url")
 
@@ -1419,848 +2650,2857 @@ func TestRequest_BodyMultipart(t *testing.T) {
 		eof, _ := reader.NextPart()
 		assert.Nil(t, eof)
 	})
-}
 
-func TestRequest_BodyJSON(t *testing.T) {
-	client := &mockClient{}
+	t.Run("multipart file content type", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
 
-	config := Config{
-		Client:   client,
-		Reporter: newMockReporter(t),
-	}
+		req.WithMultipart()
+		req.WithFile("a", "a.json", strings.NewReader(`{"a":1}`))
+		req.WithFileBytes("b", "b.png", []byte("2"))
 
-	t.Run("json", func(t *testing.T) {
-		expectedHeaders := map[string][]string{
-			"Content-Type": {"application/json; charset=utf-8"},
-			"Some-Header":  {"foo"},
-		}
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
 
-		req := NewRequestC(config, "GET", "url")
+		_, params, err := mime.ParseMediaType(client.req.Header.Get("Content-Type"))
+		assert.NoError(t, err)
 
-		req.WithHeaders(map[string]string{
-			"Some-Header": "foo",
-		})
+		reader := multipart.NewReader(strings.NewReader(resp.Body().Raw()),
+			params["boundary"])
 
-		req.WithJSON(map[string]interface{}{"key": "value"})
+		// Should not guess Content-Type from extension
+		part1, _ := reader.NextPart()
+		assert.Equal(t, "a", part1.FormName())
+		assert.Equal(t, "application/octet-stream", part1.Header.Get("Content-Type"))
+
+		part2, _ := reader.NextPart()
+		assert.Equal(t, "b", part2.FormName())
+		assert.Equal(t, "application/octet-stream", part2.Header.Get("Content-Type"))
+	})
+
+	t.Run("multipart file bytes content type", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
+
+		req.WithMultipart()
+		req.WithFileBytes("a", "a.bin", []byte("1"), "image/png")
 
 		resp := req.Expect()
 		resp.chain.assertNotFailed(t)
 
-		assert.Equal(t, "GET", client.req.Method)
-		assert.Equal(t, "url", client.req.URL.String())
-		assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
-		assert.Equal(t, `{"key":"value"}`, resp.Body().Raw())
+		_, params, err := mime.ParseMediaType(client.req.Header.Get("Content-Type"))
+		assert.NoError(t, err)
+
+		reader := multipart.NewReader(strings.NewReader(resp.Body().Raw()),
+			params["boundary"])
 
-		assert.Same(t, &client.resp, resp.Raw())
+		part, _ := reader.NextPart()
+		assert.Equal(t, "a", part.FormName())
+		assert.Equal(t, "image/png", part.Header.Get("Content-Type"))
 	})
 
-	t.Run("marshal error", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "url")
+	t.Run("multipart file fs", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
 
-		req.WithJSON(func() {})
+		fsys := fstest.MapFS{
+			"data/a.json": {Data: []byte(`{"a":1}`)},
+			"data/b.bin":  {Data: []byte("2")},
+		}
+
+		req.WithMultipart()
+		req.WithFileFS(fsys, "a", "data/a.json")
+		req.WithFileFS(fsys, "b", "data/b.bin")
 
 		resp := req.Expect()
-		resp.chain.assertFailed(t)
+		resp.chain.assertNotFailed(t)
 
-		assert.Nil(t, resp.Raw())
-	})
-}
+		mediatype, params, err := mime.ParseMediaType(client.req.Header.Get("Content-Type"))
 
-func TestRequest_ContentLength(t *testing.T) {
-	client := &mockClient{}
-	config := Config{
-		Client:   client,
-		Reporter: newMockReporter(t),
-	}
+		assert.NoError(t, err)
+		assert.Equal(t, "multipart/form-data", mediatype)
 
-	t.Run("chunked", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "url")
-		req.WithChunked(bytes.NewReader([]byte("12345")))
-		req.Expect().chain.assertNotFailed(t)
-		assert.Equal(t, int64(-1), client.req.ContentLength)
-	})
+		reader := multipart.NewReader(strings.NewReader(resp.Body().Raw()),
+			params["boundary"])
 
-	t.Run("bytes", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "url")
-		req.WithBytes([]byte("12345"))
-		req.Expect().chain.assertNotFailed(t)
-		assert.Equal(t, int64(5), client.req.ContentLength)
-	})
+		part1, _ := reader.NextPart()
+		assert.Equal(t, "a", part1.FormName())
+		assert.Equal(t, "a.json", filepath.Base(part1.FileName()))
+		assert.Equal(t, "application/json", part1.Header.Get("Content-Type"))
+		b1, _ := ioutil.ReadAll(part1)
+		assert.Equal(t, `{"a":1}`, string(b1))
 
-	t.Run("text", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "url")
-		req.WithText("12345")
-		req.Expect().chain.assertNotFailed(t)
-		assert.Equal(t, int64(5), client.req.ContentLength)
-	})
+		part2, _ := reader.NextPart()
+		assert.Equal(t, "b", part2.FormName())
+		assert.Equal(t, "b.bin", filepath.Base(part2.FileName()))
+		assert.Equal(t, "application/octet-stream", part2.Header.Get("Content-Type"))
+		b2, _ := ioutil.ReadAll(part2)
+		assert.Equal(t, "2", string(b2))
 
-	t.Run("json", func(t *testing.T) {
-		j, _ := json.Marshal(map[string]string{"a": "b"})
-		req := NewRequestC(config, "GET", "url")
-		req.WithJSON(map[string]string{"a": "b"})
-		req.Expect().chain.assertNotFailed(t)
-		assert.Equal(t, int64(len(j)), client.req.ContentLength)
+		eof, _ := reader.NextPart()
+		assert.Nil(t, eof)
 	})
 
-	t.Run("form", func(t *testing.T) {
-		f := `a=b`
-		req := NewRequestC(config, "GET", "url")
-		req.WithForm(map[string]string{"a": "b"})
-		req.Expect().chain.assertNotFailed(t)
-		assert.Equal(t, int64(len(f)), client.req.ContentLength)
-	})
+	t.Run("multipart file stream", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
 
-	t.Run("form field", func(t *testing.T) {
-		f := `a=b`
-		req := NewRequestC(config, "GET", "url")
-		req.WithFormField("a", "b")
-		req.Expect().chain.assertNotFailed(t)
-		assert.Equal(t, int64(len(f)), client.req.ContentLength)
-	})
-
-	t.Run("multipart", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "url")
 		req.WithMultipart()
-		req.WithFileBytes("a", "b", []byte("12345"))
-		req.Expect().chain.assertNotFailed(t)
-		assert.True(t, client.req.ContentLength > 0)
-	})
-}
+		req.WithFileStream("a", "filename1", strings.NewReader("1"))
+		req.WithFormField("b", "2")
+		req.WithFileBytes("c", "filename3", []byte("3"))
+		req.WithFileStream("d", "filename4", strings.NewReader("4"))
 
-func TestRequest_ContentType(t *testing.T) {
-	client := &mockClient{}
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
 
-	config := Config{
-		Client:   client,
-		Reporter: newMockReporter(t),
-	}
+		assert.Equal(t, int64(-1), client.req.ContentLength)
 
-	t.Run("WithText sets Content-Type header", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "url")
-		req.WithText("hello")
-		req.WithHeader("Content-Type", "foo")
-		req.Expect().chain.assertNotFailed(t)
-		assert.Equal(t, http.Header{"Content-Type": {"foo"}}, client.req.Header)
-	})
+		mediatype, params, err := mime.ParseMediaType(client.req.Header.Get("Content-Type"))
 
-	t.Run("WithHeader sets Content-Type header", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "url")
-		req.WithHeader("Content-Type", "foo")
-		req.WithText("hello")
-		req.Expect().chain.assertNotFailed(t)
-		assert.Equal(t, http.Header{"Content-Type": {"foo"}}, client.req.Header)
-	})
+		assert.NoError(t, err)
+		assert.Equal(t, "multipart/form-data", mediatype)
+		assert.True(t, params["boundary"] != "")
 
-	t.Run("WithJSON overrides Content-Type header", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "url")
-		req.WithJSON(map[string]interface{}{"a": "b"})
-		req.WithHeader("Content-Type", "foo")
-		req.WithHeader("Content-Type", "bar")
-		req.Expect().chain.assertNotFailed(t)
-		assert.Equal(t, http.Header{"Content-Type": {"foo", "bar"}}, client.req.Header)
+		reader := multipart.NewReader(strings.NewReader(resp.Body().Raw()),
+			params["boundary"])
+
+		part1, _ := reader.NextPart()
+		assert.Equal(t, "b", part1.FormName())
+		assert.Equal(t, "", part1.FileName())
+		b1, _ := ioutil.ReadAll(part1)
+		assert.Equal(t, "2", string(b1))
+
+		part2, _ := reader.NextPart()
+		assert.Equal(t, "c", part2.FormName())
+		assert.Equal(t, "filename3", part2.FileName())
+		b2, _ := ioutil.ReadAll(part2)
+		assert.Equal(t, "3", string(b2))
+
+		part3, _ := reader.NextPart()
+		assert.Equal(t, "a", part3.FormName())
+		assert.Equal(t, "filename1", part3.FileName())
+		b3, _ := ioutil.ReadAll(part3)
+		assert.Equal(t, "1", string(b3))
+
+		part4, _ := reader.NextPart()
+		assert.Equal(t, "d", part4.FormName())
+		assert.Equal(t, "filename4", part4.FileName())
+		b4, _ := ioutil.ReadAll(part4)
+		assert.Equal(t, "4", string(b4))
+
+		eof, _ := reader.NextPart()
+		assert.Nil(t, eof)
 	})
 
-	t.Run("WithForm overrides Content-Type header", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "url")
-		req.WithForm(map[string]interface{}{"a": "b"})
-		req.WithHeader("Content-Type", "foo")
-		req.WithHeader("Content-Type", "bar")
-		req.Expect().chain.assertNotFailed(t)
-		assert.Equal(t, http.Header{"Content-Type": {"foo", "bar"}}, client.req.Header)
+	t.Run("multipart file stream without multipart", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
+
+		req.WithFileStream("a", "filename1", strings.NewReader("1"))
+		req.chain.assertFailed(t)
 	})
 
-	t.Run("WithMultipart overrides Content-Type header", func(t *testing.T) {
-		req := NewRequestC(config, "GET", "url")
+	t.Run("multipart file stream with nil reader", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
+
 		req.WithMultipart()
-		req.WithForm(map[string]interface{}{"a": "b"})
-		req.WithHeader("Content-Type", "foo")
-		req.WithHeader("Content-Type", "bar")
-		req.Expect().chain.assertNotFailed(t)
-		assert.Equal(t, http.Header{"Content-Type": {"foo", "bar"}}, client.req.Header)
+		req.WithFileStream("a", "filename1", nil)
+		req.chain.assertFailed(t)
 	})
-}
 
-func TestRequest_RedirectsDontFollow(t *testing.T) {
-	t.Run("no body", func(t *testing.T) {
-		reporter := newMockReporter(t)
+	t.Run("multipart file stream with retries", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
 
-		tp := newMockTransportRedirect()
-		tp.assertFn = func(r *http.Request) {
-			assert.Equal(t, http.NoBody, r.Body)
-		}
+		req.WithMultipart()
+		req.WithFileStream("a", "filename1", strings.NewReader("1"))
+		req.WithMaxRetries(1)
+		req.chain.assertNotFailed(t)
 
-		config := Config{
-			Client:   &http.Client{Transport: tp},
-			Reporter: reporter,
-		}
+		req.Expect()
+		req.chain.assertFailed(t)
+	})
 
-		req := NewRequestC(config, http.MethodPut, "/url").
-			WithRedirectPolicy(DontFollowRedirects)
+	t.Run("multipart file stream with redirects", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Client:   &http.Client{Transport: newMockTransportRedirect()},
+			Reporter: newMockReporter(t),
+		}, "POST", "url")
+
+		req.WithMultipart()
+		req.WithFileStream("a", "filename1", strings.NewReader("1"))
+		req.WithRedirectPolicy(FollowAllRedirects)
 		req.chain.assertNotFailed(t)
 
-		// Should return redirection response
-		resp := req.Expect().
-			Status(tp.redirectHTTPStatusCode).
-			Header("Location").
-			IsEqual("/redirect")
-		resp.chain.assertNotFailed(t)
+		req.Expect()
+		req.chain.assertFailed(t)
+	})
 
-		// Should set GetBody
-		assert.Nil(t, req.httpReq.GetBody)
+	t.Run("seeded boundary", func(t *testing.T) {
+		send := func(seed int64) string {
+			client := &mockClient{}
 
-		// Should set CheckRedirect
-		httpClient, _ := req.config.Client.(*http.Client)
-		assert.NotNil(t, httpClient.CheckRedirect)
-		assert.Equal(t, http.ErrUseLastResponse, httpClient.CheckRedirect(req.httpReq, nil))
+			req := NewRequestC(Config{
+				Client:   client,
+				Reporter: newMockReporter(t),
+				Rand:     rand.New(rand.NewSource(seed)),
+			}, "POST", "url")
 
-		// Should do round trip
-		assert.Equal(t, 1, tp.tripCount)
-	})
+			req.WithMultipart()
+			req.WithFormField("a", 1)
+			req.Expect().chain.assertNotFailed(t)
 
-	t.Run("has body", func(t *testing.T) {
-		reporter := newMockReporter(t)
+			_, params, err := mime.ParseMediaType(client.req.Header.Get("Content-Type"))
+			require.NoError(t, err)
+			require.NotEmpty(t, params["boundary"])
 
-		tp := newMockTransportRedirect()
-		tp.assertFn = func(r *http.Request) {
-			b, err := ioutil.ReadAll(r.Body)
-			assert.NoError(t, err)
-			assert.Equal(t, "test body", string(b))
+			return params["boundary"]
 		}
 
-		config := Config{
-			Client:   &http.Client{Transport: tp},
-			Reporter: reporter,
-		}
+		assert.Equal(t, send(1), send(1))
+		assert.NotEqual(t, send(1), send(2))
+	})
 
-		req := NewRequestC(config, http.MethodPut, "/url").
-			WithRedirectPolicy(DontFollowRedirects).
-			WithText("test body")
-		req.chain.assertNotFailed(t)
+	t.Run("default boundary", func(t *testing.T) {
+		send := func() string {
+			client := &mockClient{}
 
-		// Should return redirection response
-		resp := req.Expect().
-			Status(tp.redirectHTTPStatusCode).
-			Header("Location").
-			IsEqual("/redirect")
-		resp.chain.assertNotFailed(t)
+			req := NewRequestC(Config{
+				Client:   client,
+				Reporter: newMockReporter(t),
+			}, "POST", "url")
 
-		// Should set GetBody
-		assert.Nil(t, req.httpReq.GetBody)
+			req.WithMultipart()
+			req.WithFormField("a", 1)
+			req.Expect().chain.assertNotFailed(t)
 
-		// Should set CheckRedirect
-		httpClient, _ := req.config.Client.(*http.Client)
-		assert.NotNil(t, httpClient.CheckRedirect)
-		assert.Equal(t, http.ErrUseLastResponse, httpClient.CheckRedirect(req.httpReq, nil))
+			_, params, err := mime.ParseMediaType(client.req.Header.Get("Content-Type"))
+			require.NoError(t, err)
+			require.NotEmpty(t, params["boundary"])
 
-		// Should do round trip
-		assert.Equal(t, 1, tp.tripCount)
+			return params["boundary"]
+		}
+
+		assert.NotEqual(t, send(), send())
 	})
 }
 
-func TestRequest_RedirectsFollowAll(t *testing.T) {
-	t.Run("no body", func(t *testing.T) {
-		reporter := newMockReporter(t)
-
-		tp := newMockTransportRedirect()
-		tp.maxRedirect = 1
-		tp.assertFn = func(r *http.Request) {
-			assert.Equal(t, http.NoBody, r.Body)
+func TestRequest_Clone(t *testing.T) {
+	newConfig := func(client Client) Config {
+		return Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Reporter: newMockReporter(t),
 		}
+	}
 
-		config := Config{
-			Client:   &http.Client{Transport: tp},
-			Reporter: reporter,
-		}
+	t.Run("independent", func(t *testing.T) {
+		client := &mockClient{}
 
-		req := NewRequestC(config, http.MethodPut, "/url").
-			WithRedirectPolicy(FollowAllRedirects).
-			WithMaxRedirects(1)
-		req.chain.assertNotFailed(t)
+		base := NewRequestC(newConfig(client), "POST", "/path").
+			WithHeader("X-Base", "base").
+			WithQuery("q", "base").
+			WithCookie("c", "base")
+
+		clone1 := base.Clone()
+		clone1.chain.assertNotFailed(t)
+
+		clone1.
+			WithHeader("X-Clone", "clone1").
+			WithQuery("q", "clone1").
+			WithCookie("c1", "clone1").
+			WithText("clone1")
+
+		clone2 := base.Clone()
+		clone2.chain.assertNotFailed(t)
+
+		clone2.WithText("clone2")
+
+		clone1.Expect().Body().IsEqual("clone1")
+		assert.Equal(t, "base", client.req.Header.Get("X-Base"))
+		assert.Equal(t, "clone1", client.req.Header.Get("X-Clone"))
+		assert.Equal(t, "q=base&q=clone1", client.req.URL.RawQuery)
+		assert.Equal(t, "/path", client.req.URL.Path)
+		assert.Equal(t, 2, len(client.req.Cookies()))
+
+		clone2.Expect().Body().IsEqual("clone2")
+		assert.Equal(t, "base", client.req.Header.Get("X-Base"))
+		assert.Equal(t, "", client.req.Header.Get("X-Clone"))
+		assert.Equal(t, "q=base", client.req.URL.RawQuery)
+		assert.Equal(t, "/path", client.req.URL.Path)
+		assert.Equal(t, 1, len(client.req.Cookies()))
+
+		base.Expect().Body().IsEmpty()
+		assert.Equal(t, "", client.req.Header.Get("X-Clone"))
+		assert.Equal(t, "q=base", client.req.URL.RawQuery)
+		assert.Equal(t, "/path", client.req.URL.Path)
+
+		base.chain.assertNotFailed(t)
+		clone1.chain.assertNotFailed(t)
+		clone2.chain.assertNotFailed(t)
+	})
 
-		// Should return OK response
-		resp := req.Expect().
-			Status(http.StatusOK)
-		resp.chain.assertNotFailed(t)
+	t.Run("body", func(t *testing.T) {
+		client := &mockClient{}
 
-		// Should set GetBody
-		gb, err := req.httpReq.GetBody()
-		assert.NoError(t, err)
-		assert.Equal(t, http.NoBody, gb)
+		base := NewRequestC(newConfig(client), "PUT", "/path").
+			WithBytes([]byte("body"))
 
-		// Should set CheckRedirect
-		httpClient, _ := req.config.Client.(*http.Client)
-		assert.NotNil(t, httpClient.CheckRedirect)
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-		assert.Equal(t,
-			errors.New("stopped after 1 redirects"),
-			httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)))
+		clone := base.Clone()
+		clone.chain.assertNotFailed(t)
 
-		// Should do round trip
-		assert.Equal(t, 2, tp.tripCount)
+		clone.Expect().Body().IsEqual("body")
+		base.Expect().Body().IsEqual("body")
 	})
 
-	t.Run("no body, too many redirects", func(t *testing.T) {
-		reporter := newMockReporter(t)
+	t.Run("chunked body", func(t *testing.T) {
+		client := &mockClient{}
 
-		tp := newMockTransportRedirect()
-		tp.assertFn = func(r *http.Request) {
-			assert.Equal(t, http.NoBody, r.Body)
-		}
+		base := NewRequestC(newConfig(client), "PUT", "/path").
+			WithChunked(bytes.NewBufferString("body"))
 
-		config := Config{
-			Client:   &http.Client{Transport: tp},
-			Reporter: reporter,
-		}
+		clone := base.Clone()
+		clone.chain.assertNotFailed(t)
 
-		req := NewRequestC(config, http.MethodPut, "/url").
-			WithRedirectPolicy(FollowAllRedirects).
-			WithMaxRedirects(1)
-		req.chain.assertNotFailed(t)
+		clone.Expect().Body().IsEqual("body")
+		assert.Equal(t, int64(-1), client.req.ContentLength)
 
-		// Should error
-		resp := req.Expect()
-		resp.chain.assertFailed(t)
+		base.Expect().Body().IsEqual("body")
+		assert.Equal(t, int64(-1), client.req.ContentLength)
+	})
 
-		// Should set GetBody
-		gb, err := req.httpReq.GetBody()
-		assert.NoError(t, err)
-		assert.Equal(t, http.NoBody, gb)
+	t.Run("body read error", func(t *testing.T) {
+		client := &mockClient{}
 
-		// Should set CheckRedirect
-		httpClient, _ := req.config.Client.(*http.Client)
-		assert.NotNil(t, httpClient.CheckRedirect)
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-		assert.Equal(t,
-			errors.New("stopped after 1 redirects"),
-			httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)))
+		body := newMockBody("body")
+		body.readErr = errors.New("read error")
 
-		// Should do round trip
-		assert.Equal(t, 2, tp.tripCount)
+		base := NewRequestC(newConfig(client), "PUT", "/path").
+			WithChunked(body)
+
+		clone := base.Clone()
+		clone.chain.assertFailed(t)
+		base.chain.assertFailed(t)
 	})
 
-	t.Run("has body", func(t *testing.T) {
-		reporter := newMockReporter(t)
+	t.Run("form", func(t *testing.T) {
+		client := &mockClient{}
 
-		tp := newMockTransportRedirect()
-		tp.maxRedirect = 1
-		tp.assertFn = func(r *http.Request) {
-			b, err := ioutil.ReadAll(r.Body)
-			assert.NoError(t, err)
-			assert.Equal(t, "test body", string(b))
-		}
+		base := NewRequestC(newConfig(client), "POST", "/path").
+			WithFormField("a", 1)
 
-		config := Config{
-			Client:   &http.Client{Transport: tp},
-			Reporter: reporter,
-		}
+		clone := base.Clone()
+		clone.chain.assertNotFailed(t)
 
-		req := NewRequestC(config, http.MethodPut, "/url").
-			WithRedirectPolicy(FollowAllRedirects).
-			WithMaxRedirects(1).
-			WithText("test body")
-		req.chain.assertNotFailed(t)
+		clone.WithFormField("b", 2)
 
-		// Should return OK response
-		resp := req.Expect().
-			Status(http.StatusOK)
-		resp.chain.assertNotFailed(t)
+		clone.Expect().Body().IsEqual("a=1&b=2")
+		base.Expect().Body().IsEqual("a=1")
+	})
 
-		// Should set GetBody
-		gb, err := req.httpReq.GetBody()
-		assert.NoError(t, err)
-		b, err := ioutil.ReadAll(gb)
-		assert.NoError(t, err)
-		assert.Equal(t, "test body", string(b))
+	t.Run("multipart", func(t *testing.T) {
+		client := &mockClient{}
 
-		// Should set CheckRedirect
-		httpClient, _ := req.config.Client.(*http.Client)
-		assert.NotNil(t, httpClient.CheckRedirect)
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-		assert.Equal(t,
-			errors.New("stopped after 1 redirects"),
-			httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)))
+		base := NewRequestC(newConfig(client), "POST", "/path").
+			WithMultipart().
+			WithFormField("a", 1)
 
-		// Should do round trip
-		assert.Equal(t, 2, tp.tripCount)
-	})
+		clone := base.Clone()
+		clone.chain.assertNotFailed(t)
 
-	t.Run("has body, too many redirects", func(t *testing.T) {
-		reporter := newMockReporter(t)
+		clone.WithFormField("b", 2).
+			WithFileBytes("c", "c.txt", []byte("3"))
 
-		tp := newMockTransportRedirect()
-		tp.assertFn = func(r *http.Request) {
-			b, err := ioutil.ReadAll(r.Body)
-			assert.NoError(t, err)
-			assert.Equal(t, "test body", string(b))
-		}
+		readParts := func(resp *Response) map[string]string {
+			_, params, err := mime.ParseMediaType(client.req.Header.Get("Content-Type"))
+			require.NoError(t, err)
 
-		config := Config{
-			Client:   &http.Client{Transport: tp},
-			Reporter: reporter,
-		}
+			reader := multipart.NewReader(
+				strings.NewReader(resp.Body().Raw()), params["boundary"])
 
-		req := NewRequestC(config, http.MethodPut, "/url").
-			WithRedirectPolicy(FollowAllRedirects).
-			WithMaxRedirects(1).
-			WithText("test body")
-		req.chain.assertNotFailed(t)
+			parts := map[string]string{}
+			for {
+				part, err := reader.NextPart()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
 
-		// Should error
-		resp := req.Expect()
-		resp.chain.assertFailed(t)
+				b, err := ioutil.ReadAll(part)
+				require.NoError(t, err)
 
-		// Should set GetBody
-		gb, err := req.httpReq.GetBody()
-		assert.NoError(t, err)
-		b, err := ioutil.ReadAll(gb)
-		assert.NoError(t, err)
-		assert.Equal(t, "test body", string(b))
+				parts[part.FormName()] = string(b)
+			}
+
+			return parts
+		}
 
-		// Should set CheckRedirect
-		httpClient, _ := req.config.Client.(*http.Client)
-		assert.NotNil(t, httpClient.CheckRedirect)
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
 		assert.Equal(t,
-			errors.New("stopped after 1 redirects"),
-			httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)))
+			map[string]string{"a": "1", "b": "2", "c": "3"},
+			readParts(clone.Expect()))
 
-		// Should do round trip
-		assert.Equal(t, 2, tp.tripCount)
+		assert.Equal(t,
+			map[string]string{"a": "1"},
+			readParts(base.Expect()))
 	})
-}
 
-func TestRequest_RedirectsFollowWithoutBody(t *testing.T) {
-	t.Run("no body", func(t *testing.T) {
-		reporter := newMockReporter(t)
+	t.Run("empty multipart", func(t *testing.T) {
+		client := &mockClient{}
 
-		tp := newMockTransportRedirect()
-		tp.maxRedirect = 1
-		tp.assertFn = func(r *http.Request) {
-			assert.Contains(t, []interface{}{nil, http.NoBody}, r.Body)
-		}
+		base := NewRequestC(newConfig(client), "POST", "/path").
+			WithMultipart()
 
-		config := Config{
-			Client:   &http.Client{Transport: tp},
-			Reporter: reporter,
-		}
+		clone := base.Clone()
+		clone.chain.assertNotFailed(t)
 
-		req := NewRequestC(config, http.MethodPut, "/url").
-			WithRedirectPolicy(FollowRedirectsWithoutBody).
-			WithMaxRedirects(1)
-		req.chain.assertNotFailed(t)
+		clone.WithFormField("a", 1)
 
-		// Should return OK response
-		resp := req.Expect().
-			Status(http.StatusOK)
+		resp := clone.Expect()
 		resp.chain.assertNotFailed(t)
 
-		// Should set GetBody
-		assert.Nil(t, req.httpReq.GetBody)
+		_, params, err := mime.ParseMediaType(client.req.Header.Get("Content-Type"))
+		require.NoError(t, err)
 
-		// Should set CheckRedirect
-		httpClient, _ := req.config.Client.(*http.Client)
-		assert.NotNil(t, httpClient.CheckRedirect)
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-		assert.Equal(t,
-			errors.New("stopped after 1 redirects"),
-			httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)))
+		reader := multipart.NewReader(
+			strings.NewReader(resp.Body().Raw()), params["boundary"])
 
-		// Should do round trip
-		assert.Equal(t, 2, tp.tripCount)
+		part, err := reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "a", part.FormName())
 	})
 
-	t.Run("no body, too many redirects", func(t *testing.T) {
-		reporter := newMockReporter(t)
+	t.Run("file stream", func(t *testing.T) {
+		client := &mockClient{}
 
-		tp := newMockTransportRedirect()
-		tp.assertFn = func(r *http.Request) {
-			assert.Contains(t, []interface{}{nil, http.NoBody}, r.Body)
-		}
+		base := NewRequestC(newConfig(client), "POST", "/path").
+			WithMultipart().
+			WithFileStream("a", "a.txt", bytes.NewBufferString("data"))
 
-		config := Config{
-			Client:   &http.Client{Transport: tp},
-			Reporter: reporter,
-		}
+		clone := base.Clone()
+		clone.chain.assertFailed(t)
+		base.chain.assertFailed(t)
 
-		req := NewRequestC(config, http.MethodPut, "/url").
-			WithRedirectPolicy(FollowRedirectsWithoutBody).
-			WithMaxRedirects(1)
-		req.chain.assertNotFailed(t)
+		clone.Expect().chain.assertFailed(t)
+	})
 
-		// Should error
-		resp := req.Expect()
-		resp.chain.assertFailed(t)
+	t.Run("failed clone", func(t *testing.T) {
+		client := &mockClient{}
 
-		// Should set GetBody
-		assert.Nil(t, req.httpReq.GetBody)
+		base := NewRequestC(newConfig(client), "POST", "/path")
+		base.Expect()
 
-		// Should set CheckRedirect
-		httpClient, _ := req.config.Client.(*http.Client)
-		assert.NotNil(t, httpClient.CheckRedirect)
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-		assert.Equal(t,
-			errors.New("stopped after 1 redirects"),
-			httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)))
+		clone := base.Clone()
+		clone.chain.assertFailed(t)
 
-		// Should do round trip
-		assert.Equal(t, 2, tp.tripCount)
+		clone.WithHeader("foo", "bar")
+		clone.Expect().chain.assertFailed(t)
 	})
+}
 
-	t.Run("has body, status permanent redirect", func(t *testing.T) {
-		reporter := newMockReporter(t)
+func TestRequest_BodyJSON(t *testing.T) {
+	client := &mockClient{}
 
-		tp := newMockTransportRedirect()
-		tp.maxRedirect = 1
-		tp.assertFn = func(r *http.Request) {
-			b, err := ioutil.ReadAll(r.Body)
-			assert.NoError(t, err)
-			assert.Equal(t, "test body", string(b))
-		}
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
 
-		config := Config{
-			Client:   &http.Client{Transport: tp},
-			Reporter: reporter,
+	t.Run("json", func(t *testing.T) {
+		expectedHeaders := map[string][]string{
+			"Content-Type": {"application/json; charset=utf-8"},
+			"Some-Header":  {"foo"},
 		}
 
-		req := NewRequestC(config, http.MethodPut, "/url").
-			WithRedirectPolicy(FollowRedirectsWithoutBody).
-			WithMaxRedirects(1).
-			WithText("test body")
-		req.chain.assertNotFailed(t)
+		req := NewRequestC(config, "GET", "url")
 
-		// Should return redirection response
-		resp := req.Expect().
-			Status(tp.redirectHTTPStatusCode)
+		req.WithHeaders(map[string]string{
+			"Some-Header": "foo",
+		})
+
+		req.WithJSON(map[string]interface{}{"key": "value"})
+
+		resp := req.Expect()
 		resp.chain.assertNotFailed(t)
 
-		// Should set GetBody
-		assert.Nil(t, req.httpReq.GetBody)
+		assert.Equal(t, "GET", client.req.Method)
+		assert.Equal(t, "url", client.req.URL.String())
+		assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
+		assert.Equal(t, `{"key":"value"}`, resp.Body().Raw())
 
-		// Should set CheckRedirect
-		httpClient, _ := req.config.Client.(*http.Client)
-		assert.NotNil(t, httpClient.CheckRedirect)
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-		assert.Equal(t,
-			errors.New("stopped after 1 redirects"),
-			httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)))
+		assert.Equal(t, client.resp.Header, resp.Raw().Header)
+	})
 
-		// Should do round trip
-		assert.Equal(t, 1, tp.tripCount)
+	t.Run("marshal error", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithJSON(func() {})
+
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+
+		assert.Nil(t, resp.Raw())
 	})
+}
 
-	t.Run("has body, status moved permanently", func(t *testing.T) {
-		reporter := newMockReporter(t)
+func TestRequest_BodyYAML(t *testing.T) {
+	client := &mockClient{}
 
-		tp := newMockTransportRedirect()
-		tp.maxRedirect = 1
-		tp.redirectHTTPStatusCode = http.StatusMovedPermanently
-		tp.assertFn = func(r *http.Request) {
-			if r.URL.String() == "/url" {
-				assert.Equal(t, r.Method, http.MethodPut)
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
 
-				b, err := ioutil.ReadAll(r.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			} else if r.URL.String() == "/redirect" {
-				assert.Equal(t, r.Method, http.MethodGet)
-				assert.Nil(t, r.Body)
-			} else {
-				t.Fatalf("invalid request URL")
-			}
+	t.Run("yaml", func(t *testing.T) {
+		expectedHeaders := map[string][]string{
+			"Content-Type": {"application/yaml"},
+			"Some-Header":  {"foo"},
 		}
 
-		config := Config{
-			Client:   &http.Client{Transport: tp},
-			Reporter: reporter,
-		}
+		req := NewRequestC(config, "GET", "url")
 
-		req := NewRequestC(config, http.MethodPut, "/url").
-			WithRedirectPolicy(FollowRedirectsWithoutBody).
-			WithMaxRedirects(1).
-			WithText("test body")
-		req.chain.assertNotFailed(t)
+		req.WithHeaders(map[string]string{
+			"Some-Header": "foo",
+		})
 
-		// Should return OK response
-		resp := req.Expect().
-			Status(http.StatusOK)
+		req.WithYAML(map[string]interface{}{"key": "value"})
+
+		resp := req.Expect()
 		resp.chain.assertNotFailed(t)
 
-		// Should set GetBody
-		assert.Nil(t, req.httpReq.GetBody)
+		assert.Equal(t, "GET", client.req.Method)
+		assert.Equal(t, "url", client.req.URL.String())
+		assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
+		assert.Equal(t, "key: value\n", resp.Body().Raw())
+	})
 
-		// Should set CheckRedirect
-		httpClient, _ := req.config.Client.(*http.Client)
-		assert.NotNil(t, httpClient.CheckRedirect)
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-		assert.Equal(t,
-			errors.New("stopped after 1 redirects"),
-			httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)))
+	t.Run("custom content type", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
 
-		// Should do round trip
-		assert.Equal(t, 2, tp.tripCount)
-	})
+		req.WithHeader("Content-Type", "text/yaml")
+		req.WithYAML(map[string]interface{}{"key": "value"})
 
-	t.Run("has body, status moved permanently, too many redirects", func(t *testing.T) {
-		reporter := newMockReporter(t)
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
 
-		tp := newMockTransportRedirect()
-		tp.redirectHTTPStatusCode = http.StatusMovedPermanently
-		tp.assertFn = func(r *http.Request) {
-			if r.URL.String() == "/url" {
-				assert.Equal(t, r.Method, http.MethodPut)
-
-				b, err := ioutil.ReadAll(r.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			} else if r.URL.String() == "/redirect" {
-				assert.Equal(t, r.Method, http.MethodGet)
-				assert.Nil(t, r.Body)
-			} else {
-				t.Fatalf("invalid request URL")
-			}
-		}
+		assert.Equal(t, "text/yaml", client.req.Header.Get("Content-Type"))
+		assert.Equal(t, "key: value\n", resp.Body().Raw())
+	})
 
-		config := Config{
-			Client:   &http.Client{Transport: tp},
-			Reporter: reporter,
-		}
+	t.Run("nil", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
 
-		req := NewRequestC(config, http.MethodPut, "/url").
-			WithRedirectPolicy(FollowRedirectsWithoutBody).
-			WithMaxRedirects(1).
-			WithText("test body")
-		req.chain.assertNotFailed(t)
+		req.WithYAML(nil)
 
-		// Should error
 		resp := req.Expect()
-		resp.chain.assertFailed(t)
+		resp.chain.assertNotFailed(t)
 
-		// Should set GetBody
-		assert.Nil(t, req.httpReq.GetBody)
+		assert.Equal(t, "application/yaml", client.req.Header.Get("Content-Type"))
+		assert.Equal(t, int64(0), client.req.ContentLength)
+		assert.Equal(t, "", resp.Body().Raw())
+	})
 
-		// Should set CheckRedirect
-		httpClient, _ := req.config.Client.(*http.Client)
-		assert.NotNil(t, httpClient.CheckRedirect)
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
-		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-		assert.Equal(t,
-			errors.New("stopped after 1 redirects"),
-			httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)))
+	t.Run("marshal error", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
 
-		// Should do round trip
-		assert.Equal(t, 2, tp.tripCount)
+		req.WithYAML(func() {})
+
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+
+		assert.Nil(t, resp.Raw())
 	})
 }
 
-func TestRequest_Retries(t *testing.T) {
-	reporter := newMockReporter(t)
+func TestRequest_BodyGzip(t *testing.T) {
+	client := &mockClient{}
 
-	newNoErrClient := func(cb func(req *http.Request)) *mockClient {
-		return &mockClient{
-			resp: http.Response{
-				StatusCode: http.StatusOK,
-			},
-			cb: cb,
-		}
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
 	}
 
-	newTimeoutErrClient := func(cb func(req *http.Request)) *mockClient {
-		return &mockClient{
-			err: &mockNetError{
-				isTimeout: true,
-			},
-			cb: cb,
-		}
+	gunzip := func(t *testing.T, s string) string {
+		zr, err := gzip.NewReader(strings.NewReader(s))
+		assert.NoError(t, err)
+		b, err := ioutil.ReadAll(zr)
+		assert.NoError(t, err)
+		return string(b)
 	}
 
-	newServerErrClient := func(cb func(req *http.Request)) *mockClient {
-		return &mockClient{
-			resp: http.Response{
-				StatusCode: http.StatusInternalServerError,
-			},
-			cb: cb,
-		}
-	}
+	t.Run("json", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
 
-	newHTTPErrClient := func(cb func(req *http.Request)) *mockClient {
-		return &mockClient{
-			resp: http.Response{
-				StatusCode: http.StatusBadRequest,
-			},
-			cb: cb,
-		}
-	}
+		req.WithJSON(map[string]interface{}{"key": "value"})
+		req.WithGzip()
 
-	noopSleepFn := func(time.Duration) <-chan time.Time {
-		return time.After(0)
-	}
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
 
-	t.Run("DontRetry", func(t *testing.T) {
-		t.Run("no error", func(t *testing.T) {
-			callCount := 0
+		body := resp.Body().Raw()
 
-			client := newNoErrClient(func(req *http.Request) {
-				callCount++
+		assert.Equal(t, "application/json; charset=utf-8",
+			client.req.Header.Get("Content-Type"))
+		assert.Equal(t, "gzip", client.req.Header.Get("Content-Encoding"))
+		assert.Equal(t, int64(len(body)), client.req.ContentLength)
+		assert.Equal(t, `{"key":"value"}`, gunzip(t, body))
+	})
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+	t.Run("gzip before form", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+		req.WithGzip()
+		req.WithForm(map[string]interface{}{"a": 1})
+		req.WithFormField("b", 2)
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(DontRetry)
-			req.sleepFn = noopSleepFn
-			req.chain.assertNotFailed(t)
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
 
-			resp := req.Expect()
-			resp.chain.assertNotFailed(t)
+		body := resp.Body().Raw()
 
-			// Should not retry
-			assert.Equal(t, 1, callCount)
-		})
+		assert.Equal(t, "application/x-www-form-urlencoded",
+			client.req.Header.Get("Content-Type"))
+		assert.Equal(t, "gzip", client.req.Header.Get("Content-Encoding"))
+		assert.Equal(t, int64(len(body)), client.req.ContentLength)
+		assert.Equal(t, "a=1&b=2", gunzip(t, body))
+	})
 
-		t.Run("timeout error", func(t *testing.T) {
-			callCount := 0
+	t.Run("chunked", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
 
-			client := newTimeoutErrClient(func(req *http.Request) {
-				callCount++
+		req.WithChunked(strings.NewReader("12345"))
+		req.WithGzip()
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+		body := resp.Body().Raw()
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(DontRetry).
-				WithMaxRetries(1)
-			req.sleepFn = noopSleepFn
-			req.chain.assertNotFailed(t)
+		assert.Equal(t, "gzip", client.req.Header.Get("Content-Encoding"))
+		assert.Equal(t, int64(len(body)), client.req.ContentLength)
+		assert.Equal(t, "12345", gunzip(t, body))
+	})
 
-			resp := req.Expect()
-			resp.chain.assertFailed(t)
+	t.Run("streamed", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
 
-			// Should not retry
-			assert.Equal(t, 1, callCount)
-		})
+		req.WithReader(strings.NewReader("12345"), 5)
+		req.WithGzip()
 
-		t.Run("server error", func(t *testing.T) {
-			callCount := 0
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
 
-			client := newServerErrClient(func(req *http.Request) {
-				callCount++
+		body := resp.Body().Raw()
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+		assert.Equal(t, "gzip", client.req.Header.Get("Content-Encoding"))
+		assert.Equal(t, int64(-1), client.req.ContentLength)
+		assert.Equal(t, "12345", gunzip(t, body))
+	})
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+	t.Run("retries", func(t *testing.T) {
+		var bodies []string
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(DontRetry).
-				WithMaxRetries(1)
-			req.sleepFn = noopSleepFn
-			req.chain.assertNotFailed(t)
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				bodies = append(bodies, gunzip(t, string(b)))
+				if len(bodies) == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+		defer server.Close()
 
-			resp := req.Expect().
-				Status(http.StatusInternalServerError)
-			resp.chain.assertNotFailed(t)
+		req := NewRequestC(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		}, "POST", "/")
 
-			// Should not retry
-			assert.Equal(t, 1, callCount)
-		})
+		req.WithText("12345")
+		req.WithGzip()
+		req.WithMaxRetries(1)
+		req.WithRetryDelay(0, 0)
 
-		t.Run("http error", func(t *testing.T) {
-			callCount := 0
+		req.Expect().
+			Status(http.StatusOK).
+			chain.assertNotFailed(t)
 
-			client := newHTTPErrClient(func(req *http.Request) {
-				callCount++
+		// Should resend compressed body
+		assert.Equal(t, []string{"12345", "12345"}, bodies)
+	})
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+	t.Run("no body", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+		req.WithGzip()
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(DontRetry).
-				WithMaxRetries(1)
-			req.sleepFn = noopSleepFn
-			req.chain.assertNotFailed(t)
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
 
-			resp := req.Expect().
-				Status(http.StatusBadRequest)
-			resp.chain.assertNotFailed(t)
+		assert.Equal(t, "", client.req.Header.Get("Content-Encoding"))
+		assert.Equal(t, int64(0), client.req.ContentLength)
+		assert.Equal(t, "", resp.Body().Raw())
+	})
+}
 
-			// Should not retry
-			assert.Equal(t, 1, callCount)
-		})
+func TestRequest_ContentLength(t *testing.T) {
+	client := &mockClient{}
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
 
+	t.Run("chunked", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+		req.WithChunked(bytes.NewReader([]byte("12345")))
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, int64(-1), client.req.ContentLength)
 	})
 
-	t.Run("RetryTimeoutErrors", func(t *testing.T) {
-		t.Run("no error", func(t *testing.T) {
-			callCount := 0
+	t.Run("bytes", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+		req.WithBytes([]byte("12345"))
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, int64(5), client.req.ContentLength)
+	})
 
-			client := newNoErrClient(func(req *http.Request) {
-				callCount++
+	t.Run("text", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+		req.WithText("12345")
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, int64(5), client.req.ContentLength)
+	})
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+	t.Run("json", func(t *testing.T) {
+		j, _ := json.Marshal(map[string]string{"a": "b"})
+		req := NewRequestC(config, "GET", "url")
+		req.WithJSON(map[string]string{"a": "b"})
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, int64(len(j)), client.req.ContentLength)
+	})
 
-			config := Config{
-				Client:   client,
+	t.Run("form", func(t *testing.T) {
+		f := `a=b`
+		req := NewRequestC(config, "GET", "url")
+		req.WithForm(map[string]string{"a": "b"})
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, int64(len(f)), client.req.ContentLength)
+	})
+
+	t.Run("form field", func(t *testing.T) {
+		f := `a=b`
+		req := NewRequestC(config, "GET", "url")
+		req.WithFormField("a", "b")
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, int64(len(f)), client.req.ContentLength)
+	})
+
+	t.Run("multipart", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+		req.WithMultipart()
+		req.WithFileBytes("a", "b", []byte("12345"))
+		req.Expect().chain.assertNotFailed(t)
+		assert.True(t, client.req.ContentLength > 0)
+	})
+}
+
+func TestRequest_ContentType(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	t.Run("WithText sets Content-Type header", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+		req.WithText("hello")
+		req.WithHeader("Content-Type", "foo")
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, http.Header{"Content-Type": {"foo"}}, client.req.Header)
+	})
+
+	t.Run("WithHeader sets Content-Type header", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+		req.WithHeader("Content-Type", "foo")
+		req.WithText("hello")
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, http.Header{"Content-Type": {"foo"}}, client.req.Header)
+	})
+
+	t.Run("WithJSON overrides Content-Type header", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+		req.WithJSON(map[string]interface{}{"a": "b"})
+		req.WithHeader("Content-Type", "foo")
+		req.WithHeader("Content-Type", "bar")
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, http.Header{"Content-Type": {"foo", "bar"}}, client.req.Header)
+	})
+
+	t.Run("WithForm overrides Content-Type header", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+		req.WithForm(map[string]interface{}{"a": "b"})
+		req.WithHeader("Content-Type", "foo")
+		req.WithHeader("Content-Type", "bar")
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, http.Header{"Content-Type": {"foo", "bar"}}, client.req.Header)
+	})
+
+	t.Run("WithMultipart overrides Content-Type header", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+		req.WithMultipart()
+		req.WithForm(map[string]interface{}{"a": "b"})
+		req.WithHeader("Content-Type", "foo")
+		req.WithHeader("Content-Type", "bar")
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, http.Header{"Content-Type": {"foo", "bar"}}, client.req.Header)
+	})
+}
+
+func TestRequest_RedirectsDontFollow(t *testing.T) {
+	t.Run("no body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+		tp.assertFn = func(r *http.Request) {
+			assert.Equal(t, http.NoBody, r.Body)
+		}
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodPut, "/url").
+			WithRedirectPolicy(DontFollowRedirects)
+		req.chain.assertNotFailed(t)
+
+		// Should return redirection response
+		resp := req.Expect().
+			Status(tp.redirectHTTPStatusCode).
+			Header("Location").
+			IsEqual("/redirect")
+		resp.chain.assertNotFailed(t)
+
+		// Should set GetBody
+		assert.Nil(t, req.httpReq.GetBody)
+
+		// Should set CheckRedirect
+		httpClient, _ := req.config.Client.(*http.Client)
+		assert.NotNil(t, httpClient.CheckRedirect)
+		assert.Equal(t, http.ErrUseLastResponse, httpClient.CheckRedirect(req.httpReq, nil))
+
+		// Should do round trip
+		assert.Equal(t, 1, tp.tripCount)
+	})
+
+	t.Run("has body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+		tp.assertFn = func(r *http.Request) {
+			b, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, "test body", string(b))
+		}
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodPut, "/url").
+			WithRedirectPolicy(DontFollowRedirects).
+			WithText("test body")
+		req.chain.assertNotFailed(t)
+
+		// Should return redirection response
+		resp := req.Expect().
+			Status(tp.redirectHTTPStatusCode).
+			Header("Location").
+			IsEqual("/redirect")
+		resp.chain.assertNotFailed(t)
+
+		// Should set GetBody
+		assert.Nil(t, req.httpReq.GetBody)
+
+		// Should set CheckRedirect
+		httpClient, _ := req.config.Client.(*http.Client)
+		assert.NotNil(t, httpClient.CheckRedirect)
+		assert.Equal(t, http.ErrUseLastResponse, httpClient.CheckRedirect(req.httpReq, nil))
+
+		// Should do round trip
+		assert.Equal(t, 1, tp.tripCount)
+	})
+}
+
+func TestRequest_RedirectsFollowAll(t *testing.T) {
+	t.Run("no body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+		tp.maxRedirect = 1
+		tp.assertFn = func(r *http.Request) {
+			assert.Equal(t, http.NoBody, r.Body)
+		}
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodPut, "/url").
+			WithRedirectPolicy(FollowAllRedirects).
+			WithMaxRedirects(1)
+		req.chain.assertNotFailed(t)
+
+		// Should return OK response
+		resp := req.Expect().
+			Status(http.StatusOK)
+		resp.chain.assertNotFailed(t)
+
+		// Should set GetBody
+		gb, err := req.httpReq.GetBody()
+		assert.NoError(t, err)
+		assert.Equal(t, http.NoBody, gb)
+
+		// Should set CheckRedirect
+		httpClient, _ := req.config.Client.(*http.Client)
+		assert.NotNil(t, httpClient.CheckRedirect)
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
+		assert.Equal(t,
+			errors.New("stopped after 1 redirects: /url -> /url -> /url"),
+			httpClient.CheckRedirect(req.httpReq, []*http.Request{req.httpReq, req.httpReq}))
+
+		// Should do round trip
+		assert.Equal(t, 2, tp.tripCount)
+	})
+
+	t.Run("no body, too many redirects", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+		tp.assertFn = func(r *http.Request) {
+			assert.Equal(t, http.NoBody, r.Body)
+		}
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodPut, "/url").
+			WithRedirectPolicy(FollowAllRedirects).
+			WithMaxRedirects(1)
+		req.chain.assertNotFailed(t)
+
+		// Should error
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+
+		// Should set GetBody
+		gb, err := req.httpReq.GetBody()
+		assert.NoError(t, err)
+		assert.Equal(t, http.NoBody, gb)
+
+		// Should set CheckRedirect
+		httpClient, _ := req.config.Client.(*http.Client)
+		assert.NotNil(t, httpClient.CheckRedirect)
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
+		assert.Equal(t,
+			errors.New("stopped after 1 redirects: /url -> /url -> /url"),
+			httpClient.CheckRedirect(req.httpReq, []*http.Request{req.httpReq, req.httpReq}))
+
+		// Should do round trip
+		assert.Equal(t, 2, tp.tripCount)
+	})
+
+	t.Run("has body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+		tp.maxRedirect = 1
+		tp.assertFn = func(r *http.Request) {
+			b, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, "test body", string(b))
+		}
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodPut, "/url").
+			WithRedirectPolicy(FollowAllRedirects).
+			WithMaxRedirects(1).
+			WithText("test body")
+		req.chain.assertNotFailed(t)
+
+		// Should return OK response
+		resp := req.Expect().
+			Status(http.StatusOK)
+		resp.chain.assertNotFailed(t)
+
+		// Should set GetBody
+		gb, err := req.httpReq.GetBody()
+		assert.NoError(t, err)
+		b, err := ioutil.ReadAll(gb)
+		assert.NoError(t, err)
+		assert.Equal(t, "test body", string(b))
+
+		// Should set CheckRedirect
+		httpClient, _ := req.config.Client.(*http.Client)
+		assert.NotNil(t, httpClient.CheckRedirect)
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
+		assert.Equal(t,
+			errors.New("stopped after 1 redirects: /url -> /url -> /url"),
+			httpClient.CheckRedirect(req.httpReq, []*http.Request{req.httpReq, req.httpReq}))
+
+		// Should do round trip
+		assert.Equal(t, 2, tp.tripCount)
+	})
+
+	t.Run("has body, too many redirects", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+		tp.assertFn = func(r *http.Request) {
+			b, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, "test body", string(b))
+		}
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodPut, "/url").
+			WithRedirectPolicy(FollowAllRedirects).
+			WithMaxRedirects(1).
+			WithText("test body")
+		req.chain.assertNotFailed(t)
+
+		// Should error
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+
+		// Should set GetBody
+		gb, err := req.httpReq.GetBody()
+		assert.NoError(t, err)
+		b, err := ioutil.ReadAll(gb)
+		assert.NoError(t, err)
+		assert.Equal(t, "test body", string(b))
+
+		// Should set CheckRedirect
+		httpClient, _ := req.config.Client.(*http.Client)
+		assert.NotNil(t, httpClient.CheckRedirect)
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
+		assert.Equal(t,
+			errors.New("stopped after 1 redirects: /url -> /url -> /url"),
+			httpClient.CheckRedirect(req.httpReq, []*http.Request{req.httpReq, req.httpReq}))
+
+		// Should do round trip
+		assert.Equal(t, 2, tp.tripCount)
+	})
+}
+
+func TestRequest_RedirectsFollowWithoutBody(t *testing.T) {
+	t.Run("no body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+		tp.maxRedirect = 1
+		tp.assertFn = func(r *http.Request) {
+			assert.Contains(t, []interface{}{nil, http.NoBody}, r.Body)
+		}
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodPut, "/url").
+			WithRedirectPolicy(FollowRedirectsWithoutBody).
+			WithMaxRedirects(1)
+		req.chain.assertNotFailed(t)
+
+		// Should return OK response
+		resp := req.Expect().
+			Status(http.StatusOK)
+		resp.chain.assertNotFailed(t)
+
+		// Should set GetBody
+		assert.Nil(t, req.httpReq.GetBody)
+
+		// Should set CheckRedirect
+		httpClient, _ := req.config.Client.(*http.Client)
+		assert.NotNil(t, httpClient.CheckRedirect)
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
+		assert.Equal(t,
+			errors.New("stopped after 1 redirects: /url -> /url -> /url"),
+			httpClient.CheckRedirect(req.httpReq, []*http.Request{req.httpReq, req.httpReq}))
+
+		// Should do round trip
+		assert.Equal(t, 2, tp.tripCount)
+	})
+
+	t.Run("no body, too many redirects", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+		tp.assertFn = func(r *http.Request) {
+			assert.Contains(t, []interface{}{nil, http.NoBody}, r.Body)
+		}
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodPut, "/url").
+			WithRedirectPolicy(FollowRedirectsWithoutBody).
+			WithMaxRedirects(1)
+		req.chain.assertNotFailed(t)
+
+		// Should error
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+
+		// Should set GetBody
+		assert.Nil(t, req.httpReq.GetBody)
+
+		// Should set CheckRedirect
+		httpClient, _ := req.config.Client.(*http.Client)
+		assert.NotNil(t, httpClient.CheckRedirect)
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
+		assert.Equal(t,
+			errors.New("stopped after 1 redirects: /url -> /url -> /url"),
+			httpClient.CheckRedirect(req.httpReq, []*http.Request{req.httpReq, req.httpReq}))
+
+		// Should do round trip
+		assert.Equal(t, 2, tp.tripCount)
+	})
+
+	t.Run("has body, status permanent redirect", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+		tp.maxRedirect = 1
+		tp.assertFn = func(r *http.Request) {
+			b, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, "test body", string(b))
+		}
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodPut, "/url").
+			WithRedirectPolicy(FollowRedirectsWithoutBody).
+			WithMaxRedirects(1).
+			WithText("test body")
+		req.chain.assertNotFailed(t)
+
+		// Should return redirection response
+		resp := req.Expect().
+			Status(tp.redirectHTTPStatusCode)
+		resp.chain.assertNotFailed(t)
+
+		// Should set GetBody
+		assert.Nil(t, req.httpReq.GetBody)
+
+		// Should set CheckRedirect
+		httpClient, _ := req.config.Client.(*http.Client)
+		assert.NotNil(t, httpClient.CheckRedirect)
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
+		assert.Equal(t,
+			errors.New("stopped after 1 redirects: /url -> /url -> /url"),
+			httpClient.CheckRedirect(req.httpReq, []*http.Request{req.httpReq, req.httpReq}))
+
+		// Should do round trip
+		assert.Equal(t, 1, tp.tripCount)
+	})
+
+	t.Run("has body, status moved permanently", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+		tp.maxRedirect = 1
+		tp.redirectHTTPStatusCode = http.StatusMovedPermanently
+		tp.assertFn = func(r *http.Request) {
+			if r.URL.String() == "/url" {
+				assert.Equal(t, r.Method, http.MethodPut)
+
+				b, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			} else if r.URL.String() == "/redirect" {
+				assert.Equal(t, r.Method, http.MethodGet)
+				assert.Nil(t, r.Body)
+			} else {
+				t.Fatalf("invalid request URL")
+			}
+		}
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodPut, "/url").
+			WithRedirectPolicy(FollowRedirectsWithoutBody).
+			WithMaxRedirects(1).
+			WithText("test body")
+		req.chain.assertNotFailed(t)
+
+		// Should return OK response
+		resp := req.Expect().
+			Status(http.StatusOK)
+		resp.chain.assertNotFailed(t)
+
+		// Should set GetBody
+		assert.Nil(t, req.httpReq.GetBody)
+
+		// Should set CheckRedirect
+		httpClient, _ := req.config.Client.(*http.Client)
+		assert.NotNil(t, httpClient.CheckRedirect)
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
+		assert.Equal(t,
+			errors.New("stopped after 1 redirects: /url -> /url -> /url"),
+			httpClient.CheckRedirect(req.httpReq, []*http.Request{req.httpReq, req.httpReq}))
+
+		// Should do round trip
+		assert.Equal(t, 2, tp.tripCount)
+	})
+
+	t.Run("has body, status moved permanently, too many redirects", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+		tp.redirectHTTPStatusCode = http.StatusMovedPermanently
+		tp.assertFn = func(r *http.Request) {
+			if r.URL.String() == "/url" {
+				assert.Equal(t, r.Method, http.MethodPut)
+
+				b, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			} else if r.URL.String() == "/redirect" {
+				assert.Equal(t, r.Method, http.MethodGet)
+				assert.Nil(t, r.Body)
+			} else {
+				t.Fatalf("invalid request URL")
+			}
+		}
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodPut, "/url").
+			WithRedirectPolicy(FollowRedirectsWithoutBody).
+			WithMaxRedirects(1).
+			WithText("test body")
+		req.chain.assertNotFailed(t)
+
+		// Should error
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+
+		// Should set GetBody
+		assert.Nil(t, req.httpReq.GetBody)
+
+		// Should set CheckRedirect
+		httpClient, _ := req.config.Client.(*http.Client)
+		assert.NotNil(t, httpClient.CheckRedirect)
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
+		assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
+		assert.Equal(t,
+			errors.New("stopped after 1 redirects: /url -> /url -> /url"),
+			httpClient.CheckRedirect(req.httpReq, []*http.Request{req.httpReq, req.httpReq}))
+
+		// Should do round trip
+		assert.Equal(t, 2, tp.tripCount)
+	})
+}
+
+func TestRequest_RedirectsClientCheck(t *testing.T) {
+	t.Run("client check is invoked", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+
+		var checkCount int
+
+		config := Config{
+			Client: &http.Client{
+				Transport: tp,
+				CheckRedirect: func(req *http.Request, via []*http.Request) error {
+					checkCount++
+					return http.ErrUseLastResponse
+				},
+			},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodGet, "/url").
+			WithRedirectPolicy(FollowAllRedirects).
+			WithMaxRedirects(5)
+		req.chain.assertNotFailed(t)
+
+		// Should return redirection response
+		resp := req.Expect().
+			Status(tp.redirectHTTPStatusCode).
+			Header("Location").
+			IsEqual("/redirect")
+		resp.chain.assertNotFailed(t)
+
+		// Should invoke client check
+		assert.Equal(t, 1, checkCount)
+		assert.Equal(t, 1, tp.tripCount)
+	})
+
+	t.Run("limit is checked first", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+
+		var checkCount int
+
+		config := Config{
+			Client: &http.Client{
+				Transport: tp,
+				CheckRedirect: func(req *http.Request, via []*http.Request) error {
+					checkCount++
+					return nil
+				},
+			},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodGet, "/url").
+			WithRedirectPolicy(FollowAllRedirects).
+			WithMaxRedirects(1)
+		req.chain.assertNotFailed(t)
+
+		// Should error
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+
+		// Should invoke client check only within limit
+		assert.Equal(t, 1, checkCount)
+		assert.Equal(t, 2, tp.tripCount)
+	})
+
+	t.Run("client is not modified", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockTransportRedirect()
+
+		client := &http.Client{
+			Transport: tp,
+		}
+
+		config := Config{
+			Client:   client,
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodGet, "/url").
+			WithRedirectPolicy(DontFollowRedirects)
+		req.chain.assertNotFailed(t)
+
+		req.Expect().
+			Status(tp.redirectHTTPStatusCode)
+
+		// Should not modify original client
+		assert.Nil(t, client.CheckRedirect)
+	})
+}
+
+func TestRequest_ConnectionClose(t *testing.T) {
+	type connServer struct {
+		*httptest.Server
+		mu        sync.Mutex
+		connCount int
+		closeReqs []string
+	}
+
+	recordClose := func(srv *connServer, r *http.Request) {
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+
+		if r.Close {
+			srv.closeReqs = append(srv.closeReqs, r.URL.Path)
+		}
+	}
+
+	newServer := func() *connServer {
+		srv := &connServer{}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+			recordClose(srv, r)
+			http.Redirect(w, r, "/target", http.StatusFound)
+		})
+		mux.HandleFunc("/target", func(w http.ResponseWriter, r *http.Request) {
+			recordClose(srv, r)
+			_, _ = w.Write([]byte("target"))
+		})
+
+		srv.Server = httptest.NewUnstartedServer(mux)
+		srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				srv.mu.Lock()
+				srv.connCount++
+				srv.mu.Unlock()
+			}
+		}
+		srv.Start()
+
+		return srv
+	}
+
+	t.Run("request", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, http.MethodGet, "/url").
+			WithConnectionClose()
+
+		req.Expect().chain.assertNotFailed(t)
+
+		assert.True(t, client.req.Close)
+		assert.Equal(t, "close", client.req.Header.Get("Connection"))
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, http.MethodGet, "/url").
+			WithConnectionClose().
+			Clone()
+
+		req.Expect().chain.assertNotFailed(t)
+
+		assert.True(t, client.req.Close)
+		assert.Equal(t, "close", client.req.Header.Get("Connection"))
+	})
+
+	t.Run("connection reuse", func(t *testing.T) {
+		srv := newServer()
+		defer srv.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  srv.URL,
+			Client:   &http.Client{Transport: &http.Transport{}},
+			Reporter: newMockReporter(t),
+		})
+
+		for i := 0; i < 3; i++ {
+			e.GET("/target").
+				Expect().
+				Body().IsEqual("target")
+		}
+
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+
+		assert.Equal(t, 1, srv.connCount)
+		assert.Equal(t, 0, len(srv.closeReqs))
+	})
+
+	t.Run("connection close", func(t *testing.T) {
+		srv := newServer()
+		defer srv.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  srv.URL,
+			Client:   &http.Client{Transport: &http.Transport{}},
+			Reporter: newMockReporter(t),
+		})
+
+		for i := 0; i < 3; i++ {
+			e.GET("/target").
+				WithConnectionClose().
+				Expect().
+				Body().IsEqual("target")
+		}
+
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+
+		assert.Equal(t, 3, srv.connCount)
+		assert.Equal(t, []string{"/target", "/target", "/target"}, srv.closeReqs)
+	})
+
+	t.Run("redirects", func(t *testing.T) {
+		srv := newServer()
+		defer srv.Close()
+
+		client := &http.Client{Transport: &http.Transport{}}
+
+		e := WithConfig(Config{
+			BaseURL:  srv.URL,
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+
+		e.GET("/redirect").
+			WithConnectionClose().
+			Expect().
+			Body().IsEqual("target")
+
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+
+		assert.Equal(t, 2, srv.connCount)
+		assert.Equal(t, []string{"/redirect", "/target"}, srv.closeReqs)
+
+		// Should not modify original client
+		assert.Nil(t, client.CheckRedirect)
+	})
+}
+
+// connTracker counts connections of http.Server by tracking their states.
+type connTracker struct {
+	mu     sync.Mutex
+	opened int
+	closed int
+}
+
+func (c *connTracker) connState(_ net.Conn, state http.ConnState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch state {
+	case http.StateNew:
+		c.opened++
+	case http.StateClosed, http.StateHijacked:
+		c.closed++
+	}
+}
+
+func (c *connTracker) counts() (opened, closed int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.opened, c.closed
+}
+
+// assertClosed checks that n connections were opened and all of them
+// are eventually closed.
+func (c *connTracker) assertClosed(t *testing.T, n int) {
+	assert.Eventually(t, func() bool {
+		opened, closed := c.counts()
+		return opened == n && closed == n
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRequest_Proxy(t *testing.T) {
+	newProxy := func() (*httptest.Server, *[]string) {
+		var targets []string
+
+		proxy := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				targets = append(targets, r.URL.String())
+				w.WriteHeader(http.StatusAccepted)
+			}))
+
+		return proxy, &targets
+	}
+
+	t.Run("request is sent via proxy", func(t *testing.T) {
+		proxy, targets := newProxy()
+		defer proxy.Close()
+
+		client := &http.Client{}
+
+		config := Config{
+			BaseURL:  "http://example.invalid",
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, http.MethodGet, "/path").
+			WithProxy(proxy.URL)
+		req.chain.assertNotFailed(t)
+
+		req.Expect().
+			Status(http.StatusAccepted).
+			chain.assertNotFailed(t)
+
+		assert.Equal(t, []string{"http://example.invalid/path"}, *targets)
+
+		// Should not modify original client
+		assert.Nil(t, client.Transport)
+	})
+
+	t.Run("transport is copied", func(t *testing.T) {
+		proxy, targets := newProxy()
+		defer proxy.Close()
+
+		transport := &http.Transport{}
+
+		config := Config{
+			BaseURL:  "http://example.invalid",
+			Client:   &http.Client{Transport: transport},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, http.MethodGet, "/path").
+			WithProxy(proxy.URL)
+		req.chain.assertNotFailed(t)
+
+		req.Expect().
+			Status(http.StatusAccepted).
+			chain.assertNotFailed(t)
+
+		assert.Equal(t, []string{"http://example.invalid/path"}, *targets)
+
+		// Should not modify original transport
+		assert.Nil(t, transport.Proxy)
+	})
+
+	t.Run("other requests are not affected", func(t *testing.T) {
+		proxy, targets := newProxy()
+		defer proxy.Close()
+
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Client:   &http.Client{Transport: &http.Transport{}},
+			Reporter: newMockReporter(t),
+		})
+
+		e.GET("/path").
+			WithProxy(proxy.URL).
+			Expect().
+			Status(http.StatusAccepted)
+
+		e.GET("/path").
+			Expect().
+			Status(http.StatusOK)
+
+		assert.Equal(t, []string{server.URL + "/path"}, *targets)
+	})
+
+	t.Run("idle connections are closed", func(t *testing.T) {
+		tracker := &connTracker{}
+
+		proxy := httptest.NewUnstartedServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("proxied"))
+			}))
+		proxy.Config.ConnState = tracker.connState
+		proxy.Start()
+		defer proxy.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.invalid",
+			Client:   &http.Client{Transport: &http.Transport{}},
+			Reporter: newMockReporter(t),
+		})
+
+		for i := 0; i < 2; i++ {
+			e.GET("/path").
+				WithProxy(proxy.URL).
+				Expect().
+				Body().IsEqual("proxied")
+		}
+
+		// Should close connections of transport copies
+		tracker.assertClosed(t, 2)
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		proxy, targets := newProxy()
+		defer proxy.Close()
+
+		config := Config{
+			BaseURL:  "http://example.invalid",
+			Client:   &http.Client{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, http.MethodGet, "/path").
+			WithProxy(proxy.URL).
+			Clone()
+
+		req.Expect().
+			Status(http.StatusAccepted).
+			chain.assertNotFailed(t)
+
+		assert.Equal(t, []string{"http://example.invalid/path"}, *targets)
+	})
+}
+
+func TestRequest_Deadline(t *testing.T) {
+	newClient := func(deadline *time.Time) *mockClient {
+		return &mockClient{
+			cb: func(req *http.Request) {
+				*deadline, _ = req.Context().Deadline()
+			},
+		}
+	}
+
+	t.Run("deadline", func(t *testing.T) {
+		var deadline time.Time
+
+		expected := time.Now().Add(time.Hour)
+
+		NewRequestC(Config{
+			Client:   newClient(&deadline),
+			Reporter: newMockReporter(t),
+		}, "GET", "/url").
+			WithDeadline(expected).
+			Expect().
+			chain.assertNotFailed(t)
+
+		assert.True(t, expected.Equal(deadline))
+	})
+
+	t.Run("deadline before timeout", func(t *testing.T) {
+		var deadline time.Time
+
+		expected := time.Now().Add(time.Minute)
+
+		NewRequestC(Config{
+			Client:   newClient(&deadline),
+			Reporter: newMockReporter(t),
+		}, "GET", "/url").
+			WithTimeout(time.Hour).
+			WithDeadline(expected).
+			Expect().
+			chain.assertNotFailed(t)
+
+		assert.True(t, expected.Equal(deadline))
+	})
+
+	t.Run("timeout before deadline", func(t *testing.T) {
+		var deadline time.Time
+
+		start := time.Now()
+
+		NewRequestC(Config{
+			Client:   newClient(&deadline),
+			Reporter: newMockReporter(t),
+			Timeout:  time.Minute,
+		}, "GET", "/url").
+			WithDeadline(start.Add(time.Hour)).
+			Expect().
+			chain.assertNotFailed(t)
+
+		assert.False(t, deadline.IsZero())
+		assert.True(t, deadline.Before(start.Add(time.Hour-time.Minute)))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var deadline time.Time
+
+		NewRequestC(Config{
+			Client:   newClient(&deadline),
+			Reporter: newMockReporter(t),
+		}, "GET", "/url").
+			WithDeadline(time.Now().Add(time.Hour)).
+			WithDeadline(time.Time{}).
+			Expect().
+			chain.assertNotFailed(t)
+
+		assert.True(t, deadline.IsZero())
+	})
+
+	t.Run("no retries after deadline", func(t *testing.T) {
+		callCount := 0
+
+		client := &mockClient{
+			err: &mockNetError{
+				isTimeout: true,
+			},
+			cb: func(req *http.Request) {
+				callCount++
+			},
+		}
+
+		req := NewRequestC(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}, "GET", "/url").
+			WithDeadline(time.Now().Add(-time.Second)).
+			WithMaxRetries(3).
+			WithRetryPolicy(RetryTimeoutErrors).
+			WithRetryDelay(0, 0)
+
+		req.Expect().chain.assertFailed(t)
+
+		assert.Equal(t, 1, callCount)
+	})
+}
+
+func TestRequest_ConfigTimeout(t *testing.T) {
+	t.Run("config timeout", func(t *testing.T) {
+		var deadline time.Time
+
+		client := &mockClient{
+			cb: func(req *http.Request) {
+				deadline, _ = req.Context().Deadline()
+			},
+		}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Timeout:  time.Hour,
+		}
+
+		start := time.Now()
+
+		NewRequestC(config, "GET", "/url").
+			Expect().
+			chain.assertNotFailed(t)
+
+		assert.False(t, deadline.IsZero())
+		assert.True(t, deadline.After(start.Add(time.Hour-time.Minute)))
+	})
+
+	t.Run("overridden by request", func(t *testing.T) {
+		var deadline time.Time
+
+		client := &mockClient{
+			cb: func(req *http.Request) {
+				deadline, _ = req.Context().Deadline()
+			},
+		}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Timeout:  time.Hour,
+		}
+
+		start := time.Now()
+
+		NewRequestC(config, "GET", "/url").
+			WithTimeout(time.Minute).
+			Expect().
+			chain.assertNotFailed(t)
+
+		assert.False(t, deadline.IsZero())
+		assert.True(t, deadline.Before(start.Add(time.Hour-time.Minute)))
+	})
+
+	t.Run("disabled by request", func(t *testing.T) {
+		hasDeadline := true
+
+		client := &mockClient{
+			cb: func(req *http.Request) {
+				_, hasDeadline = req.Context().Deadline()
+			},
+		}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Timeout:  time.Hour,
+		}
+
+		NewRequestC(config, "GET", "/url").
+			WithTimeout(0).
+			Expect().
+			chain.assertNotFailed(t)
+
+		assert.False(t, hasDeadline)
+	})
+}
+
+func TestRequest_MaxRedirects(t *testing.T) {
+	t.Run("limit exceeded", func(t *testing.T) {
+		tp := newMockTransportRedirect()
+		tp.redirectHTTPStatusCode = http.StatusFound
+
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			BaseURL:          "http://example.com",
+			Client:           &http.Client{Transport: tp},
+			AssertionHandler: handler,
+		}
+
+		resp := NewRequestC(config, http.MethodGet, "/url").
+			WithMaxRedirects(2).
+			Expect()
+		resp.chain.assertFailed(t)
+
+		assert.Equal(t, 3, tp.tripCount)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		require.Equal(t, 2, len(handler.failure.Errors))
+		assert.Contains(t, handler.failure.Errors[1].Error(),
+			"stopped after 2 redirects: "+
+				"http://example.com/url -> "+
+				"http://example.com/redirect -> "+
+				"http://example.com/redirect -> "+
+				"http://example.com/redirect")
+	})
+
+	t.Run("zero limit", func(t *testing.T) {
+		tp := newMockTransportRedirect()
+		tp.redirectHTTPStatusCode = http.StatusFound
+
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			BaseURL:          "http://example.com",
+			Client:           &http.Client{Transport: tp},
+			AssertionHandler: handler,
+		}
+
+		resp := NewRequestC(config, http.MethodGet, "/url").
+			WithMaxRedirects(0).
+			Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, 1, tp.tripCount)
+		assert.Nil(t, handler.failure)
+
+		resp.Status(http.StatusFound)
+		resp.Header("Location").IsEqual("/redirect")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("within limit", func(t *testing.T) {
+		tp := newMockTransportRedirect()
+		tp.redirectHTTPStatusCode = http.StatusFound
+		tp.maxRedirect = 2
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: newMockReporter(t),
+		}
+
+		resp := NewRequestC(config, http.MethodGet, "/url").
+			WithMaxRedirects(2).
+			Expect()
+		resp.chain.assertNotFailed(t)
+
+		resp.Status(http.StatusOK)
+		assert.Equal(t, 3, tp.tripCount)
+	})
+
+	t.Run("negative limit", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client:           &http.Client{Transport: newMockTransportRedirect()},
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, http.MethodGet, "/url").
+			WithMaxRedirects(-1)
+		req.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertUsage, handler.failure.Type)
+	})
+}
+
+func TestRequest_MaxResponseSize(t *testing.T) {
+	t.Run("within limit", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}, "POST", "url")
+
+		req.WithBytes([]byte("12345"))
+		req.WithMaxResponseSize(5)
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		resp.Body().IsEqual("12345")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("body exceeds limit", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}, "POST", "url")
+
+		req.WithBytes([]byte("12345"))
+		req.WithMaxResponseSize(4)
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		resp.Body()
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("json exceeds limit", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}, "POST", "url")
+
+		req.WithJSON(map[string]interface{}{"foo": "bar"})
+		req.WithMaxResponseSize(4)
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		resp.JSON().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("content length exceeds limit", func(t *testing.T) {
+		body := strings.NewReader("12345")
+
+		req := NewRequestC(Config{
+			Client: ClientFunc(func(*http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode:    http.StatusOK,
+					ContentLength: 5,
+					Body:          ioutil.NopCloser(body),
+				}, nil
+			}),
+			Reporter: newMockReporter(t),
+		}, "GET", "url")
+
+		req.WithMaxResponseSize(4)
+
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+
+		// body was not read
+		assert.Equal(t, 5, body.Len())
+	})
+
+	t.Run("limit reader", func(t *testing.T) {
+		lb := newLimitedBody(ioutil.NopCloser(strings.NewReader("12345")), -1, 3)
+
+		b, err := ioutil.ReadAll(lb)
+		assert.Error(t, err)
+		assert.Equal(t, "123", string(b))
+
+		_, err = lb.Read(make([]byte, 10))
+		assert.Error(t, err)
+
+		lb = newLimitedBody(ioutil.NopCloser(strings.NewReader("123")), -1, 3)
+
+		b, err = ioutil.ReadAll(lb)
+		assert.NoError(t, err)
+		assert.Equal(t, "123", string(b))
+	})
+}
+
+func TestRequest_WithoutBodyBuffering(t *testing.T) {
+	t.Run("status and headers", func(t *testing.T) {
+		body := newMockBody("12345")
+
+		req := NewRequestC(Config{
+			Client: ClientFunc(func(*http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Foo": {"bar"}},
+					Body:       body,
+				}, nil
+			}),
+			Reporter: newMockReporter(t),
+		}, "GET", "url")
+
+		req.WithoutBodyBuffering()
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		resp.Status(http.StatusOK)
+		resp.Header("Foo").IsEqual("bar")
+		resp.chain.assertNotFailed(t)
+
+		// body was not read
+		assert.Equal(t, 0, body.readCount)
+		assert.Equal(t, 0, body.closeCount)
+	})
+
+	t.Run("body consumed once", func(t *testing.T) {
+		body := newMockBody(`{"foo":123}`)
+
+		req := NewRequestC(Config{
+			Client: ClientFunc(func(*http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header: http.Header{
+						"Content-Type": {"application/json"},
+					},
+					Body: body,
+				}, nil
+			}),
+			Reporter: newMockReporter(t),
+		}, "GET", "url")
+
+		req.WithoutBodyBuffering()
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		resp.JSON().Object().HasValue("foo", 123)
+		resp.chain.assertNotFailed(t)
+		assert.Equal(t, 1, body.closeCount)
+
+		resp.Body()
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("failure message", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		req := NewRequestC(Config{
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+		}, "POST", "url")
+
+		req.WithText("12345")
+		req.WithoutBodyBuffering()
+
+		resp := req.Expect()
+
+		resp.Body().IsEqual("12345")
+		assert.Nil(t, handler.failure)
+
+		resp.Body()
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		assert.Contains(t, handler.failure.Errors[0].Error(), "already consumed")
+	})
+
+	t.Run("printers", func(t *testing.T) {
+		printer := &mockPrinter{}
+
+		req := NewRequestC(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+			Printers: []Printer{printer},
+		}, "POST", "url")
+
+		req.WithText("12345")
+		req.WithoutBodyBuffering()
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		// printer doesn't consume body
+		assert.Equal(t, "", string(printer.respBody))
+
+		resp.Body().IsEqual("12345")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("decompression", func(t *testing.T) {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, _ = gz.Write([]byte("12345"))
+		_ = gz.Close()
+
+		req := NewRequestC(Config{
+			Client: ClientFunc(func(*http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Encoding": {"gzip"}},
+					Body:       ioutil.NopCloser(&buf),
+				}, nil
+			}),
+			Reporter: newMockReporter(t),
+		}, "GET", "url")
+
+		req.WithoutBodyBuffering()
+
+		resp := req.Expect()
+		resp.WithDecompression()
+		resp.chain.assertNotFailed(t)
+
+		resp.Body().IsEqual("12345")
+		resp.chain.assertNotFailed(t)
+	})
+}
+
+func TestRequest_Retries(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	newNoErrClient := func(cb func(req *http.Request)) *mockClient {
+		return &mockClient{
+			resp: http.Response{
+				StatusCode: http.StatusOK,
+			},
+			cb: cb,
+		}
+	}
+
+	newTimeoutErrClient := func(cb func(req *http.Request)) *mockClient {
+		return &mockClient{
+			err: &mockNetError{
+				isTimeout: true,
+			},
+			cb: cb,
+		}
+	}
+
+	newServerErrClient := func(cb func(req *http.Request)) *mockClient {
+		return &mockClient{
+			resp: http.Response{
+				StatusCode: http.StatusInternalServerError,
+			},
+			cb: cb,
+		}
+	}
+
+	newHTTPErrClient := func(cb func(req *http.Request)) *mockClient {
+		return &mockClient{
+			resp: http.Response{
+				StatusCode: http.StatusBadRequest,
+			},
+			cb: cb,
+		}
+	}
+
+	noopSleepFn := func(time.Duration) <-chan time.Time {
+		return time.After(0)
+	}
+
+	t.Run("DontRetry", func(t *testing.T) {
+		t.Run("no error", func(t *testing.T) {
+			callCount := 0
+
+			client := newNoErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(DontRetry)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect()
+			resp.chain.assertNotFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+
+		t.Run("timeout error", func(t *testing.T) {
+			callCount := 0
+
+			client := newTimeoutErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(DontRetry).
+				WithMaxRetries(1)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect()
+			resp.chain.assertFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+
+		t.Run("server error", func(t *testing.T) {
+			callCount := 0
+
+			client := newServerErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(DontRetry).
+				WithMaxRetries(1)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusInternalServerError)
+			resp.chain.assertNotFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+
+		t.Run("http error", func(t *testing.T) {
+			callCount := 0
+
+			client := newHTTPErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(DontRetry).
+				WithMaxRetries(1)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusBadRequest)
+			resp.chain.assertNotFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+
+	})
+
+	t.Run("RetryTimeoutErrors", func(t *testing.T) {
+		t.Run("no error", func(t *testing.T) {
+			callCount := 0
+
+			client := newNoErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTimeoutErrors)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect()
+			resp.chain.assertNotFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+
+		t.Run("timeout error", func(t *testing.T) {
+			callCount := 0
+
+			client := newTimeoutErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTimeoutErrors).
+				WithMaxRetries(1).
+				WithRetryDelay(0, 0)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect()
+			resp.chain.assertFailed(t)
+
+			// Should retry
+			assert.Equal(t, 2, callCount)
+		})
+
+		t.Run("server error", func(t *testing.T) {
+			callCount := 0
+
+			client := newServerErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTimeoutErrors).
+				WithMaxRetries(1)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusInternalServerError)
+			resp.chain.assertNotFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+
+		t.Run("http error", func(t *testing.T) {
+			callCount := 0
+
+			client := newHTTPErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTimeoutErrors).
+				WithMaxRetries(1)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusBadRequest)
+			resp.chain.assertNotFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+	})
+
+	t.Run("RetryTimeoutAndServerErrors", func(t *testing.T) {
+		t.Run("no error", func(t *testing.T) {
+			callCount := 0
+
+			client := newNoErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTimeoutAndServerErrors)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect()
+			resp.chain.assertNotFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+
+		t.Run("timeout error", func(t *testing.T) {
+			callCount := 0
+
+			client := newTimeoutErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTimeoutAndServerErrors).
+				WithMaxRetries(1).
+				WithRetryDelay(0, 0)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect()
+			resp.chain.assertFailed(t)
+
+			// Should retry
+			assert.Equal(t, 2, callCount)
+		})
+
+		t.Run("server error", func(t *testing.T) {
+			callCount := 0
+
+			client := newServerErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTimeoutAndServerErrors).
+				WithMaxRetries(1).
+				WithRetryDelay(0, 0)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusInternalServerError)
+			resp.chain.assertNotFailed(t)
+
+			// Should retry
+			assert.Equal(t, 2, callCount)
+		})
+
+		t.Run("http error", func(t *testing.T) {
+			callCount := 0
+
+			client := newHTTPErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTimeoutAndServerErrors).
+				WithMaxRetries(1)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusBadRequest)
+			resp.chain.assertNotFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+	})
+
+	t.Run("RetryAllErrors", func(t *testing.T) {
+		t.Run("no error", func(t *testing.T) {
+			callCount := 0
+
+			client := newNoErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryAllErrors)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect()
+			resp.chain.assertNotFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+
+		t.Run("timeout error", func(t *testing.T) {
+			callCount := 0
+
+			client := newTimeoutErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryAllErrors).
+				WithMaxRetries(1).
+				WithRetryDelay(0, 0)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect()
+			resp.chain.assertFailed(t)
+
+			// Should retry
+			assert.Equal(t, 2, callCount)
+		})
+
+		t.Run("server error", func(t *testing.T) {
+			callCount := 0
+
+			client := newServerErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryAllErrors).
+				WithMaxRetries(1).
+				WithRetryDelay(0, 0)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusInternalServerError)
+			resp.chain.assertNotFailed(t)
+
+			// Should retry
+			assert.Equal(t, 2, callCount)
+		})
+
+		t.Run("http error", func(t *testing.T) {
+			callCount := 0
+
+			client := newHTTPErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryAllErrors).
+				WithMaxRetries(1).
+				WithRetryDelay(0, 0)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusBadRequest)
+			resp.chain.assertNotFailed(t)
+
+			// Should retry
+			assert.Equal(t, 2, callCount)
+		})
+	})
+
+	t.Run("max retries", func(t *testing.T) {
+		callCount := 0
+
+		client := newHTTPErrClient(func(req *http.Request) {
+			callCount++
+
+			b, err := ioutil.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, "test body", string(b))
+		})
+
+		config := Config{
+			Client:   client,
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodPost, "/url").
+			WithText("test body").
+			WithRetryPolicy(RetryAllErrors).
+			WithMaxRetries(3).
+			WithRetryDelay(0, 0)
+		req.sleepFn = noopSleepFn
+		req.chain.assertNotFailed(t)
+
+		resp := req.Expect().
+			Status(http.StatusBadRequest)
+		resp.chain.assertNotFailed(t)
+
+		// Should retry until max retries is reached
+		assert.Equal(t, 1+3, callCount)
+	})
+
+	t.Run("retry delay", func(t *testing.T) {
+		t.Run("not exceeded", func(t *testing.T) {
+			callCount := 0
+
+			client := newHTTPErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			var totalSleepTime time.Duration
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryAllErrors).
+				WithMaxRetries(3).
+				WithRetryDelay(100*time.Millisecond, 1000*time.Millisecond)
+			req.sleepFn = func(d time.Duration) <-chan time.Time {
+				totalSleepTime += d
+				return time.After(0)
+			}
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusBadRequest)
+			resp.chain.assertNotFailed(t)
+
+			// Should retry with delay
+			assert.Equal(t, int64(100+200+400), totalSleepTime.Milliseconds())
+		})
+
+		t.Run("exceeded", func(t *testing.T) {
+			callCount := 0
+
+			client := newHTTPErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
 				Reporter: reporter,
 			}
 
+			var totalSleepTime time.Duration
+
 			req := NewRequestC(config, http.MethodPost, "/url").
 				WithText("test body").
-				WithRetryPolicy(RetryTimeoutErrors)
-			req.sleepFn = noopSleepFn
+				WithRetryPolicy(RetryAllErrors).
+				WithMaxRetries(3).
+				WithRetryDelay(100*time.Millisecond, 300*time.Millisecond)
+			req.sleepFn = func(d time.Duration) <-chan time.Time {
+				totalSleepTime += d
+				return time.After(0)
+			}
+
 			req.chain.assertNotFailed(t)
 
-			resp := req.Expect()
+			resp := req.Expect().
+				Status(http.StatusBadRequest)
 			resp.chain.assertNotFailed(t)
 
-			// Should not retry
-			assert.Equal(t, 1, callCount)
+			// Should retry with delay
+			assert.Equal(t, int64(100+200+300), totalSleepTime.Milliseconds())
 		})
+	})
 
-		t.Run("timeout error", func(t *testing.T) {
+	t.Run("RetryPolicyFunc", func(t *testing.T) {
+		t.Run("retry until predicate is false", func(t *testing.T) {
 			callCount := 0
 
-			client := newTimeoutErrClient(func(req *http.Request) {
+			client := newServerErrClient(func(req *http.Request) {
 				callCount++
 
 				b, err := ioutil.ReadAll(req.Body)
@@ -2273,30 +5513,33 @@ func TestRequest_Retries(t *testing.T) {
 				Reporter: reporter,
 			}
 
+			var predicateCalls int
+
 			req := NewRequestC(config, http.MethodPost, "/url").
 				WithText("test body").
-				WithRetryPolicy(RetryTimeoutErrors).
-				WithMaxRetries(1).
-				WithRetryDelay(0, 0)
+				WithRetryPolicyFunc(func(resp *http.Response, err error) bool {
+					predicateCalls++
+					assert.NoError(t, err)
+					assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+					return predicateCalls < 2
+				}).
+				WithMaxRetries(5)
 			req.sleepFn = noopSleepFn
 			req.chain.assertNotFailed(t)
 
-			resp := req.Expect()
-			resp.chain.assertFailed(t)
+			resp := req.Expect().
+				Status(http.StatusInternalServerError)
+			resp.chain.assertNotFailed(t)
 
-			// Should retry
 			assert.Equal(t, 2, callCount)
+			assert.Equal(t, 2, predicateCalls)
 		})
 
-		t.Run("server error", func(t *testing.T) {
+		t.Run("max retries", func(t *testing.T) {
 			callCount := 0
 
-			client := newServerErrClient(func(req *http.Request) {
+			client := newHTTPErrClient(func(req *http.Request) {
 				callCount++
-
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
 			})
 
 			config := Config{
@@ -2305,551 +5548,929 @@ func TestRequest_Retries(t *testing.T) {
 			}
 
 			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(RetryTimeoutErrors).
-				WithMaxRetries(1)
+				WithRetryPolicyFunc(func(resp *http.Response, err error) bool {
+					return true
+				}).
+				WithMaxRetries(2)
 			req.sleepFn = noopSleepFn
 			req.chain.assertNotFailed(t)
 
 			resp := req.Expect().
-				Status(http.StatusInternalServerError)
+				Status(http.StatusBadRequest)
+			resp.chain.assertNotFailed(t)
+
+			assert.Equal(t, 3, callCount)
+		})
+
+		t.Run("overridden by policy", func(t *testing.T) {
+			callCount := 0
+
+			client := newHTTPErrClient(func(req *http.Request) {
+				callCount++
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithRetryPolicyFunc(func(resp *http.Response, err error) bool {
+					return true
+				}).
+				WithRetryPolicy(DontRetry).
+				WithMaxRetries(2)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect()
 			resp.chain.assertNotFailed(t)
 
-			// Should not retry
 			assert.Equal(t, 1, callCount)
 		})
 
-		t.Run("http error", func(t *testing.T) {
+		t.Run("nil func", func(t *testing.T) {
+			req := NewRequestC(Config{
+				Client:   &mockClient{},
+				Reporter: newMockReporter(t),
+			}, http.MethodGet, "/url")
+
+			req.WithRetryPolicyFunc(nil)
+			req.chain.assertFailed(t)
+		})
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		t.Run("limit", func(t *testing.T) {
+			callCount := 0
+
+			client := newHTTPErrClient(func(req *http.Request) {
+				callCount++
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithRetryPolicy(RetryAllErrors).
+				WithMaxAttempts(3)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusBadRequest)
+			resp.chain.assertNotFailed(t)
+
+			assert.Equal(t, 3, callCount)
+		})
+
+		t.Run("single attempt", func(t *testing.T) {
 			callCount := 0
 
-			client := newHTTPErrClient(func(req *http.Request) {
+			client := newHTTPErrClient(func(req *http.Request) {
+				callCount++
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithRetryPolicy(RetryAllErrors).
+				WithMaxRetries(5).
+				WithMaxAttempts(1)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			req.Expect().chain.assertNotFailed(t)
+
+			assert.Equal(t, 1, callCount)
+		})
+
+		t.Run("invalid argument", func(t *testing.T) {
+			handler := &mockAssertionHandler{}
+
+			req := NewRequestC(Config{
+				Client:           &mockClient{},
+				AssertionHandler: handler,
+			}, http.MethodGet, "/url")
+
+			req.WithMaxAttempts(0)
+			req.chain.assertFailed(t)
+
+			require.NotNil(t, handler.failure)
+			assert.Equal(t, AssertUsage, handler.failure.Type)
+		})
+	})
+
+	t.Run("attempt context", func(t *testing.T) {
+		for _, timeout := range []time.Duration{0, time.Minute} {
+			t.Run(fmt.Sprintf("timeout=%v", timeout), func(t *testing.T) {
+				var contexts []string
+
+				client := newHTTPErrClient(func(req *http.Request) {
+					contexts = append(contexts, fmt.Sprint(req.Context()))
+				})
+
+				config := Config{
+					Client:   client,
+					Reporter: reporter,
+				}
+
+				req := NewRequestC(config, http.MethodPost, "/url").
+					WithRetryPolicy(RetryAllErrors).
+					WithMaxAttempts(3).
+					WithTimeout(timeout).
+					WithTrace()
+				req.sleepFn = noopSleepFn
+
+				req.Expect().chain.assertNotFailed(t)
+
+				// Should not nest contexts of previous attempts
+				require.Equal(t, 3, len(contexts))
+				for _, ctx := range contexts {
+					assert.Equal(t, 1, strings.Count(ctx, "requestAttemptKey"))
+					assert.Equal(t, 1, strings.Count(ctx, "clientEventContextKey"))
+					assert.True(t, strings.Count(ctx, "WithDeadline") <= 1)
+				}
+			})
+		}
+	})
+
+	t.Run("retry backoff", func(t *testing.T) {
+		t.Run("custom delays", func(t *testing.T) {
+			client := newHTTPErrClient(nil)
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			var (
+				attempts []int
+				delays   []time.Duration
+			)
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithRetryPolicy(RetryAllErrors).
+				WithMaxAttempts(4).
+				WithRetryBackoff(func(attempt int) time.Duration {
+					attempts = append(attempts, attempt)
+					if attempt == 3 {
+						return -time.Second
+					}
+					return time.Duration(attempt) * 10 * time.Millisecond
+				})
+			req.sleepFn = func(d time.Duration) <-chan time.Time {
+				delays = append(delays, d)
+				return time.After(0)
+			}
+			req.chain.assertNotFailed(t)
+
+			req.Expect().chain.assertNotFailed(t)
+
+			assert.Equal(t, []int{1, 2, 3}, attempts)
+			assert.Equal(t,
+				[]time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 0},
+				delays)
+		})
+
+		t.Run("overridden by delay", func(t *testing.T) {
+			client := newHTTPErrClient(nil)
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			var delays []time.Duration
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithRetryPolicy(RetryAllErrors).
+				WithMaxAttempts(3).
+				WithRetryBackoff(func(attempt int) time.Duration {
+					return time.Hour
+				}).
+				WithRetryDelay(time.Millisecond, time.Second)
+			req.sleepFn = func(d time.Duration) <-chan time.Time {
+				delays = append(delays, d)
+				return time.After(0)
+			}
+			req.chain.assertNotFailed(t)
+
+			req.Expect().chain.assertNotFailed(t)
+
+			assert.Equal(t,
+				[]time.Duration{time.Millisecond, 2 * time.Millisecond},
+				delays)
+		})
+
+		t.Run("nil func", func(t *testing.T) {
+			req := NewRequestC(Config{
+				Client:   &mockClient{},
+				Reporter: newMockReporter(t),
+			}, http.MethodGet, "/url")
+
+			req.WithRetryBackoff(nil)
+			req.chain.assertFailed(t)
+		})
+	})
+
+	t.Run("attempt number", func(t *testing.T) {
+		var attempts []int
+
+		client := newServerErrClient(func(req *http.Request) {
+			attempts = append(attempts, RequestAttempt(req))
+		})
+
+		logger := newMockLogger(t)
+
+		config := Config{
+			Client:   client,
+			Reporter: reporter,
+			Printers: []Printer{NewCompactPrinter(logger)},
+		}
+
+		req := NewRequestC(config, http.MethodPost, "/url").
+			WithMaxAttempts(3).
+			WithTimeout(time.Minute)
+		req.sleepFn = noopSleepFn
+		req.chain.assertNotFailed(t)
+
+		req.Expect().chain.assertNotFailed(t)
+
+		assert.Equal(t, []int{1, 2, 3}, attempts)
+		assert.Equal(t, "POST /url (attempt 3)", logger.lastMessage)
+	})
+
+	t.Run("attempts in failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client:           newTimeoutErrClient(nil),
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, http.MethodPost, "/url").
+			WithMaxAttempts(3)
+		req.sleepFn = noopSleepFn
+		req.chain.assertNotFailed(t)
+
+		req.Expect().chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("request failed after 3 attempts"))
+	})
+
+	t.Run("cancelled retries", func(t *testing.T) {
+		callCount := 0
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		client := newHTTPErrClient(func(req *http.Request) {
+			callCount++
+
+			cancel() // Cancel during first attempt to trigger error
+
+			assert.Error(t, req.Context().Err(), context.Canceled.Error())
+
+			b, err := ioutil.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, "test body", string(b))
+		})
+
+		config := Config{
+			Client:   client,
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, http.MethodPost, "/url").
+			WithText("test body").
+			WithRetryPolicy(RetryAllErrors).
+			WithMaxRetries(1).
+			WithContext(ctx).
+			WithRetryDelay(1*time.Minute, 5*time.Minute)
+		req.chain.assertNotFailed(t)
+
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+
+		// Should not retry
+		assert.Equal(t, 1, callCount)
+	})
+}
+
+func TestRequest_Context(t *testing.T) {
+	t.Run("cancelled before send", func(t *testing.T) {
+		callCount := 0
+
+		client := &mockClient{
+			cb: func(req *http.Request) {
 				callCount++
+			},
+		}
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+		handler := &mockAssertionHandler{}
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+		config := Config{
+			Client:           client,
+			AssertionHandler: handler,
+		}
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(RetryTimeoutErrors).
-				WithMaxRetries(1)
-			req.sleepFn = noopSleepFn
-			req.chain.assertNotFailed(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
 
-			resp := req.Expect().
-				Status(http.StatusBadRequest)
-			resp.chain.assertNotFailed(t)
+		resp := NewRequestC(config, "GET", "/url").
+			WithContext(ctx).
+			Expect()
+		resp.chain.assertFailed(t)
 
-			// Should not retry
-			assert.Equal(t, 1, callCount)
-		})
+		assert.Equal(t, 0, callCount)
+
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, handler.failure.Errors, context.Canceled)
 	})
 
-	t.Run("RetryTimeoutAndServerErrors", func(t *testing.T) {
-		t.Run("no error", func(t *testing.T) {
-			callCount := 0
+	t.Run("expired before send", func(t *testing.T) {
+		callCount := 0
 
-			client := newNoErrClient(func(req *http.Request) {
+		client := &mockClient{
+			cb: func(req *http.Request) {
 				callCount++
+			},
+		}
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+		ctx, cancel := context.WithDeadline(context.Background(),
+			time.Now().Add(-time.Second))
+		defer cancel()
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(RetryTimeoutAndServerErrors)
-			req.sleepFn = noopSleepFn
-			req.chain.assertNotFailed(t)
+		resp := NewRequestC(config, "GET", "/url").
+			WithContext(ctx).
+			Expect()
+		resp.chain.assertFailed(t)
 
-			resp := req.Expect()
-			resp.chain.assertNotFailed(t)
+		assert.Equal(t, 0, callCount)
+	})
 
-			// Should not retry
-			assert.Equal(t, 1, callCount)
-		})
+	t.Run("earlier deadline wins", func(t *testing.T) {
+		cases := []struct {
+			name        string
+			ctxTimeout  time.Duration
+			reqTimeout  time.Duration
+			maxDeadline time.Duration
+		}{
+			{
+				name:        "context deadline is earlier",
+				ctxTimeout:  time.Minute,
+				reqTimeout:  time.Hour,
+				maxDeadline: time.Minute,
+			},
+			{
+				name:        "request timeout is earlier",
+				ctxTimeout:  time.Hour,
+				reqTimeout:  time.Minute,
+				maxDeadline: time.Minute,
+			},
+		}
 
-		t.Run("timeout error", func(t *testing.T) {
-			callCount := 0
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				var deadline time.Time
 
-			client := newTimeoutErrClient(func(req *http.Request) {
-				callCount++
+				client := &mockClient{
+					cb: func(req *http.Request) {
+						deadline, _ = req.Context().Deadline()
+					},
+				}
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
+				config := Config{
+					Client:   client,
+					Reporter: newMockReporter(t),
+				}
+
+				ctx, cancel := context.WithTimeout(context.Background(), tc.ctxTimeout)
+				defer cancel()
+
+				start := time.Now()
+
+				resp := NewRequestC(config, "GET", "/url").
+					WithContext(ctx).
+					WithTimeout(tc.reqTimeout).
+					Expect()
+				resp.chain.assertNotFailed(t)
+
+				require.False(t, deadline.IsZero())
+				assert.True(t, deadline.Before(start.Add(tc.maxDeadline+time.Second)))
 			})
+		}
+	})
+}
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+func TestRequest_Conflicts(t *testing.T) {
+	client := &mockClient{}
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(RetryTimeoutAndServerErrors).
-				WithMaxRetries(1).
-				WithRetryDelay(0, 0)
-			req.sleepFn = noopSleepFn
-			req.chain.assertNotFailed(t)
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
 
-			resp := req.Expect()
-			resp.chain.assertFailed(t)
+	t.Run("body conflict", func(t *testing.T) {
+		var req *Request
 
-			// Should retry
-			assert.Equal(t, 2, callCount)
-		})
+		req = NewRequestC(config, "GET", "url")
+		req.WithChunked(nil)
+		req.chain.assertNotFailed(t)
+		req.WithChunked(nil)
+		req.chain.assertFailed(t)
 
-		t.Run("server error", func(t *testing.T) {
-			callCount := 0
+		req = NewRequestC(config, "GET", "url")
+		req.WithChunked(nil)
+		req.chain.assertNotFailed(t)
+		req.WithBytes(nil)
+		req.chain.assertFailed(t)
 
-			client := newServerErrClient(func(req *http.Request) {
-				callCount++
+		req = NewRequestC(config, "GET", "url")
+		req.WithChunked(nil)
+		req.chain.assertNotFailed(t)
+		req.WithText("")
+		req.chain.assertFailed(t)
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+		req = NewRequestC(config, "GET", "url")
+		req.WithChunked(nil)
+		req.chain.assertNotFailed(t)
+		req.WithJSON(map[string]interface{}{"a": "b"})
+		req.chain.assertFailed(t)
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+		req = NewRequestC(config, "GET", "url")
+		req.WithChunked(nil)
+		req.chain.assertNotFailed(t)
+		req.WithForm(map[string]interface{}{"a": "b"})
+		req.Expect()
+		req.chain.assertFailed(t)
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(RetryTimeoutAndServerErrors).
-				WithMaxRetries(1).
-				WithRetryDelay(0, 0)
-			req.sleepFn = noopSleepFn
-			req.chain.assertNotFailed(t)
+		req = NewRequestC(config, "GET", "url")
+		req.WithChunked(nil)
+		req.chain.assertNotFailed(t)
+		req.WithFormField("a", "b")
+		req.Expect()
+		req.chain.assertFailed(t)
 
-			resp := req.Expect().
-				Status(http.StatusInternalServerError)
-			resp.chain.assertNotFailed(t)
+		req = NewRequestC(config, "GET", "url")
+		req.WithChunked(nil)
+		req.chain.assertNotFailed(t)
+		req.WithMultipart()
+		req.chain.assertFailed(t)
 
-			// Should retry
-			assert.Equal(t, 2, callCount)
-		})
+		req = NewRequestC(config, "GET", "url")
+		req.WithReader(strings.NewReader("a"), 1)
+		req.chain.assertNotFailed(t)
+		req.WithJSON(map[string]interface{}{"a": "b"})
+		req.chain.assertFailed(t)
 
-		t.Run("http error", func(t *testing.T) {
-			callCount := 0
+		req = NewRequestC(config, "GET", "url")
+		req.WithJSON(map[string]interface{}{"a": "b"})
+		req.chain.assertNotFailed(t)
+		req.WithReader(strings.NewReader("a"), 1)
+		req.chain.assertFailed(t)
 
-			client := newHTTPErrClient(func(req *http.Request) {
-				callCount++
+		req = NewRequestC(config, "GET", "url")
+		req.WithReader(strings.NewReader("a"), 1)
+		req.chain.assertNotFailed(t)
+		req.WithForm(map[string]interface{}{"a": "b"})
+		req.Expect()
+		req.chain.assertFailed(t)
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+		req = NewRequestC(config, "GET", "url")
+		req.WithFormField("a", "b")
+		req.chain.assertNotFailed(t)
+		req.WithReader(strings.NewReader("a"), 1)
+		req.Expect()
+		req.chain.assertFailed(t)
+	})
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+	t.Run("type conflict", func(t *testing.T) {
+		var req *Request
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(RetryTimeoutAndServerErrors).
-				WithMaxRetries(1)
-			req.sleepFn = noopSleepFn
-			req.chain.assertNotFailed(t)
+		req = NewRequestC(config, "GET", "url")
+		req.WithText("")
+		req.chain.assertNotFailed(t)
+		req.WithJSON(map[string]interface{}{"a": "b"})
+		req.chain.assertFailed(t)
 
-			resp := req.Expect().
-				Status(http.StatusBadRequest)
-			resp.chain.assertNotFailed(t)
+		req = NewRequestC(config, "GET", "url")
+		req.WithText("")
+		req.chain.assertNotFailed(t)
+		req.WithForm(map[string]interface{}{"a": "b"})
+		req.chain.assertFailed(t)
 
-			// Should not retry
-			assert.Equal(t, 1, callCount)
-		})
+		req = NewRequestC(config, "GET", "url")
+		req.WithText("")
+		req.chain.assertNotFailed(t)
+		req.WithFormField("a", "b")
+		req.chain.assertFailed(t)
+
+		req = NewRequestC(config, "GET", "url")
+		req.WithText("")
+		req.chain.assertNotFailed(t)
+		req.WithMultipart()
+		req.chain.assertFailed(t)
 	})
 
-	t.Run("RetryAllErrors", func(t *testing.T) {
-		t.Run("no error", func(t *testing.T) {
-			callCount := 0
+	t.Run("multipart conflict", func(t *testing.T) {
+		var req *Request
 
-			client := newNoErrClient(func(req *http.Request) {
-				callCount++
+		req = NewRequestC(config, "GET", "url")
+		req.WithForm(map[string]interface{}{"a": "b"})
+		req.chain.assertNotFailed(t)
+		req.WithMultipart()
+		req.chain.assertFailed(t)
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+		req = NewRequestC(config, "GET", "url")
+		req.WithFormField("a", "b")
+		req.chain.assertNotFailed(t)
+		req.WithMultipart()
+		req.chain.assertFailed(t)
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+		req = NewRequestC(config, "GET", "url")
+		req.WithFileBytes("a", "a", []byte("a"))
+		req.chain.assertFailed(t)
+	})
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(RetryAllErrors)
-			req.sleepFn = noopSleepFn
-			req.chain.assertNotFailed(t)
+	t.Run("query conflict", func(t *testing.T) {
+		var req *Request
 
-			resp := req.Expect()
-			resp.chain.assertNotFailed(t)
+		req = NewRequestC(config, "GET", "url")
+		req.WithQuery("a", "b")
+		req.chain.assertNotFailed(t)
+		req.WithRawQuery("a=b")
+		req.chain.assertFailed(t)
 
-			// Should not retry
-			assert.Equal(t, 1, callCount)
-		})
+		req = NewRequestC(config, "GET", "url")
+		req.WithQueryObject(map[string]interface{}{"a": "b"})
+		req.chain.assertNotFailed(t)
+		req.WithRawQuery("a=b")
+		req.chain.assertFailed(t)
 
-		t.Run("timeout error", func(t *testing.T) {
-			callCount := 0
+		req = NewRequestC(config, "GET", "url")
+		req.WithQueryString("a=b")
+		req.chain.assertNotFailed(t)
+		req.WithRawQuery("a=b")
+		req.chain.assertFailed(t)
 
-			client := newTimeoutErrClient(func(req *http.Request) {
-				callCount++
+		req = NewRequestC(config, "GET", "url")
+		req.WithRawQuery("a=b")
+		req.chain.assertNotFailed(t)
+		req.WithQuery("a", "b")
+		req.chain.assertFailed(t)
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+		req = NewRequestC(config, "GET", "url")
+		req.WithRawQuery("a=b")
+		req.chain.assertNotFailed(t)
+		req.WithQueryObject(map[string]interface{}{"a": "b"})
+		req.chain.assertFailed(t)
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+		req = NewRequestC(config, "GET", "url")
+		req.WithRawQuery("a=b")
+		req.chain.assertNotFailed(t)
+		req.WithQueryString("a=b")
+		req.chain.assertFailed(t)
+	})
+}
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(RetryAllErrors).
-				WithMaxRetries(1).
-				WithRetryDelay(0, 0)
-			req.sleepFn = noopSleepFn
-			req.chain.assertNotFailed(t)
+func TestRequest_Poll(t *testing.T) {
+	newStatusClient := func(callCount *int, statuses ...int) Client {
+		return ClientFunc(func(req *http.Request) (*http.Response, error) {
+			b, err := ioutil.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, "test body", string(b))
 
-			resp := req.Expect()
-			resp.chain.assertFailed(t)
+			status := statuses[len(statuses)-1]
+			if *callCount < len(statuses) {
+				status = statuses[*callCount]
+			}
+			*callCount++
 
-			// Should retry
-			assert.Equal(t, 2, callCount)
+			return &http.Response{
+				StatusCode: status,
+				Body:       ioutil.NopCloser(strings.NewReader(strconv.Itoa(status))),
+			}, nil
 		})
+	}
 
-		t.Run("server error", func(t *testing.T) {
-			callCount := 0
+	isOK := func(resp *Response) bool {
+		return resp.Raw().StatusCode == http.StatusOK
+	}
 
-			client := newServerErrClient(func(req *http.Request) {
-				callCount++
+	t.Run("condition met", func(t *testing.T) {
+		callCount := 0
+		matchCount := 0
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+		config := Config{
+			Client: newStatusClient(&callCount,
+				http.StatusNotFound, http.StatusAccepted, http.StatusOK),
+			Reporter: newMockReporter(t),
+		}
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+		req := NewRequestC(config, http.MethodPost, "/url").
+			WithText("test body").
+			WithMatcher(func(resp *Response) {
+				matchCount++
+			})
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(RetryAllErrors).
-				WithMaxRetries(1).
-				WithRetryDelay(0, 0)
-			req.sleepFn = noopSleepFn
-			req.chain.assertNotFailed(t)
+		resp := req.Poll(time.Millisecond, time.Minute, isOK)
 
-			resp := req.Expect().
-				Status(http.StatusInternalServerError)
-			resp.chain.assertNotFailed(t)
+		req.chain.assertNotFailed(t)
+		resp.chain.assertNotFailed(t)
 
-			// Should retry
-			assert.Equal(t, 2, callCount)
-		})
+		assert.Equal(t, 3, callCount)
+		assert.Equal(t, 1, matchCount)
 
-		t.Run("http error", func(t *testing.T) {
-			callCount := 0
+		resp.Status(http.StatusOK)
+		resp.Body().IsEqual("200")
+		resp.chain.assertNotFailed(t)
+	})
 
-			client := newHTTPErrClient(func(req *http.Request) {
-				callCount++
+	t.Run("condition met on first attempt", func(t *testing.T) {
+		callCount := 0
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+		config := Config{
+			Client:   newStatusClient(&callCount, http.StatusOK),
+			Reporter: newMockReporter(t),
+		}
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+		req := NewRequestC(config, http.MethodPost, "/url").
+			WithText("test body")
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(RetryAllErrors).
-				WithMaxRetries(1).
-				WithRetryDelay(0, 0)
-			req.sleepFn = noopSleepFn
-			req.chain.assertNotFailed(t)
+		resp := req.Poll(time.Minute, time.Minute, isOK)
 
-			resp := req.Expect().
-				Status(http.StatusBadRequest)
-			resp.chain.assertNotFailed(t)
+		req.chain.assertNotFailed(t)
+		resp.chain.assertNotFailed(t)
 
-			// Should retry
-			assert.Equal(t, 2, callCount)
-		})
+		assert.Equal(t, 1, callCount)
 	})
 
-	t.Run("max retries", func(t *testing.T) {
+	t.Run("assertions inside condition", func(t *testing.T) {
 		callCount := 0
 
-		client := newHTTPErrClient(func(req *http.Request) {
-			callCount++
-
-			b, err := ioutil.ReadAll(req.Body)
-			assert.NoError(t, err)
-			assert.Equal(t, "test body", string(b))
-		})
-
 		config := Config{
-			Client:   client,
-			Reporter: reporter,
+			Client: newStatusClient(&callCount,
+				http.StatusNotFound, http.StatusOK),
+			Reporter: newMockReporter(t),
 		}
 
 		req := NewRequestC(config, http.MethodPost, "/url").
-			WithText("test body").
-			WithRetryPolicy(RetryAllErrors).
-			WithMaxRetries(3).
-			WithRetryDelay(0, 0)
-		req.sleepFn = noopSleepFn
-		req.chain.assertNotFailed(t)
+			WithText("test body")
 
-		resp := req.Expect().
-			Status(http.StatusBadRequest)
+		resp := req.Poll(time.Millisecond, time.Minute, func(resp *Response) bool {
+			resp.Status(http.StatusOK)
+			return !resp.chain.failed()
+		})
+
+		req.chain.assertNotFailed(t)
 		resp.chain.assertNotFailed(t)
 
-		// Should retry until max retries is reached
-		assert.Equal(t, 1+3, callCount)
+		assert.Equal(t, 2, callCount)
 	})
 
-	t.Run("retry delay", func(t *testing.T) {
-		t.Run("not exceeded", func(t *testing.T) {
-			callCount := 0
+	t.Run("timeout", func(t *testing.T) {
+		callCount := 0
 
-			client := newHTTPErrClient(func(req *http.Request) {
-				callCount++
+		handler := &mockAssertionHandler{}
+		config := Config{
+			Client: newStatusClient(&callCount,
+				http.StatusServiceUnavailable),
+			AssertionHandler: handler,
+		}
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+		req := NewRequestC(config, http.MethodPost, "/url").
+			WithText("test body")
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+		resp := req.Poll(time.Millisecond, time.Millisecond*20, isOK)
 
-			var totalSleepTime time.Duration
+		req.chain.assertFailed(t)
+		resp.chain.assertFailed(t)
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(RetryAllErrors).
-				WithMaxRetries(3).
-				WithRetryDelay(100*time.Millisecond, 1000*time.Millisecond)
-			req.sleepFn = func(d time.Duration) <-chan time.Time {
-				totalSleepTime += d
-				return time.After(0)
-			}
-			req.chain.assertNotFailed(t)
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
 
-			resp := req.Expect().
-				Status(http.StatusBadRequest)
-			resp.chain.assertNotFailed(t)
+		assert.NotEqual(t, 0, callCount)
+		assert.Contains(t, handler.failure.Errors,
+			fmt.Errorf("made %d attempt(s), last response status: %s",
+				callCount, "503 Service Unavailable"))
+	})
 
-			// Should retry with delay
-			assert.Equal(t, int64(100+200+400), totalSleepTime.Milliseconds())
-		})
+	t.Run("send error", func(t *testing.T) {
+		callCount := 0
 
-		t.Run("exceeded", func(t *testing.T) {
-			callCount := 0
+		config := Config{
+			Client: &mockClient{
+				err: errors.New("test error"),
+				cb: func(req *http.Request) {
+					callCount++
+				},
+			},
+			Reporter: newMockReporter(t),
+		}
 
-			client := newHTTPErrClient(func(req *http.Request) {
-				callCount++
+		req := NewRequestC(config, http.MethodPost, "/url")
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			})
+		resp := req.Poll(time.Millisecond, time.Minute, isOK)
 
-			config := Config{
-				Client:   client,
-				Reporter: reporter,
-			}
+		req.chain.assertFailed(t)
+		resp.chain.assertFailed(t)
 
-			var totalSleepTime time.Duration
+		assert.Equal(t, 1, callCount)
+	})
 
-			req := NewRequestC(config, http.MethodPost, "/url").
-				WithText("test body").
-				WithRetryPolicy(RetryAllErrors).
-				WithMaxRetries(3).
-				WithRetryDelay(100*time.Millisecond, 300*time.Millisecond)
-			req.sleepFn = func(d time.Duration) <-chan time.Time {
-				totalSleepTime += d
-				return time.After(0)
-			}
+	t.Run("context canceled", func(t *testing.T) {
+		callCount := 0
 
-			req.chain.assertNotFailed(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 
-			resp := req.Expect().
-				Status(http.StatusBadRequest)
-			resp.chain.assertNotFailed(t)
+		config := Config{
+			Client:   newStatusClient(&callCount, http.StatusNotFound),
+			Reporter: newMockReporter(t),
+			Context:  ctx,
+		}
+
+		req := NewRequestC(config, http.MethodPost, "/url").
+			WithText("test body")
+
+		req.sleepFn = func(time.Duration) <-chan time.Time {
+			return nil
+		}
+
+		resp := req.Poll(time.Millisecond, time.Minute, func(*Response) bool {
+			cancel()
+			return false
+		})
+
+		req.chain.assertFailed(t)
+		resp.chain.assertFailed(t)
 
-			// Should retry with delay
-			assert.Equal(t, int64(100+200+300), totalSleepTime.Milliseconds())
-		})
+		assert.Equal(t, 1, callCount)
 	})
 
-	t.Run("cancelled retries", func(t *testing.T) {
+	t.Run("request context canceled", func(t *testing.T) {
 		callCount := 0
 
-		client := newHTTPErrClient(func(req *http.Request) {
-			callCount++
-
-			assert.Error(t, req.Context().Err(), context.Canceled.Error())
-
-			b, err := ioutil.ReadAll(req.Body)
-			assert.NoError(t, err)
-			assert.Equal(t, "test body", string(b))
-		})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 
+		handler := &mockAssertionHandler{}
 		config := Config{
-			Client:   client,
-			Reporter: reporter,
+			Client:           newStatusClient(&callCount, http.StatusNotFound),
+			AssertionHandler: handler,
 		}
 
-		ctx, cancel := context.WithCancel(context.Background())
-		cancel() // Cancel immediately to trigger error
-
 		req := NewRequestC(config, http.MethodPost, "/url").
 			WithText("test body").
-			WithRetryPolicy(RetryAllErrors).
-			WithMaxRetries(1).
-			WithContext(ctx).
-			WithRetryDelay(1*time.Minute, 5*time.Minute)
-		req.chain.assertNotFailed(t)
+			WithContext(ctx)
 
-		resp := req.Expect()
+		req.sleepFn = func(time.Duration) <-chan time.Time {
+			return nil
+		}
+
+		resp := req.Poll(time.Millisecond, time.Minute, func(*Response) bool {
+			cancel()
+			return false
+		})
+
+		req.chain.assertFailed(t)
 		resp.chain.assertFailed(t)
 
-		// Should not retry
 		assert.Equal(t, 1, callCount)
-	})
-}
-
-func TestRequest_Conflicts(t *testing.T) {
-	client := &mockClient{}
 
-	config := Config{
-		Client:   client,
-		Reporter: newMockReporter(t),
-	}
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, handler.failure.Errors, context.Canceled)
+	})
 
-	t.Run("body conflict", func(t *testing.T) {
-		var req *Request
+	t.Run("request timeout", func(t *testing.T) {
+		callCount := 0
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithChunked(nil)
-		req.chain.assertNotFailed(t)
-		req.WithChunked(nil)
-		req.chain.assertFailed(t)
+		client := newStatusClient(&callCount,
+			http.StatusNotFound, http.StatusNotFound, http.StatusOK)
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithChunked(nil)
-		req.chain.assertNotFailed(t)
-		req.WithBytes(nil)
-		req.chain.assertFailed(t)
+		config := Config{
+			Client: ClientFunc(func(req *http.Request) (*http.Response, error) {
+				if err := req.Context().Err(); err != nil {
+					return nil, err
+				}
+				return client.Do(req)
+			}),
+			Reporter: newMockReporter(t),
+		}
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithChunked(nil)
-		req.chain.assertNotFailed(t)
-		req.WithText("")
-		req.chain.assertFailed(t)
+		req := NewRequestC(config, http.MethodPost, "/url").
+			WithText("test body").
+			WithTimeout(time.Minute)
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithChunked(nil)
-		req.chain.assertNotFailed(t)
-		req.WithJSON(map[string]interface{}{"a": "b"})
-		req.chain.assertFailed(t)
+		req.sleepFn = func(time.Duration) <-chan time.Time {
+			return time.After(0)
+		}
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithChunked(nil)
-		req.chain.assertNotFailed(t)
-		req.WithForm(map[string]interface{}{"a": "b"})
-		req.Expect()
-		req.chain.assertFailed(t)
+		// Should not inherit canceled context of previous attempt
+		resp := req.Poll(time.Millisecond, time.Minute, isOK)
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithChunked(nil)
 		req.chain.assertNotFailed(t)
-		req.WithFormField("a", "b")
-		req.Expect()
-		req.chain.assertFailed(t)
+		resp.chain.assertNotFailed(t)
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithChunked(nil)
-		req.chain.assertNotFailed(t)
-		req.WithMultipart()
-		req.chain.assertFailed(t)
+		assert.Equal(t, 3, callCount)
 	})
 
-	t.Run("type conflict", func(t *testing.T) {
-		var req *Request
+	t.Run("usage", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			prepFunc func(req *Request)
+			interval time.Duration
+			timeout  time.Duration
+			until    func(*Response) bool
+		}{
+			{
+				name:     "zero interval",
+				interval: 0,
+				timeout:  time.Minute,
+				until:    isOK,
+			},
+			{
+				name:     "negative timeout",
+				interval: time.Millisecond,
+				timeout:  -time.Minute,
+				until:    isOK,
+			},
+			{
+				name:     "nil until",
+				interval: time.Millisecond,
+				timeout:  time.Minute,
+				until:    nil,
+			},
+			{
+				name: "websocket",
+				prepFunc: func(req *Request) {
+					req.WithWebsocketUpgrade()
+				},
+				interval: time.Millisecond,
+				timeout:  time.Minute,
+				until:    isOK,
+			},
+			{
+				name: "no body buffering",
+				prepFunc: func(req *Request) {
+					req.WithoutBodyBuffering()
+				},
+				interval: time.Millisecond,
+				timeout:  time.Minute,
+				until:    isOK,
+			},
+			{
+				name: "streamed body",
+				prepFunc: func(req *Request) {
+					req.WithReader(strings.NewReader("test body"), -1)
+				},
+				interval: time.Millisecond,
+				timeout:  time.Minute,
+				until:    isOK,
+			},
+		}
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithText("")
-		req.chain.assertNotFailed(t)
-		req.WithJSON(map[string]interface{}{"a": "b"})
-		req.chain.assertFailed(t)
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				callCount := 0
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithText("")
-		req.chain.assertNotFailed(t)
-		req.WithForm(map[string]interface{}{"a": "b"})
-		req.chain.assertFailed(t)
+				handler := &mockAssertionHandler{}
+				config := Config{
+					Client:           newStatusClient(&callCount, http.StatusOK),
+					AssertionHandler: handler,
+				}
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithText("")
-		req.chain.assertNotFailed(t)
-		req.WithFormField("a", "b")
-		req.chain.assertFailed(t)
+				req := NewRequestC(config, http.MethodPost, "/url")
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithText("")
-		req.chain.assertNotFailed(t)
-		req.WithMultipart()
-		req.chain.assertFailed(t)
-	})
+				if tc.prepFunc != nil {
+					tc.prepFunc(req)
+				}
+				req.chain.assertNotFailed(t)
 
-	t.Run("multipart conflict", func(t *testing.T) {
-		var req *Request
+				resp := req.Poll(tc.interval, tc.timeout, tc.until)
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithForm(map[string]interface{}{"a": "b"})
-		req.chain.assertNotFailed(t)
-		req.WithMultipart()
-		req.chain.assertFailed(t)
+				req.chain.assertFailed(t)
+				resp.chain.assertFailed(t)
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithFormField("a", "b")
-		req.chain.assertNotFailed(t)
-		req.WithMultipart()
-		req.chain.assertFailed(t)
+				require.NotNil(t, handler.failure)
+				assert.Equal(t, AssertUsage, handler.failure.Type)
 
-		req = NewRequestC(config, "GET", "url")
-		req.WithFileBytes("a", "a", []byte("a"))
-		req.chain.assertFailed(t)
+				assert.Equal(t, 0, callCount)
+			})
+		}
 	})
 }
 
@@ -2862,49 +6483,233 @@ func TestRequest_Usage(t *testing.T) {
 		expectFails bool
 	}{
 		{
-			name: "WithMatcher - nil argument",
+			name: "WithMatcher - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithMatcher(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithTransformer - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithTransformer(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithPathTimeLayout - empty layout",
+			prepFunc: func(req *Request) {
+				req.WithPathTimeLayout("")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithMethod - invalid method",
+			prepFunc: func(req *Request) {
+				req.WithMethod("BAD METHOD")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithFormField - no values",
+			prepFunc: func(req *Request) {
+				req.WithFormField("foo")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithMaxResponseSize - zero size",
+			prepFunc: func(req *Request) {
+				req.WithMaxResponseSize(0)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithMaxResponseSize - negative size",
+			prepFunc: func(req *Request) {
+				req.WithMaxResponseSize(-1)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithHeaderFn - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithHeaderFn("foo", nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithHeaderFn - empty name",
+			prepFunc: func(req *Request) {
+				req.WithHeaderFn("", func() string { return "" })
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithReader - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithReader(nil, 0)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithReader - negative length",
+			prepFunc: func(req *Request) {
+				req.WithReader(strings.NewReader("a"), -2)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithClient - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithClient(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithETagFrom - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithETagFrom(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithIfNoneMatchFrom - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithIfNoneMatchFrom(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithHandler - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithHandler(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithContext - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithContext(nil) //nolint
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithMaxRedirects - negative argument",
+			prepFunc: func(req *Request) {
+				req.WithMaxRedirects(-1)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithTLSClientCert - empty certificate",
 			prepFunc: func(req *Request) {
-				req.WithMatcher(nil)
+				req.WithTLSClientCert(tls.Certificate{})
 			},
 			prepFails:   true,
 			expectFails: true,
 		},
 		{
-			name: "WithTransformer - nil argument",
+			name: "WithTrailer - invalid name",
 			prepFunc: func(req *Request) {
-				req.WithTransformer(nil)
+				req.WithTrailer("foo bar", "baz")
 			},
 			prepFails:   true,
 			expectFails: true,
 		},
 		{
-			name: "WithClient - nil argument",
+			name: "WithTrailer - forbidden name",
 			prepFunc: func(req *Request) {
-				req.WithClient(nil)
+				req.WithTrailer("Content-Length", "1")
 			},
 			prepFails:   true,
 			expectFails: true,
 		},
 		{
-			name: "WithHandler - nil argument",
+			name: "WithTrailer - invalid value",
 			prepFunc: func(req *Request) {
-				req.WithHandler(nil)
+				req.WithTrailer("foo", "bar\nbaz")
 			},
 			prepFails:   true,
 			expectFails: true,
 		},
 		{
-			name: "WithContext - nil argument",
+			name: "WithTrailer - without body",
 			prepFunc: func(req *Request) {
-				req.WithContext(nil) //nolint
+				req.WithTrailer("foo", "bar")
+			},
+			prepFails:   false,
+			expectFails: true,
+		},
+		{
+			name: "WithRawQuery - fragment",
+			prepFunc: func(req *Request) {
+				req.WithRawQuery("a=1#b")
 			},
 			prepFails:   true,
 			expectFails: true,
 		},
 		{
-			name: "WithMaxRedirects - negative argument",
+			name: "WithRawQuery - space",
 			prepFunc: func(req *Request) {
-				req.WithMaxRedirects(-1)
+				req.WithRawQuery("a=1 2")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithRawQuery - control character",
+			prepFunc: func(req *Request) {
+				req.WithRawQuery("a=1\n")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithProxy - invalid URL",
+			prepFunc: func(req *Request) {
+				req.WithProxy("http://[::1")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithProxy - unsupported scheme",
+			prepFunc: func(req *Request) {
+				req.WithProxy("ftp://127.0.0.1:3128")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithProxy - missing host",
+			prepFunc: func(req *Request) {
+				req.WithProxy("http://")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithUnixSocket - empty path",
+			prepFunc: func(req *Request) {
+				req.WithUnixSocket("")
 			},
 			prepFails:   true,
 			expectFails: true,
@@ -2925,6 +6730,30 @@ func TestRequest_Usage(t *testing.T) {
 			prepFails:   true,
 			expectFails: true,
 		},
+		{
+			name: "WithWebsocketSubprotocols - empty list",
+			prepFunc: func(req *Request) {
+				req.WithWebsocketSubprotocols()
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithWebsocketSubprotocols - invalid subprotocol",
+			prepFunc: func(req *Request) {
+				req.WithWebsocketSubprotocols("foo", "bar baz")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithWebsocketSubprotocols - without upgrade",
+			prepFunc: func(req *Request) {
+				req.WithWebsocketSubprotocols("foo")
+			},
+			prepFails:   false,
+			expectFails: true,
+		},
 		{
 			name: "WithWebsocketDialer - nil argument",
 			prepFunc: func(req *Request) {
@@ -2957,6 +6786,22 @@ func TestRequest_Usage(t *testing.T) {
 			prepFails:   true,
 			expectFails: true,
 		},
+		{
+			name: "WithAbsoluteURL - invalid url",
+			prepFunc: func(req *Request) {
+				req.WithAbsoluteURL("%-invalid-url")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithAbsoluteURL - relative url",
+			prepFunc: func(req *Request) {
+				req.WithAbsoluteURL("/path")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
 		{
 			name: "WithFile - multiple readers",
 			prepFunc: func(req *Request) {
@@ -2965,6 +6810,33 @@ func TestRequest_Usage(t *testing.T) {
 			prepFails:   true,
 			expectFails: true,
 		},
+		{
+			name: "WithFileBytes - multiple content types",
+			prepFunc: func(req *Request) {
+				req.WithMultipart()
+				req.WithFileBytes("test-key", "test-path", nil, "text/plain", "text/html")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithFileFS - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithMultipart()
+				req.WithFileFS(nil, "test-key", "test-path")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithFileFS - missing file",
+			prepFunc: func(req *Request) {
+				req.WithMultipart()
+				req.WithFileFS(fstest.MapFS{}, "test-key", "test-path")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
 		// WithRedirectPolicy and WithMaxRedirects require Client
 		// to be http.Client, but we use another one
 		{
@@ -2976,6 +6848,15 @@ func TestRequest_Usage(t *testing.T) {
 			prepFails:   false,
 			expectFails: true,
 		},
+		{
+			name: "WithConnectionClose - websocket",
+			prepFunc: func(req *Request) {
+				req.WithWebsocketUpgrade()
+				req.WithConnectionClose()
+			},
+			prepFails:   false,
+			expectFails: true,
+		},
 		{
 			name:   "WithMaxRedirects - incompatible client",
 			client: &mockClient{},
@@ -2985,6 +6866,34 @@ func TestRequest_Usage(t *testing.T) {
 			prepFails:   false,
 			expectFails: true,
 		},
+		// WithProxy requires Client to be http.Client with http.Transport
+		{
+			name:   "WithProxy - incompatible client",
+			client: &mockClient{},
+			prepFunc: func(req *Request) {
+				req.WithProxy("http://127.0.0.1:3128")
+			},
+			prepFails:   false,
+			expectFails: true,
+		},
+		{
+			name:   "WithTLSClientCert - incompatible client",
+			client: &mockClient{},
+			prepFunc: func(req *Request) {
+				req.WithTLSClientCert(tls.Certificate{Certificate: [][]byte{{1}}})
+			},
+			prepFails:   false,
+			expectFails: true,
+		},
+		{
+			name:   "WithProxy - incompatible transport",
+			client: &http.Client{Transport: NewBinder(http.NotFoundHandler())},
+			prepFunc: func(req *Request) {
+				req.WithProxy("http://127.0.0.1:3128")
+			},
+			prepFails:   false,
+			expectFails: true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -3029,6 +6938,14 @@ func TestRequest_Order(t *testing.T) {
 				req.Expect()
 			},
 		},
+		{
+			name: "Poll after Expect",
+			afterFunc: func(req *Request) {
+				req.Poll(time.Millisecond, time.Second, func(*Response) bool {
+					return true
+				})
+			},
+		},
 		{
 			name: "WithName after Expect",
 			afterFunc: func(req *Request) {
@@ -3073,6 +6990,18 @@ func TestRequest_Order(t *testing.T) {
 				req.WithTimeout(3 * time.Second)
 			},
 		},
+		{
+			name: "WithTrace after Expect",
+			afterFunc: func(req *Request) {
+				req.WithTrace()
+			},
+		},
+		{
+			name: "WithRawHeaders after Expect",
+			afterFunc: func(req *Request) {
+				req.WithRawHeaders()
+			},
+		},
 		{
 			name: "WithRedirectPolicy after Expect",
 			afterFunc: func(req *Request) {
@@ -3085,12 +7014,62 @@ func TestRequest_Order(t *testing.T) {
 				req.WithMaxRedirects(3)
 			},
 		},
+		{
+			name: "WithTLSClientCert after Expect",
+			afterFunc: func(req *Request) {
+				req.WithTLSClientCert(tls.Certificate{Certificate: [][]byte{{1}}})
+			},
+		},
+		{
+			name: "WithTrailer after Expect",
+			afterFunc: func(req *Request) {
+				req.WithTrailer("foo", "bar")
+			},
+		},
+		{
+			name: "WithConnectionClose after Expect",
+			afterFunc: func(req *Request) {
+				req.WithConnectionClose()
+			},
+		},
+		{
+			name: "WithMaxAttempts after Expect",
+			afterFunc: func(req *Request) {
+				req.WithMaxAttempts(3)
+			},
+		},
+		{
+			name: "WithRetryBackoff after Expect",
+			afterFunc: func(req *Request) {
+				req.WithRetryBackoff(func(int) time.Duration { return 0 })
+			},
+		},
+		{
+			name: "WithUnixSocket after Expect",
+			afterFunc: func(req *Request) {
+				req.WithUnixSocket("/tmp/test.sock")
+			},
+		},
+		{
+			name: "WithProxy after Expect",
+			afterFunc: func(req *Request) {
+				req.WithProxy("http://127.0.0.1:3128")
+			},
+		},
 		{
 			name: "WithRetryPolicy after Expect",
 			afterFunc: func(req *Request) {
 				req.WithRetryPolicy(DontRetry)
 			},
 		},
+		{
+			name: "WithRetryPolicyFunc after Expect",
+			afterFunc: func(req *Request) {
+				req.WithRetryPolicyFunc(func(*http.Response, error) bool {
+					return false
+				})
+			},
+		},
 		{
 			name: "WithMaxRetries after Expect",
 			afterFunc: func(req *Request) {
@@ -3109,6 +7088,12 @@ func TestRequest_Order(t *testing.T) {
 				req.WithWebsocketUpgrade()
 			},
 		},
+		{
+			name: "WithWebsocketSubprotocols after Expect",
+			afterFunc: func(req *Request) {
+				req.WithWebsocketSubprotocols("foo")
+			},
+		},
 		{
 			name: "WithWebsocketDialer after Expect",
 			afterFunc: func(req *Request) {
@@ -3121,6 +7106,12 @@ func TestRequest_Order(t *testing.T) {
 				req.WithPath("repo", "repo1")
 			},
 		},
+		{
+			name: "WithPathTimeLayout after Expect",
+			afterFunc: func(req *Request) {
+				req.WithPathTimeLayout(time.RFC1123)
+			},
+		},
 		{
 			name: "WithPathObject after Expect",
 			afterFunc: func(req *Request) {
@@ -3149,12 +7140,30 @@ func TestRequest_Order(t *testing.T) {
 				req.WithQueryString("a=123&b=hello")
 			},
 		},
+		{
+			name: "WithRawQuery after Expect",
+			afterFunc: func(req *Request) {
+				req.WithRawQuery("a=123&b=hello")
+			},
+		},
 		{
 			name: "WithURL after Expect",
 			afterFunc: func(req *Request) {
 				req.WithURL("https://www.github.com")
 			},
 		},
+		{
+			name: "Clone after Expect",
+			afterFunc: func(req *Request) {
+				req.Clone()
+			},
+		},
+		{
+			name: "WithAbsoluteURL after Expect",
+			afterFunc: func(req *Request) {
+				req.WithAbsoluteURL("https://www.github.com")
+			},
+		},
 		{
 			name: "WithHeaders after Expect",
 			afterFunc: func(req *Request) {
@@ -3169,6 +7178,18 @@ func TestRequest_Order(t *testing.T) {
 				req.WithHeader("Content-Type", "application/json")
 			},
 		},
+		{
+			name: "WithMaxResponseSize after Expect",
+			afterFunc: func(req *Request) {
+				req.WithMaxResponseSize(1)
+			},
+		},
+		{
+			name: "WithHeaderFn after Expect",
+			afterFunc: func(req *Request) {
+				req.WithHeaderFn("foo", func() string { return "bar" })
+			},
+		},
 		{
 			name: "WithCookies after Expect",
 			afterFunc: func(req *Request) {
@@ -3183,12 +7204,54 @@ func TestRequest_Order(t *testing.T) {
 				req.WithCookie("key1", "val1")
 			},
 		},
+		{
+			name: "WithETagFrom after Expect",
+			afterFunc: func(req *Request) {
+				req.WithETagFrom(&Response{})
+			},
+		},
+		{
+			name: "WithIfNoneMatchFrom after Expect",
+			afterFunc: func(req *Request) {
+				req.WithIfNoneMatchFrom(&Response{})
+			},
+		},
 		{
 			name: "WithBasicAuth after Expect",
 			afterFunc: func(req *Request) {
 				req.WithBasicAuth("user", "pass")
 			},
 		},
+		{
+			name: "WithoutBasicAuth after Expect",
+			afterFunc: func(req *Request) {
+				req.WithoutBasicAuth()
+			},
+		},
+		{
+			name: "WithBearerToken after Expect",
+			afterFunc: func(req *Request) {
+				req.WithBearerToken("token")
+			},
+		},
+		{
+			name: "WithMethod after Expect",
+			afterFunc: func(req *Request) {
+				req.WithMethod("POST")
+			},
+		},
+		{
+			name: "WithDeadline after Expect",
+			afterFunc: func(req *Request) {
+				req.WithDeadline(time.Now())
+			},
+		},
+		{
+			name: "WithoutBodyBuffering after Expect",
+			afterFunc: func(req *Request) {
+				req.WithoutBodyBuffering()
+			},
+		},
 		{
 			name: "WithHost after Expect",
 			afterFunc: func(req *Request) {
@@ -3207,6 +7270,12 @@ func TestRequest_Order(t *testing.T) {
 				req.WithChunked(bytes.NewReader(nil))
 			},
 		},
+		{
+			name: "WithReader after Expect",
+			afterFunc: func(req *Request) {
+				req.WithReader(bytes.NewReader(nil), 0)
+			},
+		},
 		{
 			name: "WithBytes after Expect",
 			afterFunc: func(req *Request) {
@@ -3227,6 +7296,20 @@ func TestRequest_Order(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "WithYAML after Expect",
+			afterFunc: func(req *Request) {
+				req.WithYAML(map[string]string{
+					"key1": "val1",
+				})
+			},
+		},
+		{
+			name: "WithGzip after Expect",
+			afterFunc: func(req *Request) {
+				req.WithGzip()
+			},
+		},
 		{
 			name: "WithForm after Expect",
 			afterFunc: func(req *Request) {
@@ -3259,6 +7342,24 @@ func TestRequest_Order(t *testing.T) {
 				req.WithFileBytes("foo", "bar", []byte("baz"))
 			},
 		},
+		{
+			name: "WithFileFS after Expect",
+			beforeFunc: func(req *Request) {
+				req.WithMultipart()
+			},
+			afterFunc: func(req *Request) {
+				req.WithFileFS(fstest.MapFS{"bar": {Data: []byte("baz")}}, "foo", "bar")
+			},
+		},
+		{
+			name: "WithFileStream after Expect",
+			beforeFunc: func(req *Request) {
+				req.WithMultipart()
+			},
+			afterFunc: func(req *Request) {
+				req.WithFileStream("foo", "bar", strings.NewReader("baz"))
+			},
+		},
 		{
 			name: "WithMultipart after Expect",
 			afterFunc: func(req *Request) {
@@ -2,20 +2,28 @@ package httpexpect
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"mime"
 	"net/http"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ajg/form"
 	"github.com/gorilla/websocket"
+	"golang.org/x/text/encoding/htmlindex"
+	"gopkg.in/yaml.v2"
 )
 
 // Response provides methods to inspect attached http.Response object.
@@ -27,10 +35,22 @@ type Response struct {
 	httpResp  *http.Response
 	websocket *websocket.Conn
 	rtt       *time.Duration
+	trace     *TraceInfo
+
+	// response header with names as they were received, if recorded
+	rawHeader http.Header
 
 	content      []byte
 	contentState contentState
 
+	// if set, body is not buffered and can be read only once
+	noBuffering     bool
+	contentConsumed bool
+
+	bodyTransforms []func([]byte) []byte
+
+	charset string
+
 	cookies []*http.Cookie
 }
 
@@ -89,6 +109,10 @@ type responseOpts struct {
 	httpResp  *http.Response
 	websocket *websocket.Conn
 	rtt       []time.Duration
+	trace     *TraceInfo
+	rawHeader http.Header
+
+	noBuffering bool
 }
 
 func newResponse(opts responseOpts) *Response {
@@ -98,6 +122,7 @@ func newResponse(opts responseOpts) *Response {
 		config:       opts.config,
 		chain:        opts.chain.clone(),
 		contentState: contentPending,
+		noBuffering:  opts.noBuffering,
 	}
 
 	opChain := r.chain.enter("")
@@ -118,6 +143,9 @@ func newResponse(opts responseOpts) *Response {
 		r.rtt = &rttCopy
 	}
 
+	r.trace = opts.trace
+	r.rawHeader = opts.rawHeader
+
 	if opts.httpResp == nil {
 		opChain.fail(AssertionFailure{
 			Type:   AssertNotNil,
@@ -131,7 +159,7 @@ func newResponse(opts responseOpts) *Response {
 
 	r.httpResp = opts.httpResp
 
-	if r.httpResp.Body != nil && r.httpResp.Body != http.NoBody {
+	if r.httpResp.Body != nil && r.httpResp.Body != http.NoBody && !r.noBuffering {
 		if _, ok := r.httpResp.Body.(*bodyWrapper); !ok {
 			respCopy := *r.httpResp
 			r.httpResp = &respCopy
@@ -148,6 +176,67 @@ func newResponse(opts responseOpts) *Response {
 }
 
 func (r *Response) getContent(opChain *chain) ([]byte, bool) {
+	if r.noBuffering {
+		if r.contentConsumed {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("response body already consumed" +
+						" (body buffering is disabled)"),
+				},
+			})
+			return nil, false
+		}
+
+		content, ok := r.readTransformedContent(opChain)
+		if ok {
+			// don't keep unbuffered body in memory
+			r.contentConsumed = true
+			r.content = nil
+		}
+
+		return content, ok
+	}
+
+	return r.readTransformedContent(opChain)
+}
+
+func (r *Response) readTransformedContent(opChain *chain) ([]byte, bool) {
+	content, ok := r.readContent(opChain)
+	if !ok || len(r.bodyTransforms) == 0 {
+		return content, ok
+	}
+
+	transforms := r.bodyTransforms
+	r.bodyTransforms = nil
+
+	for _, fn := range transforms {
+		var err error
+
+		content, err = transformContent(fn, content)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("failed to transform response body"),
+					err,
+				},
+			})
+
+			r.content = nil
+			r.contentState = contentFailed
+
+			return nil, false
+		}
+	}
+
+	r.content = content
+	r.contentState = contentRetreived
+
+	return r.content, true
+}
+
+func (r *Response) readContent(opChain *chain) ([]byte, bool) {
 	switch r.contentState {
 	case contentRetreived:
 		return r.content, true
@@ -197,10 +286,38 @@ func (r *Response) getContent(opChain *chain) ([]byte, bool) {
 	return r.content, true
 }
 
-// Raw returns underlying http.Response object.
-// This is the value originally passed to NewResponse.
+// Raw returns a copy of underlying http.Response object.
+//
+// Returned response has its own copy of headers and trailers, so
+// modifying them doesn't affect assertions.
+//
+// Body of returned response is a fresh reader over the buffered body
+// contents. It can be read and closed independently of Body(), JSON(),
+// and other methods, and every call to Raw() returns a new reader.
+// If body buffering is disabled using Request.WithoutBodyBuffering,
+// Body is the original stream and can be read only once.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	b, _ := ioutil.ReadAll(resp.Raw().Body)
+//	resp.Body().IsEqual(string(b))
 func (r *Response) Raw() *http.Response {
-	return r.httpResp
+	if r.httpResp == nil {
+		return nil
+	}
+
+	respCopy := *r.httpResp
+	respCopy.Header = r.httpResp.Header.Clone()
+	respCopy.Trailer = r.httpResp.Trailer.Clone()
+
+	if bw, ok := r.httpResp.Body.(*bodyWrapper); ok {
+		if body, err := bw.GetBody(); err == nil {
+			respCopy.Body = body
+		}
+	}
+
+	return &respCopy
 }
 
 // Alias is similar to Value.Alias.
@@ -233,6 +350,91 @@ func (r *Response) RoundTripTime() *Duration {
 	return newDuration(opChain, r.rtt)
 }
 
+// WithinSLA succeeds if response round-trip time is less than given duration.
+//
+// It is a shorthand for RoundTripTime().Lt(sla), but returns Response, so it
+// can be chained with other response assertions. If round-trip time is not
+// available, method fails.
+//
+// Example:
+//
+//	resp := NewResponse(t, response, time.Duration(10000000))
+//	resp.WithinSLA(100 * time.Millisecond).Status(http.StatusOK)
+func (r *Response) WithinSLA(sla time.Duration) *Response {
+	opChain := r.chain.enter("WithinSLA()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if sla <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-positive argument: %v", sla),
+			},
+		})
+		return r
+	}
+
+	if r.rtt == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{r.rtt},
+			Errors: []error{
+				errors.New("expected: response round-trip time is present"),
+			},
+		})
+		return r
+	}
+
+	if !(*r.rtt < sla) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertLt,
+			Actual:   &AssertionValue{*r.rtt},
+			Expected: &AssertionValue{sla},
+			Errors: []error{
+				errors.New("expected: response round-trip time is within SLA"),
+				fmt.Errorf("round-trip time %v exceeds SLA of %v", *r.rtt, sla),
+			},
+		})
+	}
+
+	return r
+}
+
+// Trace returns a new Trace instance with timestamps of request phases.
+//
+// Trace is available only if Request.WithTrace() was called, otherwise
+// failure is reported.
+//
+// Example:
+//
+//	resp := e.GET("/path").WithTrace().Expect()
+//	resp.Trace().TLSHandshake().Lt(100 * time.Millisecond)
+//	resp.Trace().FirstByte().Lt(time.Second)
+func (r *Response) Trace() *Trace {
+	opChain := r.chain.enter("Trace()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newTrace(opChain, nil)
+	}
+
+	if r.trace == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("Trace() can be used only if Request.WithTrace() was called"),
+			},
+		})
+		return newTrace(opChain, nil)
+	}
+
+	return newTrace(opChain, r.trace)
+}
+
 // Deprecated: use RoundTripTime instead.
 func (r *Response) Duration() *Number {
 	opChain := r.chain.enter("Duration()")
@@ -333,6 +535,51 @@ func (r *Response) StatusRange(rn StatusRange) *Response {
 	return r
 }
 
+// StatusInRange succeeds if response status is in given range [min; max].
+//
+// Unlike StatusRange, which checks predefined status classes, StatusInRange
+// accepts arbitrary bounds. If min is greater than max, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.StatusInRange(200, 204)
+func (r *Response) StatusInRange(min, max int) *Response {
+	opChain := r.chain.enter("StatusInRange()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if min > max {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected status range: min %d is greater than max %d",
+					min, max),
+			},
+		})
+		return r
+	}
+
+	if r.httpResp.StatusCode < min || r.httpResp.StatusCode > max {
+		opChain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{statusCodeText(r.httpResp.StatusCode)},
+			Expected: &AssertionValue{AssertionRange{
+				Min: statusCodeText(min),
+				Max: statusCodeText(max),
+			}},
+			Errors: []error{
+				errors.New("expected: http status is within given range"),
+			},
+		})
+	}
+
+	return r
+}
+
 // StatusList succeeds if response matches with any given status code list
 //
 // Example:
@@ -451,8 +698,161 @@ func (r *Response) Header(header string) *String {
 	return newString(opChain, value)
 }
 
+// HeaderRaw returns a new String instance with given header field, looked up
+// using exact name casing.
+//
+// Unlike Header, which matches header names case-insensitively, HeaderRaw
+// succeeds only if response has header with exactly the given name. This is
+// useful to check what casing a server emitted, e.g. to reproduce interop
+// bugs with peers that treat header names case-sensitively.
+//
+// Note that http.Transport canonicalizes received header names, e.g.
+// "x-request-id" becomes "X-Request-Id". To check names as they were
+// received, use Request.WithRawHeaders, which records raw names.
+// Otherwise, names from http.Response.Header are used, so HeaderRaw can
+// check non-canonical casing only if Client preserves raw header names,
+// like Binder does for headers assigned directly to the header map by
+// handler, or a custom Client implementation.
+//
+// If response has no header with given exact name, failure is reported.
+//
+// Example:
+//
+//	resp := e.GET("/path").WithRawHeaders().Expect()
+//	resp.HeaderRaw("x-request-id").NotEmpty()
+func (r *Response) HeaderRaw(header string) *String {
+	opChain := r.chain.enter("HeaderRaw(%q)", header)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	respHeader := r.httpResp.Header
+	if r.rawHeader != nil {
+		respHeader = r.rawHeader
+	}
+
+	if values, ok := respHeader[header]; ok && len(values) != 0 {
+		return newString(opChain, values[0])
+	}
+
+	var names []string
+	for name := range respHeader {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := []error{
+		fmt.Errorf("expected: response has header with exact name %q", header),
+	}
+
+	var nameList []interface{}
+	for _, name := range names {
+		if name != header && strings.EqualFold(name, header) {
+			errs = append(errs,
+				fmt.Errorf("response has header %q with different casing", name))
+		}
+		nameList = append(nameList, name)
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:     AssertContainsKey,
+		Actual:   &AssertionValue{AssertionList(nameList)},
+		Expected: &AssertionValue{header},
+		Errors:   errs,
+	})
+
+	return newString(opChain, "")
+}
+
+// Trailer returns a new String instance with given trailer field.
+//
+// Trailers are received after response body, so Trailer reads the whole
+// body before looking up the trailer. If response doesn't have given
+// trailer, failure is reported.
+//
+// If body buffering is disabled (see Request.WithoutBodyBuffering), the
+// body read by Trailer is kept in memory until it's consumed by a method
+// that reads body (Body, JSON, etc.), so such methods can still be used
+// after Trailer.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Trailer("Grpc-Status").IsEqual("0")
+func (r *Response) Trailer(trailer string) *String {
+	opChain := r.chain.enter("Trailer(%q)", trailer)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	// if body was already consumed, it was read until the end and
+	// trailers are available; otherwise read body and keep it in memory
+	// even if buffering is disabled, so that it can be consumed later
+	if !r.contentConsumed {
+		if _, ok := r.readTransformedContent(opChain); !ok {
+			return newString(opChain, "")
+		}
+	}
+
+	values := r.httpResp.Trailer.Values(trailer)
+
+	if len(values) == 0 {
+		errs := []error{
+			fmt.Errorf("expected: response has trailer %q", trailer),
+		}
+		if len(r.httpResp.Trailer) == 0 {
+			errs = append(errs, errors.New("response has no trailers"))
+		}
+
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{r.httpResp.Trailer},
+			Expected: &AssertionValue{trailer},
+			Errors:   errs,
+		})
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, values[0])
+}
+
+// HeaderValues returns a new Array instance with all values of given header
+// field, in order they appear in response.
+//
+// Returned Array contains a String value for every header line with given
+// name. If header is absent, the Array is empty. Values are not split by
+// commas, so a single "Cache-Control: no-cache, no-store" line gives
+// a single element.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HeaderValues("Vary").ConsistsOf("Accept", "Accept-Encoding")
+func (r *Response) HeaderValues(header string) *Array {
+	opChain := r.chain.enter("HeaderValues(%q)", header)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	values := []interface{}{}
+	for _, v := range r.httpResp.Header.Values(header) {
+		values = append(values, v)
+	}
+
+	return newArray(opChain, values)
+}
+
 // Cookies returns a new Array instance with all cookie names set by this response.
-// Returned Array contains a String value for every cookie name.
+// Returned Array contains a String value for every cookie name. If response
+// sets several cookies with same name, the name is repeated.
+//
+// Use AllCookies to inspect cookies themselves.
 //
 // Note that this returns only cookies set by Set-Cookie headers of this response.
 // It doesn't return session cookies from previous responses, which may be stored
@@ -478,6 +878,46 @@ func (r *Response) Cookies() *Array {
 	return newArray(opChain, names)
 }
 
+// AllCookies returns a slice of Cookie instances, one for every cookie
+// set by this response, in order of Set-Cookie headers.
+//
+// Unlike Cookie, AllCookies doesn't collapse cookies with same name:
+// if response sets several cookies with same name, all of them are returned.
+//
+// Note that this returns only cookies set by Set-Cookie headers of this response.
+// It doesn't return session cookies from previous responses, which may be stored
+// in a cookie jar.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	cookies := resp.AllCookies()
+//	assert.Equal(t, 2, len(cookies))
+//	for _, cookie := range cookies {
+//		cookie.Path().IsEqual("/")
+//	}
+func (r *Response) AllCookies() []*Cookie {
+	opChain := r.chain.enter("AllCookies()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return []*Cookie{}
+	}
+
+	ret := []*Cookie{}
+
+	for index, c := range r.cookies {
+		func() {
+			cookieChain := opChain.replace("AllCookies[%d]", index)
+			defer cookieChain.leave()
+
+			ret = append(ret, newCookie(cookieChain, c))
+		}()
+	}
+
+	return ret
+}
+
 // Cookie returns a new Cookie instance with specified cookie from response.
 //
 // Note that this returns only cookies set by Set-Cookie headers of this response.
@@ -669,54 +1109,438 @@ func (r *Response) TransferEncoding(encoding ...string) *Response {
 	return r
 }
 
-// ContentOpts define parameters for matching the response content parameters.
-type ContentOpts struct {
-	// The media type Content-Type part, e.g. "application/json"
-	MediaType string
-	// The character set Content-Type part, e.g. "utf-8"
-	Charset string
-}
-
-// Text returns a new String instance with response body.
+// ContentLength returns a new Number instance with response content length.
 //
-// Text succeeds if response contains "text/plain" Content-Type header
-// with empty or "utf-8" charset.
+// Content length is taken from http.Response.ContentLength and doesn't
+// require reading body. If content length is unknown (e.g. response is
+// chunked), ContentLength fails.
 //
 // Example:
 //
 //	resp := NewResponse(t, response)
-//	resp.Text().IsEqual("hello, world!")
-//	resp.Text(ContentOpts{
-//	  MediaType: "text/plain",
-//	}).IsEqual("hello, world!")
-func (r *Response) Text(options ...ContentOpts) *String {
-	opChain := r.chain.enter("Text()")
+//	resp.ContentLength().IsEqual(100)
+func (r *Response) ContentLength() *Number {
+	opChain := r.chain.enter("ContentLength()")
 	defer opChain.leave()
 
 	if opChain.failed() {
-		return newString(opChain, "")
+		return newNumber(opChain, 0)
 	}
 
-	if len(options) > 1 {
-		opChain.fail(AssertionFailure{
-			Type: AssertUsage,
-			Errors: []error{
-				errors.New("unexpected multiple options arguments"),
-			},
-		})
-		return newString(opChain, "")
+	if !r.checkContentLength(opChain) {
+		return newNumber(opChain, 0)
 	}
 
-	if !r.checkContentOptions(opChain, options, "text/plain") {
-		return newString(opChain, "")
-	}
+	return newNumber(opChain, float64(r.httpResp.ContentLength))
+}
 
-	content, ok := r.getContent(opChain)
-	if !ok {
-		return newString(opChain, "")
+// HasContentLength succeeds if response has known content length equal
+// to given value.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HasContentLength(100)
+func (r *Response) HasContentLength(length int64) *Response {
+	opChain := r.chain.enter("HasContentLength()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
 	}
 
-	return newString(opChain, string(content))
+	if !r.checkContentLength(opChain) {
+		return r
+	}
+
+	if r.httpResp.ContentLength != length {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{r.httpResp.ContentLength},
+			Expected: &AssertionValue{length},
+			Errors: []error{
+				errors.New("expected: content length is equal to given value"),
+			},
+		})
+	}
+
+	return r
+}
+
+// WithDecompression decodes response body according to Content-Encoding header.
+//
+// Supported encodings are "gzip" and "deflate". If Content-Encoding header is
+// empty or "identity", WithDecompression does nothing. After successful
+// decoding, Body(), Text(), JSON() and other methods will see decoded body,
+// and Content-Encoding header is removed from response headers.
+//
+// WithDecompression fails if encoding is not supported, or if body can't
+// be decoded, e.g. when header claims gzip but body is not compressed.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.WithDecompression().JSON().Object().HasValue("foo", 123)
+func (r *Response) WithDecompression() *Response {
+	opChain := r.chain.enter("WithDecompression()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	encoding := r.httpResp.Header.Get("Content-Encoding")
+
+	var newReader func(io.Reader) (io.ReadCloser, error)
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return r
+
+	case "gzip", "x-gzip":
+		newReader = func(rd io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(rd)
+		}
+
+	case "deflate":
+		newReader = zlib.NewReader
+
+	default:
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{encoding},
+			Errors: []error{
+				errors.New("expected: supported \"Content-Encoding\" header"),
+			},
+		})
+		return r
+	}
+
+	content, ok := r.getContent(opChain)
+	if !ok {
+		return r
+	}
+
+	decoded, err := decompress(newReader, content)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{encoding},
+			Errors: []error{
+				fmt.Errorf("failed to decode %q response body", encoding),
+				err,
+			},
+		})
+
+		r.content = nil
+		r.contentState = contentFailed
+
+		return r
+	}
+
+	respCopy := *r.httpResp
+	r.httpResp = &respCopy
+	r.httpResp.Header = r.httpResp.Header.Clone()
+	r.httpResp.Header.Del("Content-Encoding")
+	r.httpResp.Header.Del("Content-Length")
+	r.httpResp.ContentLength = int64(len(decoded))
+	r.httpResp.Uncompressed = true
+	r.httpResp.Body = newBodyWrapper(
+		ioutil.NopCloser(bytes.NewReader(decoded)), nil)
+
+	r.content = decoded
+	r.contentState = contentRetreived
+	r.contentConsumed = false
+
+	return r
+}
+
+func decompress(
+	newReader func(io.Reader) (io.ReadCloser, error), content []byte,
+) ([]byte, error) {
+	rd, err := newReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := ioutil.ReadAll(rd)
+
+	closeErr := rd.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decoded, nil
+}
+
+// Proto returns a new String instance with response protocol,
+// e.g. "HTTP/1.1" or "HTTP/2.0".
+//
+// If response has no protocol set (e.g. it's a mock), returned string
+// is empty.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Proto().IsEqual("HTTP/2.0")
+func (r *Response) Proto() *String {
+	opChain := r.chain.enter("Proto()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, r.httpResp.Proto)
+}
+
+// HTTPVersion succeeds if response protocol has given major and minor version.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HTTPVersion(2, 0)
+func (r *Response) HTTPVersion(major, minor int) *Response {
+	opChain := r.chain.enter("HTTPVersion()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if r.httpResp.ProtoMajor != major || r.httpResp.ProtoMinor != minor {
+		opChain.fail(AssertionFailure{
+			Type: AssertEqual,
+			Actual: &AssertionValue{
+				fmt.Sprintf("HTTP/%d.%d", r.httpResp.ProtoMajor, r.httpResp.ProtoMinor),
+			},
+			Expected: &AssertionValue{
+				fmt.Sprintf("HTTP/%d.%d", major, minor),
+			},
+			Errors: []error{
+				errors.New("expected: http version is equal to given one"),
+			},
+		})
+	}
+
+	return r
+}
+
+// WithBodyTransform registers a function that rewrites response body before
+// it is inspected.
+//
+// The function is applied lazily, when body is first needed by Body(),
+// Text(), JSON(), and other methods. It is invoked exactly once, and
+// its result is cached. Multiple transforms are applied in the order in
+// which they were registered. Raw() still returns original body.
+//
+// If the function panics or returns nil, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.WithBodyTransform(func(b []byte) []byte {
+//		return bytes.TrimPrefix(b, []byte(")]}',"))
+//	}).JSON().Object().HasValue("foo", 123)
+func (r *Response) WithBodyTransform(fn func([]byte) []byte) *Response {
+	opChain := r.chain.enter("WithBodyTransform()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.bodyTransforms = append(r.bodyTransforms, fn)
+
+	return r
+}
+
+// WithCharset overrides charset used by Text() to decode response body.
+//
+// By default, Text() uses charset from Content-Type header. WithCharset
+// can be used when server sends body in a charset different from the one
+// it advertises. Charset names are the same as in Content-Type header,
+// e.g. "iso-8859-1" or "windows-1251".
+//
+// If charset is not supported, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.WithCharset("iso-8859-1").Text().IsEqual("café")
+func (r *Response) WithCharset(charset string) *Response {
+	opChain := r.chain.enter("WithCharset()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if charset == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty charset argument"),
+			},
+		})
+		return r
+	}
+
+	if !isUTF8Charset(charset) {
+		if _, err := htmlindex.Get(charset); err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("unsupported charset %q", charset),
+					err,
+				},
+			})
+			return r
+		}
+	}
+
+	r.charset = charset
+
+	return r
+}
+
+// ContentOpts define parameters for matching the response content parameters.
+type ContentOpts struct {
+	// The media type Content-Type part, e.g. "application/json"
+	MediaType string
+	// The character set Content-Type part, e.g. "utf-8"
+	Charset string
+}
+
+// Text returns a new String instance with response body.
+//
+// Text succeeds if response contains "text/plain" Content-Type header.
+//
+// If Content-Type header specifies charset other than "utf-8" or "us-ascii",
+// response body is decoded from that charset to UTF-8. Charset from header
+// can be overridden using WithCharset. If charset is not supported, failure
+// is reported.
+//
+// If Charset is set in ContentOpts, Text also checks that Content-Type
+// header contains that charset.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Text().IsEqual("hello, world!")
+//	resp.Text(ContentOpts{
+//	  MediaType: "text/plain",
+//	}).IsEqual("hello, world!")
+func (r *Response) Text(options ...ContentOpts) *String {
+	opChain := r.chain.enter("Text()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	text, ok := r.getText(opChain, options...)
+	if !ok {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, text)
+}
+
+func (r *Response) getText(opChain *chain, options ...ContentOpts) (string, bool) {
+	var params map[string]string
+
+	if len(options) != 0 && options[0].Charset != "" {
+		if !r.checkContentOptions(opChain, options, "text/plain") {
+			return "", false
+		}
+		params = map[string]string{"charset": options[0].Charset}
+	} else {
+		expectedType := "text/plain"
+		if len(options) != 0 && options[0].MediaType != "" {
+			expectedType = options[0].MediaType
+		}
+
+		var ok bool
+		if params, ok = r.checkMediaType(opChain, expectedType); !ok {
+			return "", false
+		}
+	}
+
+	charset := params["charset"]
+	if r.charset != "" {
+		charset = r.charset
+	}
+
+	content, ok := r.getContent(opChain)
+	if !ok {
+		return "", false
+	}
+
+	if isUTF8Charset(charset) {
+		return string(content), true
+	}
+
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{charset},
+			Errors: []error{
+				fmt.Errorf(`unsupported charset %q in "Content-Type" response header`,
+					charset),
+				err,
+			},
+		})
+		return "", false
+	}
+
+	decoded, err := encoding.NewDecoder().Bytes(content)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{charset},
+			Errors: []error{
+				fmt.Errorf("failed to decode response body from charset %q", charset),
+				err,
+			},
+		})
+		return "", false
+	}
+
+	return string(decoded), true
+}
+
+// ASCII-compatible charsets that don't require decoding
+func isUTF8Charset(charset string) bool {
+	return charset == "" ||
+		strings.EqualFold(charset, "utf-8") ||
+		strings.EqualFold(charset, "utf8") ||
+		strings.EqualFold(charset, "us-ascii")
 }
 
 // Form returns a new Object instance with form decoded from response body.
@@ -852,6 +1676,280 @@ func (r *Response) getJSON(opChain *chain, options ...ContentOpts) interface{} {
 	return value
 }
 
+// YAML returns a new Value instance with YAML decoded from response body.
+//
+// YAML succeeds if response contains "application/yaml", "application/x-yaml",
+// "text/yaml", or "text/x-yaml" Content-Type header with empty or "utf-8"
+// charset and if YAML may be decoded from response body.
+//
+// Decoded value is converted to canonical form, like values decoded from JSON:
+// mappings become map[string]interface{} and numbers become float64.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.YAML().Array().ConsistsOf("foo", "bar")
+//	resp.YAML(ContentOpts{
+//	  MediaType: "application/yaml",
+//	}).Array().ConsistsOf("foo", "bar")
+func (r *Response) YAML(options ...ContentOpts) *Value {
+	opChain := r.chain.enter("YAML()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	value := r.getYAML(opChain, options...)
+
+	return newValue(opChain, value)
+}
+
+var yamlMediaTypes = []string{
+	"application/yaml",
+	"application/x-yaml",
+	"text/yaml",
+	"text/x-yaml",
+}
+
+func (r *Response) getYAML(opChain *chain, options ...ContentOpts) interface{} {
+	expectedType := yamlMediaTypes[0]
+
+	mediaType, _, err := mime.ParseMediaType(r.httpResp.Header.Get("Content-Type"))
+	if err == nil {
+		for _, t := range yamlMediaTypes {
+			if mediaType == t {
+				expectedType = t
+				break
+			}
+		}
+	}
+
+	if !r.checkContentOptions(opChain, options, expectedType) {
+		return nil
+	}
+
+	content, ok := r.getContent(opChain)
+	if !ok {
+		return nil
+	}
+
+	var value interface{}
+
+	if err := yaml.Unmarshal(content, &value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(content),
+			},
+			Errors: []error{
+				errors.New("failed to decode yaml"),
+				err,
+			},
+		})
+		return nil
+	}
+
+	return yamlToCanon(value)
+}
+
+// yaml decodes mappings into map[interface{}]interface{}, which can't be
+// converted to canonical form directly; replace them with string-keyed maps
+func yamlToCanon(in interface{}) interface{} {
+	switch v := in.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			out[fmt.Sprint(key)] = yamlToCanon(val)
+		}
+		return out
+
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = yamlToCanon(val)
+		}
+		return out
+
+	default:
+		return v
+	}
+}
+
+// CSVOpts define parameters for decoding CSV response body.
+type CSVOpts struct {
+	// The media type Content-Type part, e.g. "text/csv"
+	MediaType string
+	// The character set Content-Type part, e.g. "utf-8"
+	Charset string
+	// Field delimiter, comma by default
+	Delimiter rune
+	// Whether first row is a header with column names
+	Header bool
+}
+
+// CSV returns a new Array instance with CSV decoded from response body.
+//
+// CSV succeeds if response contains "text/csv" Content-Type header and
+// if CSV may be decoded from response body. Body is decoded from charset
+// specified in Content-Type header, like in Text().
+//
+// By default, every row is represented as an array of strings. If Header
+// is set in CSVOpts, first row is used as a list of column names, and every
+// other row is represented as an object with column names as keys.
+//
+// All rows should have the same number of fields, otherwise failure is
+// reported with the number of offending line.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.CSV().Value(0).Array().ConsistsOf("foo", "bar")
+//	resp.CSV(CSVOpts{
+//	  Delimiter: ';',
+//	  Header:    true,
+//	}).Value(0).Object().HasValue("name", "foo")
+func (r *Response) CSV(options ...CSVOpts) *Array {
+	opChain := r.chain.enter("CSV()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	var opts CSVOpts
+	if len(options) != 0 {
+		opts = options[0]
+	}
+
+	if opts.Delimiter != 0 && !isValidCSVDelimiter(opts.Delimiter) {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("invalid CSV delimiter %q", opts.Delimiter),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	rows := r.getCSV(opChain, opts)
+
+	return newArray(opChain, rows)
+}
+
+func (r *Response) getCSV(opChain *chain, opts CSVOpts) []interface{} {
+	contentOpts := ContentOpts{
+		MediaType: "text/csv",
+		Charset:   opts.Charset,
+	}
+	if opts.MediaType != "" {
+		contentOpts.MediaType = opts.MediaType
+	}
+
+	text, ok := r.getText(opChain, contentOpts)
+	if !ok {
+		return nil
+	}
+
+	reader := csv.NewReader(strings.NewReader(text))
+	if opts.Delimiter != 0 {
+		reader.Comma = opts.Delimiter
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			err = fmt.Errorf("malformed CSV at line %d: %w", parseErr.Line, parseErr.Err)
+		}
+
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				text,
+			},
+			Errors: []error{
+				errors.New("failed to decode csv"),
+				err,
+			},
+		})
+		return nil
+	}
+
+	rows := []interface{}{}
+
+	if !opts.Header {
+		for _, record := range records {
+			row := make([]interface{}, len(record))
+			for i, field := range record {
+				row[i] = field
+			}
+			rows = append(rows, row)
+		}
+
+		return rows
+	}
+
+	if len(records) == 0 {
+		return rows
+	}
+
+	header := records[0]
+
+	for i, name := range header {
+		for _, prev := range header[:i] {
+			if prev == name {
+				opChain.fail(AssertionFailure{
+					Type: AssertValid,
+					Actual: &AssertionValue{
+						header,
+					},
+					Errors: []error{
+						errors.New("failed to decode csv"),
+						fmt.Errorf("duplicate column %q in CSV header", name),
+					},
+				})
+				return nil
+			}
+		}
+	}
+
+	for _, record := range records[1:] {
+		row := make(map[string]interface{}, len(record))
+		for i, field := range record {
+			row[header[i]] = field
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func isValidCSVDelimiter(r rune) bool {
+	return r != '"' && r != '\r' && r != '\n' &&
+		utf8.ValidRune(r) && r != utf8.RuneError
+}
+
 // JSONP returns a new Value instance with JSONP decoded from response body.
 //
 // JSONP succeeds if response contains "application/javascript" Content-Type
@@ -863,7 +1961,14 @@ func (r *Response) getJSON(opChain *chain, options ...ContentOpts) interface{} {
 //
 //	callback(<valid json>)
 //
-// Whitespaces are allowed.
+// Whitespaces and line breaks are allowed.
+//
+// Since JSONP endpoints often use legacy media types, "text/javascript"
+// and "application/x-javascript" are accepted as well, unless media type
+// is explicitly specified in options.
+//
+// If callback name in the body doesn't match given callback, or body is
+// not a valid JSONP, failure is reported.
 //
 // Example:
 //
@@ -896,13 +2001,22 @@ func (r *Response) JSONP(callback string, options ...ContentOpts) *Value {
 }
 
 var (
-	jsonp = regexp.MustCompile(`^\s*([^\s(]+)\s*\((.*)\)\s*;*\s*$`)
+	jsonp = regexp.MustCompile(`(?s)^\s*([^\s(]+)\s*\((.*)\)\s*;*\s*$`)
 )
 
 func (r *Response) getJSONP(
 	opChain *chain, callback string, options ...ContentOpts,
 ) interface{} {
-	if !r.checkContentOptions(opChain, options, "application/javascript") {
+	expectedType := "application/javascript"
+
+	if len(options) == 0 || options[0].MediaType == "" {
+		mediaType, _, err := mime.ParseMediaType(r.httpResp.Header.Get("Content-Type"))
+		if err == nil && isJavaScriptMediaType(mediaType) {
+			expectedType = mediaType
+		}
+	}
+
+	if !r.checkContentOptions(opChain, options, expectedType) {
 		return nil
 	}
 
@@ -913,7 +2027,7 @@ func (r *Response) getJSONP(
 
 	m := jsonp.FindSubmatch(content)
 
-	if len(m) != 3 || string(m[1]) != callback {
+	if len(m) != 3 {
 		opChain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{
@@ -927,6 +2041,21 @@ func (r *Response) getJSONP(
 		return nil
 	}
 
+	if string(m[1]) != callback {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(content),
+			},
+			Errors: []error{
+				fmt.Errorf(`expected: JSONP body in form of "%s(<valid json>)"`,
+					callback),
+				fmt.Errorf("unexpected JSONP callback name %q", m[1]),
+			},
+		})
+		return nil
+	}
+
 	var value interface{}
 
 	if err := json.Unmarshal(m[2], &value); err != nil {
@@ -946,6 +2075,14 @@ func (r *Response) getJSONP(
 	return value
 }
 
+func isJavaScriptMediaType(mediaType string) bool {
+	switch mediaType {
+	case "application/javascript", "text/javascript", "application/x-javascript":
+		return true
+	}
+	return false
+}
+
 func (r *Response) checkContentOptions(
 	opChain *chain, options []ContentOpts, expectedType string, expectedCharset ...string,
 ) bool {
@@ -971,28 +2108,8 @@ func (r *Response) checkContentType(
 		}
 	}
 
-	mediaType, params, err := mime.ParseMediaType(contentType)
-	if err != nil {
-		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{contentType},
-			Errors: []error{
-				errors.New(`invalid "Content-Type" response header`),
-				err,
-			},
-		})
-		return false
-	}
-
-	if mediaType != expectedType {
-		opChain.fail(AssertionFailure{
-			Type:     AssertEqual,
-			Actual:   &AssertionValue{mediaType},
-			Expected: &AssertionValue{expectedType},
-			Errors: []error{
-				errors.New(`unexpected media type in "Content-Type" response header`),
-			},
-		})
+	params, ok := r.checkMediaType(opChain, expectedType)
+	if !ok {
 		return false
 	}
 
@@ -1027,6 +2144,54 @@ func (r *Response) checkContentType(
 	return true
 }
 
+func (r *Response) checkMediaType(
+	opChain *chain, expectedType string,
+) (map[string]string, bool) {
+	contentType := r.httpResp.Header.Get("Content-Type")
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{contentType},
+			Errors: []error{
+				errors.New(`invalid "Content-Type" response header`),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	if mediaType != expectedType {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{mediaType},
+			Expected: &AssertionValue{expectedType},
+			Errors: []error{
+				errors.New(`unexpected media type in "Content-Type" response header`),
+			},
+		})
+		return nil, false
+	}
+
+	return params, true
+}
+
+func (r *Response) checkContentLength(opChain *chain) bool {
+	if r.httpResp.ContentLength < 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{r.httpResp.ContentLength},
+			Errors: []error{
+				errors.New("expected: response has known content length"),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
 func (r *Response) checkEqual(
 	opChain *chain, what string, expected, actual interface{},
 ) bool {
@@ -1044,3 +2209,21 @@ func (r *Response) checkEqual(
 
 	return true
 }
+
+func transformContent(
+	fn func([]byte) []byte, content []byte,
+) (result []byte, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			result = nil
+			err = fmt.Errorf("body transform panicked: %v", v)
+		}
+	}()
+
+	result = fn(content)
+	if result == nil {
+		return nil, errors.New("body transform returned nil")
+	}
+
+	return result, nil
+}
@@ -2,15 +2,20 @@ package httpexpect
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResponse_FailedChain(t *testing.T) {
@@ -20,25 +25,41 @@ func TestResponse_FailedChain(t *testing.T) {
 		resp.Alias("foo")
 
 		resp.RoundTripTime().chain.assertFailed(t)
+		resp.Trace().chain.assertFailed(t)
 		resp.Duration().chain.assertFailed(t)
 		resp.Headers().chain.assertFailed(t)
 		resp.Header("foo").chain.assertFailed(t)
+		resp.HeaderValues("foo").chain.assertFailed(t)
+		resp.HeaderRaw("foo").chain.assertFailed(t)
+		resp.Trailer("foo").chain.assertFailed(t)
 		resp.Cookies().chain.assertFailed(t)
 		resp.Cookie("foo").chain.assertFailed(t)
+		assert.Equal(t, 0, len(resp.AllCookies()))
 		resp.Body().chain.assertFailed(t)
 		resp.Text().chain.assertFailed(t)
 		resp.Form().chain.assertFailed(t)
 		resp.JSON().chain.assertFailed(t)
+		resp.YAML().chain.assertFailed(t)
+		resp.CSV().chain.assertFailed(t)
 		resp.JSONP("").chain.assertFailed(t)
 		resp.Websocket().chain.assertFailed(t)
+		resp.Proto().chain.assertFailed(t)
+		resp.ContentLength().chain.assertFailed(t)
 
+		resp.WithinSLA(time.Second)
 		resp.Status(123)
 		resp.StatusRange(Status2xx)
 		resp.StatusList(http.StatusOK, http.StatusBadGateway)
+		resp.StatusInRange(200, 299)
 		resp.NoContent()
 		resp.ContentType("", "")
 		resp.ContentEncoding("")
 		resp.TransferEncoding("")
+		resp.WithDecompression()
+		resp.HTTPVersion(1, 1)
+		resp.HasContentLength(0)
+		resp.WithBodyTransform(func(b []byte) []byte { return b })
+		resp.WithCharset("utf-8")
 	}
 
 	t.Run("failed chain", func(t *testing.T) {
@@ -117,6 +138,71 @@ func TestResponse_Constructors(t *testing.T) {
 	})
 }
 
+func TestResponse_Raw(t *testing.T) {
+	t.Run("headers", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Foo": {"bar"},
+			},
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		raw := resp.Raw()
+		raw.Header.Set("Foo", "baz")
+		raw.StatusCode = http.StatusNotFound
+
+		resp.Header("Foo").IsEqual("bar")
+		resp.Status(http.StatusOK)
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, "bar", httpResp.Header.Get("Foo"))
+	})
+
+	t.Run("body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		body := newMockBody("test_body")
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       body,
+		})
+
+		b1, err := ioutil.ReadAll(resp.Raw().Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "test_body", string(b1))
+
+		resp.Body().IsEqual("test_body")
+		resp.chain.assertNotFailed(t)
+
+		raw := resp.Raw()
+
+		b2, err := ioutil.ReadAll(raw.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "test_body", string(b2))
+		assert.NoError(t, raw.Body.Close())
+
+		resp.Body().IsEqual("test_body")
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, 1, body.closeCount)
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+		})
+
+		assert.Nil(t, resp.Raw().Body)
+	})
+}
+
 func TestResponse_Alias(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -129,6 +215,45 @@ func TestResponse_Alias(t *testing.T) {
 	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
 }
 
+func TestResponse_Trace(t *testing.T) {
+	t.Run("provided", func(t *testing.T) {
+		start := time.Unix(1000, 0)
+
+		info := &TraceInfo{
+			Start:     start,
+			FirstByte: start.Add(time.Second),
+		}
+
+		reporter := newMockReporter(t)
+		resp := newResponse(responseOpts{
+			config:   Config{Reporter: reporter}.withDefaults(),
+			chain:    newChainWithDefaults("Response()", reporter),
+			httpResp: &http.Response{},
+			trace:    info,
+		})
+		resp.chain.assert(t, success)
+
+		trace := resp.Trace()
+		trace.chain.assert(t, success)
+
+		assert.Same(t, info, trace.Raw())
+
+		trace.FirstByte().IsEqual(time.Second)
+		trace.chain.assert(t, success)
+	})
+
+	t.Run("omitted", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		resp := NewResponse(reporter, &http.Response{})
+		resp.chain.assert(t, success)
+
+		trace := resp.Trace()
+		trace.chain.assert(t, failure)
+
+		assert.Nil(t, trace.Raw())
+	})
+}
+
 func TestResponse_RoundTripTime(t *testing.T) {
 	t.Run("provided", func(t *testing.T) {
 		duration := time.Second
@@ -165,6 +290,78 @@ func TestResponse_RoundTripTime(t *testing.T) {
 	})
 }
 
+func TestResponse_WithinSLA(t *testing.T) {
+	cases := []struct {
+		name   string
+		rtt    []time.Duration
+		sla    time.Duration
+		result chainResult
+	}{
+		{
+			name:   "less than sla",
+			rtt:    []time.Duration{time.Millisecond},
+			sla:    time.Second,
+			result: success,
+		},
+		{
+			name:   "equal to sla",
+			rtt:    []time.Duration{time.Second},
+			sla:    time.Second,
+			result: failure,
+		},
+		{
+			name:   "greater than sla",
+			rtt:    []time.Duration{time.Minute},
+			sla:    time.Second,
+			result: failure,
+		},
+		{
+			name:   "rtt omitted",
+			rtt:    nil,
+			sla:    time.Second,
+			result: failure,
+		},
+		{
+			name:   "zero sla",
+			rtt:    []time.Duration{time.Millisecond},
+			sla:    0,
+			result: failure,
+		},
+		{
+			name:   "negative sla",
+			rtt:    []time.Duration{time.Millisecond},
+			sla:    -time.Second,
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+			resp := NewResponse(reporter, &http.Response{}, tc.rtt...)
+			resp.chain.assert(t, success)
+
+			assert.Same(t, resp, resp.WithinSLA(tc.sla))
+			resp.chain.assert(t, tc.result)
+		})
+	}
+
+	t.Run("failure message", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		NewResponseC(Config{AssertionHandler: handler},
+			&http.Response{}, 2*time.Second).
+			WithinSLA(time.Second)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertLt, handler.failure.Type)
+		assert.Equal(t, &AssertionValue{2 * time.Second}, handler.failure.Actual)
+		assert.Equal(t, &AssertionValue{time.Second}, handler.failure.Expected)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("round-trip time 2s exceeds SLA of 1s"))
+	})
+}
+
 func TestResponse_StatusRange(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -211,6 +408,37 @@ func TestResponse_StatusRange(t *testing.T) {
 	}
 }
 
+func TestResponse_StatusInRange(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cases := []struct {
+		Status int
+		Min    int
+		Max    int
+		WantOK bool
+	}{
+		{200, 200, 299, true},
+		{299, 200, 299, true},
+		{204, 204, 204, true},
+		{199, 200, 299, false},
+		{300, 200, 299, false},
+		{404, 200, 204, false},
+		{200, 299, 200, false},
+	}
+
+	for _, c := range cases {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: c.Status,
+		})
+		resp.StatusInRange(c.Min, c.Max)
+		if c.WantOK {
+			resp.chain.assertNotFailed(t)
+		} else {
+			resp.chain.assertFailed(t)
+		}
+	}
+}
+
 func TestResponse_StatusList(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -272,7 +500,8 @@ func TestResponse_Headers(t *testing.T) {
 	resp.chain.assertNotFailed(t)
 	resp.chain.clearFailed()
 
-	assert.Same(t, httpResp, resp.Raw())
+	assert.NotSame(t, httpResp, resp.Raw())
+	assert.Equal(t, httpResp.Header, resp.Raw().Header)
 
 	resp.Status(http.StatusOK)
 	resp.chain.assertNotFailed(t)
@@ -293,6 +522,330 @@ func TestResponse_Headers(t *testing.T) {
 	resp.Header("Bad-Header").IsEmpty().chain.assertNotFailed(t)
 }
 
+type trailerBody struct {
+	io.Reader
+	resp    *http.Response
+	trailer http.Header
+}
+
+func (b *trailerBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err == io.EOF {
+		for k, v := range b.trailer {
+			b.resp.Trailer[k] = v
+		}
+	}
+	return n, err
+}
+
+func (b *trailerBody) Close() error {
+	return nil
+}
+
+func TestResponse_Trailer(t *testing.T) {
+	newResp := func(trailer http.Header) *http.Response {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Trailer:    http.Header{},
+		}
+
+		// like net/http, announce trailer keys before body is read,
+		// and fill values after body is read
+		for k := range trailer {
+			httpResp.Trailer[k] = nil
+		}
+
+		httpResp.Body = &trailerBody{
+			Reader:  strings.NewReader("body"),
+			resp:    httpResp,
+			trailer: trailer,
+		}
+
+		return httpResp
+	}
+
+	t.Run("present", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, newResp(http.Header{
+			"Grpc-Status": {"0"},
+		}))
+
+		resp.Trailer("Grpc-Status").IsEqual("0")
+		resp.Trailer("grpc-status").IsEqual("0")
+		resp.chain.assert(t, success)
+
+		resp.Body().IsEqual("body")
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, newResp(http.Header{
+			"Grpc-Status": {"0"},
+		}))
+
+		resp.Trailer("Grpc-Message").chain.assert(t, failure)
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("no trailers", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		resp := NewResponseC(Config{AssertionHandler: handler}, newResp(nil))
+
+		resp.Trailer("Grpc-Status").chain.assert(t, failure)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertContainsKey, handler.failure.Type)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("response has no trailers"))
+	})
+
+	t.Run("without buffering", func(t *testing.T) {
+		newUnbuffered := func(reporter Reporter) *Response {
+			config := newMockConfig(reporter)
+
+			return newResponse(responseOpts{
+				config: config,
+				chain:  newChainWithDefaults("test", reporter),
+				httpResp: newResp(http.Header{
+					"Grpc-Status": {"0"},
+				}),
+				noBuffering: true,
+			})
+		}
+
+		t.Run("trailer then body", func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := newUnbuffered(reporter)
+
+			resp.Trailer("Grpc-Status").IsEqual("0")
+			resp.chain.assert(t, success)
+
+			resp.Body().IsEqual("body")
+			resp.chain.assert(t, success)
+
+			resp.Trailer("Grpc-Status").IsEqual("0")
+			resp.chain.assert(t, success)
+
+			resp.Body().chain.assert(t, failure)
+		})
+
+		t.Run("body then trailer", func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := newUnbuffered(reporter)
+
+			resp.Body().IsEqual("body")
+			resp.chain.assert(t, success)
+
+			resp.Trailer("Grpc-Status").IsEqual("0")
+			resp.chain.assert(t, success)
+		})
+	})
+}
+
+func TestResponse_HeaderValues(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Vary":          {"Accept", "Accept-Encoding"},
+			"Cache-Control": {"no-cache, no-store"},
+		},
+		Body: nil,
+	}
+
+	resp := NewResponse(reporter, httpResp)
+	resp.chain.assertNotFailed(t)
+
+	t.Run("multiple values", func(t *testing.T) {
+		values := resp.HeaderValues("vary")
+		values.chain.assertNotFailed(t)
+		assert.Equal(t, []interface{}{"Accept", "Accept-Encoding"}, values.Raw())
+
+		values.ConsistsOf("Accept", "Accept-Encoding")
+		values.chain.assertNotFailed(t)
+	})
+
+	t.Run("single value", func(t *testing.T) {
+		values := resp.HeaderValues("Cache-Control")
+		values.chain.assertNotFailed(t)
+		assert.Equal(t, []interface{}{"no-cache, no-store"}, values.Raw())
+	})
+
+	t.Run("absent header", func(t *testing.T) {
+		values := resp.HeaderValues("Foo")
+		values.chain.assertNotFailed(t)
+		assert.Equal(t, []interface{}{}, values.Raw())
+
+		values.IsEmpty()
+		values.chain.assertNotFailed(t)
+	})
+
+	resp.chain.assertNotFailed(t)
+}
+
+func TestResponse_HeaderRaw(t *testing.T) {
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": {"text/plain"},
+			"x-request-id": {"123", "456"},
+			"X-Trace":      {"foo"},
+			"x-trace":      {"bar"},
+		},
+		Body: nil,
+	}
+
+	t.Run("exact name", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		resp := NewResponse(reporter, httpResp)
+
+		resp.HeaderRaw("x-request-id").IsEqual("123")
+		resp.chain.assertNotFailed(t)
+
+		resp.HeaderRaw("Content-Type").IsEqual("text/plain")
+		resp.chain.assertNotFailed(t)
+
+		resp.HeaderRaw("X-Trace").IsEqual("foo")
+		resp.chain.assertNotFailed(t)
+
+		resp.HeaderRaw("x-trace").IsEqual("bar")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("different casing", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		resp := NewResponseC(Config{AssertionHandler: handler}, httpResp)
+
+		value := resp.HeaderRaw("X-Request-Id")
+		value.chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+
+		assert.Equal(t, "", value.Raw())
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertContainsKey, handler.failure.Type)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New(`response has header "x-request-id" with different casing`))
+	})
+
+	t.Run("absent header", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		resp := NewResponseC(Config{AssertionHandler: handler}, httpResp)
+
+		resp.HeaderRaw("Foo").chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, []error{
+			errors.New(`expected: response has header with exact name "Foo"`),
+		}, handler.failure.Errors)
+	})
+
+	t.Run("binder", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header()["x-custom"] = []string{"raw"}
+			w.Header().Set("x-canonical", "canon")
+		})
+
+		e := WithConfig(Config{
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			Reporter: newMockReporter(t),
+		})
+
+		resp := e.GET("/").Expect()
+
+		resp.HeaderRaw("x-custom").IsEqual("raw")
+		resp.HeaderRaw("X-Canonical").IsEqual("canon")
+		resp.chain.assertNotFailed(t)
+
+		resp.HeaderRaw("x-canonical").chain.assertFailed(t)
+	})
+
+	rawHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header()["x-custom"] = []string{"raw"}
+		w.Header()["X-Multi"] = []string{"foo"}
+		w.Header()["x-multi"] = []string{"bar"}
+		w.Header().Set("x-canonical", "canon")
+		_, _ = w.Write([]byte("body"))
+	})
+
+	t.Run("raw headers", func(t *testing.T) {
+		server := httptest.NewServer(rawHandler)
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
+
+		for i := 0; i < 2; i++ {
+			resp := e.GET("/").WithRawHeaders().Expect()
+
+			resp.HeaderRaw("x-custom").IsEqual("raw")
+			resp.HeaderRaw("X-Multi").IsEqual("foo")
+			resp.HeaderRaw("x-multi").IsEqual("bar")
+			resp.HeaderRaw("X-Canonical").IsEqual("canon")
+			resp.Body().IsEqual("body")
+			resp.chain.assertNotFailed(t)
+
+			// Should keep canonicalized names in Header
+			resp.Header("X-Custom").IsEqual("raw")
+			resp.chain.assertNotFailed(t)
+
+			resp.HeaderRaw("X-Custom").chain.assertFailed(t)
+		}
+	})
+
+	t.Run("raw headers, tls", func(t *testing.T) {
+		server := httptest.NewTLSServer(rawHandler)
+		defer server.Close()
+
+		client := server.Client()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+
+		resp := e.GET("/").WithRawHeaders().Expect()
+
+		resp.HeaderRaw("x-custom").IsEqual("raw")
+		resp.HeaderRaw("x-multi").IsEqual("bar")
+		resp.Body().IsEqual("body")
+		resp.chain.assertNotFailed(t)
+
+		// Should not modify original client
+		assert.Nil(t, client.Transport.(*http.Transport).DialTLSContext)
+	})
+
+	t.Run("raw headers, not recorded", func(t *testing.T) {
+		server := httptest.NewServer(rawHandler)
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
+
+		resp := e.GET("/").Expect()
+
+		resp.HeaderRaw("X-Custom").IsEqual("raw")
+		resp.chain.assertNotFailed(t)
+
+		resp.HeaderRaw("x-custom").chain.assertFailed(t)
+	})
+}
+
 func TestResponse_Cookies(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -338,12 +891,63 @@ func TestResponse_Cookies(t *testing.T) {
 		resp.chain.assertFailed(t)
 		c3.chain.assertFailed(t)
 		assert.Nil(t, c3.Raw())
+		resp.chain.clearFailed()
+
+		all := resp.AllCookies()
+		resp.chain.assertNotFailed(t)
+		require.Equal(t, 2, len(all))
+		all[0].chain.assertNotFailed(t)
+		all[1].chain.assertNotFailed(t)
+		assert.Equal(t, "foo", all[0].Raw().Name)
+		assert.Equal(t, "bar", all[1].Raw().Name)
 	})
 
-	t.Run("no cookies", func(t *testing.T) {
-		httpResp := &http.Response{
-			StatusCode: http.StatusOK,
-			Header:     nil,
+	t.Run("duplicate cookies", func(t *testing.T) {
+		headers := map[string][]string{
+			"Set-Cookie": {
+				"foo=aaa; path=/a",
+				"foo=bbb; path=/b",
+				"bar=ccc",
+			},
+		}
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       nil,
+		}
+
+		resp := NewResponse(reporter, httpResp)
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, []interface{}{"foo", "foo", "bar"}, resp.Cookies().Raw())
+		resp.Cookies().Length().IsEqual(3)
+		resp.chain.assertNotFailed(t)
+
+		all := resp.AllCookies()
+		resp.chain.assertNotFailed(t)
+		require.Equal(t, 3, len(all))
+
+		all[0].Value().IsEqual("aaa")
+		all[0].Path().IsEqual("/a")
+		all[1].Value().IsEqual("bbb")
+		all[1].Path().IsEqual("/b")
+		all[2].Name().IsEqual("bar")
+		all[2].Value().IsEqual("ccc")
+
+		for _, c := range all {
+			c.chain.assertNotFailed(t)
+		}
+
+		name := all[2].Name().IsEqual("baz")
+		name.chain.assertFailed(t)
+		all[0].chain.assertNotFailed(t)
+	})
+
+	t.Run("no cookies", func(t *testing.T) {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     nil,
 			Body:       nil,
 		}
 
@@ -354,6 +958,9 @@ func TestResponse_Cookies(t *testing.T) {
 		assert.Equal(t, []interface{}{}, resp.Cookies().Raw())
 		resp.chain.assertNotFailed(t)
 
+		assert.Equal(t, 0, len(resp.AllCookies()))
+		resp.chain.assertNotFailed(t)
+
 		c := resp.Cookie("foo")
 		resp.chain.assertFailed(t)
 		c.chain.assertFailed(t)
@@ -916,6 +1523,383 @@ func TestResponse_TransferEncoding(t *testing.T) {
 	resp.chain.clearFailed()
 }
 
+func TestResponse_ContentLength(t *testing.T) {
+	t.Run("known", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		body := newMockBody("hello")
+
+		resp := NewResponse(reporter, &http.Response{
+			ContentLength: 5,
+			Body:          body,
+		})
+
+		resp.ContentLength().IsEqual(5)
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		resp.HasContentLength(5)
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		resp.HasContentLength(6)
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		// Should not read body
+		assert.Equal(t, 0, body.readCount)
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			ContentLength: 0,
+		})
+
+		resp.ContentLength().IsEqual(0)
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		resp.HasContentLength(0)
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			ContentLength:    -1,
+			TransferEncoding: []string{"chunked"},
+		})
+
+		resp.ContentLength()
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		resp.HasContentLength(-1)
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+	})
+}
+
+func TestResponse_WithDecompression(t *testing.T) {
+	gzipBytes := func(s string) []byte {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, _ = zw.Write([]byte(s))
+		_ = zw.Close()
+		return buf.Bytes()
+	}
+
+	deflateBytes := func(s string) []byte {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		_, _ = zw.Write([]byte(s))
+		_ = zw.Close()
+		return buf.Bytes()
+	}
+
+	body := `{"foo":123}`
+
+	cases := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{
+			name:     "gzip",
+			encoding: "gzip",
+			body:     gzipBytes(body),
+		},
+		{
+			name:     "deflate",
+			encoding: "deflate",
+			body:     deflateBytes(body),
+		},
+		{
+			name:     "identity",
+			encoding: "identity",
+			body:     []byte(body),
+		},
+		{
+			name:     "no encoding",
+			encoding: "",
+			body:     []byte(body),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			headers := map[string][]string{
+				"Content-Type": {"application/json"},
+			}
+			if tc.encoding != "" {
+				headers["Content-Encoding"] = []string{tc.encoding}
+			}
+
+			httpResp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header(headers),
+				Body:       ioutil.NopCloser(bytes.NewReader(tc.body)),
+			}
+
+			resp := NewResponse(reporter, httpResp)
+
+			resp.WithDecompression()
+			resp.chain.assertNotFailed(t)
+
+			assert.Equal(t, body, resp.Body().Raw())
+			assert.Equal(t, map[string]interface{}{"foo": 123.0},
+				resp.JSON().Object().Raw())
+
+			if tc.encoding != "" && tc.encoding != "identity" {
+				resp.ContentEncoding()
+				resp.chain.assertNotFailed(t)
+
+				assert.Equal(t, []string{tc.encoding},
+					httpResp.Header["Content-Encoding"])
+			}
+		})
+	}
+
+	t.Run("plain body with gzip header", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header(map[string][]string{
+				"Content-Encoding": {"gzip"},
+			}),
+			Body: ioutil.NopCloser(bytes.NewReader([]byte(body))),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.WithDecompression()
+		resp.chain.assertFailed(t)
+
+		assert.Equal(t, "", resp.Body().Raw())
+	})
+
+	t.Run("truncated body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		b := gzipBytes(body)
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header(map[string][]string{
+				"Content-Encoding": {"gzip"},
+			}),
+			Body: ioutil.NopCloser(bytes.NewReader(b[:len(b)-4])),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.WithDecompression()
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("unsupported encoding", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header(map[string][]string{
+				"Content-Encoding": {"br"},
+			}),
+			Body: ioutil.NopCloser(bytes.NewReader([]byte(body))),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.WithDecompression()
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestResponse_WithBodyTransform(t *testing.T) {
+	t.Run("strip prefix", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		callCount := 0
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(`)]}',{"foo":123}`)),
+		})
+
+		resp.WithBodyTransform(func(b []byte) []byte {
+			callCount++
+			return bytes.TrimPrefix(b, []byte(")]}',"))
+		})
+		resp.chain.assertNotFailed(t)
+
+		// Should be lazy
+		assert.Equal(t, 0, callCount)
+
+		resp.JSON().Object().HasValue("foo", 123)
+		resp.chain.assertNotFailed(t)
+
+		resp.Body().IsEqual(`{"foo":123}`)
+		resp.chain.assertNotFailed(t)
+
+		resp.Text(ContentOpts{MediaType: "application/json"}).
+			IsEqual(`{"foo":123}`)
+		resp.chain.assertNotFailed(t)
+
+		// Should be invoked once
+		assert.Equal(t, 1, callCount)
+	})
+
+	t.Run("after body is read", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			Body: ioutil.NopCloser(bytes.NewBufferString("foo")),
+		})
+
+		resp.Body().IsEqual("foo")
+		resp.chain.assertNotFailed(t)
+
+		resp.WithBodyTransform(bytes.ToUpper)
+		resp.Body().IsEqual("FOO")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("multiple transforms", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			Body: ioutil.NopCloser(bytes.NewBufferString("foo")),
+		})
+
+		resp.
+			WithBodyTransform(func(b []byte) []byte {
+				return append(b, "bar"...)
+			}).
+			WithBodyTransform(bytes.ToUpper)
+
+		resp.Body().IsEqual("FOOBAR")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("transform panics", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		resp := NewResponseC(Config{
+			AssertionHandler: handler,
+		}, &http.Response{
+			Body: ioutil.NopCloser(bytes.NewBufferString("foo")),
+		})
+
+		resp.WithBodyTransform(func(b []byte) []byte {
+			panic("test")
+		})
+		resp.chain.assertNotFailed(t)
+
+		resp.Body()
+		resp.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+	})
+
+	t.Run("transform returns nil", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		resp := NewResponseC(Config{
+			AssertionHandler: handler,
+		}, &http.Response{
+			Body: ioutil.NopCloser(bytes.NewBufferString("foo")),
+		})
+
+		resp.WithBodyTransform(func(b []byte) []byte {
+			return nil
+		})
+		resp.chain.assertNotFailed(t)
+
+		resp.Body()
+		resp.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+	})
+
+	t.Run("nil argument", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{})
+
+		resp.WithBodyTransform(nil)
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestResponse_Proto(t *testing.T) {
+	t.Run("http/2", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			Proto:      "HTTP/2.0",
+			ProtoMajor: 2,
+			ProtoMinor: 0,
+		})
+
+		assert.Equal(t, "HTTP/2.0", resp.Proto().Raw())
+		resp.chain.assertNotFailed(t)
+
+		resp.HTTPVersion(2, 0)
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		resp.HTTPVersion(1, 1)
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+	})
+
+	t.Run("http/1.1", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+		})
+
+		assert.Equal(t, "HTTP/1.1", resp.Proto().Raw())
+		resp.chain.assertNotFailed(t)
+
+		resp.HTTPVersion(1, 1)
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		resp.HTTPVersion(1, 0)
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{})
+
+		assert.Equal(t, "", resp.Proto().Raw())
+		resp.chain.assertNotFailed(t)
+
+		resp.HTTPVersion(1, 1)
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+	})
+}
+
 func TestResponse_Text(t *testing.T) {
 	t.Run("basic", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -981,6 +1965,170 @@ func TestResponse_Text(t *testing.T) {
 		respText.chain.assertFailed(t)
 		resp.chain.assertFailed(t)
 	})
+
+	t.Run("charset", func(t *testing.T) {
+		cases := []struct {
+			name        string
+			contentType string
+			body        []byte
+			result      string
+			fail        bool
+		}{
+			{
+				name:        "no charset",
+				contentType: "text/plain",
+				body:        []byte("hello"),
+				result:      "hello",
+			},
+			{
+				name:        "us-ascii",
+				contentType: "text/plain; charset=US-ASCII",
+				body:        []byte("hello"),
+				result:      "hello",
+			},
+			{
+				name:        "utf-8",
+				contentType: "text/plain; charset=UTF-8",
+				body:        []byte("caf\xc3\xa9"),
+				result:      "café",
+			},
+			{
+				name:        "iso-8859-1",
+				contentType: "text/plain; charset=iso-8859-1",
+				body:        []byte("caf\xe9"),
+				result:      "café",
+			},
+			{
+				name:        "windows-1251",
+				contentType: "text/plain; charset=windows-1251",
+				body:        []byte("\xef\xf0\xe8\xe2\xe5\xf2"),
+				result:      "привет",
+			},
+			{
+				name:        "unsupported",
+				contentType: "text/plain; charset=bad",
+				body:        []byte("hello"),
+				fail:        true,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				resp := NewResponse(reporter, &http.Response{
+					StatusCode: http.StatusOK,
+					Header: http.Header{
+						"Content-Type": {tc.contentType},
+					},
+					Body: ioutil.NopCloser(bytes.NewReader(tc.body)),
+				})
+
+				text := resp.Text()
+
+				if tc.fail {
+					text.chain.assertFailed(t)
+					resp.chain.assertFailed(t)
+				} else {
+					text.chain.assertNotFailed(t)
+					resp.chain.assertNotFailed(t)
+					assert.Equal(t, tc.result, text.Raw())
+				}
+
+				// body is not affected
+				resp.chain.clearFailed()
+				assert.Equal(t, string(tc.body), resp.Body().Raw())
+			})
+		}
+	})
+
+	t.Run("charset in options", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"text/plain; charset=iso-8859-1"},
+			},
+			Body: ioutil.NopCloser(bytes.NewReader([]byte("caf\xe9"))),
+		})
+
+		resp.Text(ContentOpts{Charset: "utf-8"})
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		assert.Equal(t, "café",
+			resp.Text(ContentOpts{Charset: "iso-8859-1"}).Raw())
+		resp.chain.assertNotFailed(t)
+	})
+}
+
+func TestResponse_WithCharset(t *testing.T) {
+	t.Run("override", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			Body: ioutil.NopCloser(bytes.NewReader([]byte("caf\xe9"))),
+		})
+
+		resp.WithCharset("iso-8859-1")
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, "café", resp.Text().Raw())
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("utf-8", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"text/plain; charset=iso-8859-1"},
+			},
+			Body: ioutil.NopCloser(bytes.NewReader([]byte("caf\xc3\xa9"))),
+		})
+
+		resp.WithCharset("utf-8")
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, "café", resp.Text().Raw())
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"text/plain"},
+			},
+			Body: ioutil.NopCloser(bytes.NewReader([]byte("hello"))),
+		})
+
+		resp.WithCharset("bad")
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"text/plain"},
+			},
+			Body: ioutil.NopCloser(bytes.NewReader([]byte("hello"))),
+		})
+
+		resp.WithCharset("")
+		resp.chain.assertFailed(t)
+	})
 }
 
 func TestResponse_Form(t *testing.T) {
@@ -1050,17 +2198,159 @@ func TestResponse_Form(t *testing.T) {
 		resp.chain.assertFailed(t)
 		resp.chain.clearFailed()
 
-		assert.Nil(t, resp.Form().Raw())
+		assert.Nil(t, resp.Form().Raw())
+	})
+
+	t.Run("bad type", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		headers := map[string][]string{
+			"Content-Type": {"bad"},
+		}
+
+		body := "foo=bar"
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.Form()
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		assert.Nil(t, resp.Form().Raw())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		headers := map[string][]string{
+			"Content-Type": {"application/x-www-form-urlencoded"},
+		}
+
+		body := newMockBody("foo=bar")
+		body.readErr = errors.New("read error")
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       body,
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		respForm := resp.Form()
+		assert.Nil(t, respForm.Raw())
+
+		respForm.chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+	})
+}
+
+func TestResponse_JSON(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		headers := map[string][]string{
+			"Content-Type": {"application/json; charset=utf-8"},
+		}
+
+		body := `{"key": "value"}`
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		assert.Equal(t, body, resp.Body().Raw())
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		resp.ContentType("application/json")
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		resp.ContentType("application/json", "utf-8")
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		resp.ContentType("text/plain")
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		resp.JSON()
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		assert.Equal(t,
+			map[string]interface{}{"key": "value"}, resp.JSON().Object().Raw())
+	})
+
+	t.Run("bad body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		headers := map[string][]string{
+			"Content-Type": {"application/json; charset=utf-8"},
+		}
+
+		body := "{"
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.JSON()
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		assert.Nil(t, resp.JSON().Raw())
+	})
+
+	t.Run("empty charset", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		headers := map[string][]string{
+			"Content-Type": {"application/json"},
+		}
+
+		body := `{"key": "value"}`
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.JSON()
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		assert.Equal(t,
+			map[string]interface{}{"key": "value"}, resp.JSON().Object().Raw())
 	})
 
-	t.Run("bad type", func(t *testing.T) {
+	t.Run("bad charset", func(t *testing.T) {
 		reporter := newMockReporter(t)
 
 		headers := map[string][]string{
-			"Content-Type": {"bad"},
+			"Content-Type": {"application/json; charset=bad"},
 		}
 
-		body := "foo=bar"
+		body := `{"key": "value"}`
 
 		httpResp := &http.Response{
 			StatusCode: http.StatusOK,
@@ -1070,21 +2360,21 @@ func TestResponse_Form(t *testing.T) {
 
 		resp := NewResponse(reporter, httpResp)
 
-		resp.Form()
+		resp.JSON()
 		resp.chain.assertFailed(t)
 		resp.chain.clearFailed()
 
-		assert.Nil(t, resp.Form().Raw())
+		assert.Equal(t, nil, resp.JSON().Raw())
 	})
 
 	t.Run("read failure", func(t *testing.T) {
 		reporter := newMockReporter(t)
 
 		headers := map[string][]string{
-			"Content-Type": {"application/x-www-form-urlencoded"},
+			"Content-Type": {"application/json; charset=utf-8"},
 		}
 
-		body := newMockBody("foo=bar")
+		body := newMockBody(`{"key": "value"}`)
 		body.readErr = errors.New("read error")
 
 		httpResp := &http.Response{
@@ -1095,24 +2385,23 @@ func TestResponse_Form(t *testing.T) {
 
 		resp := NewResponse(reporter, httpResp)
 
-		respForm := resp.Form()
-		assert.Nil(t, respForm.Raw())
-
-		respForm.chain.assertFailed(t)
+		respJSON := resp.JSON()
+		assert.Nil(t, respJSON.Raw())
+		respJSON.chain.assertFailed(t)
 		resp.chain.assertFailed(t)
 		resp.chain.clearFailed()
 	})
 }
 
-func TestResponse_JSON(t *testing.T) {
+func TestResponse_YAML(t *testing.T) {
 	t.Run("basic", func(t *testing.T) {
 		reporter := newMockReporter(t)
 
 		headers := map[string][]string{
-			"Content-Type": {"application/json; charset=utf-8"},
+			"Content-Type": {"application/yaml; charset=utf-8"},
 		}
 
-		body := `{"key": "value"}`
+		body := "key: value\nnum: 123\nlist:\n  - 1\n  - foo\n"
 
 		httpResp := &http.Response{
 			StatusCode: http.StatusOK,
@@ -1122,38 +2411,30 @@ func TestResponse_JSON(t *testing.T) {
 
 		resp := NewResponse(reporter, httpResp)
 
-		assert.Equal(t, body, resp.Body().Raw())
-		resp.chain.assertNotFailed(t)
-		resp.chain.clearFailed()
-
-		resp.ContentType("application/json")
-		resp.chain.assertNotFailed(t)
-		resp.chain.clearFailed()
-
-		resp.ContentType("application/json", "utf-8")
+		resp.YAML()
 		resp.chain.assertNotFailed(t)
 		resp.chain.clearFailed()
 
-		resp.ContentType("text/plain")
-		resp.chain.assertFailed(t)
-		resp.chain.clearFailed()
+		assert.Equal(t,
+			map[string]interface{}{
+				"key":  "value",
+				"num":  123.0,
+				"list": []interface{}{1.0, "foo"},
+			},
+			resp.YAML().Object().Raw())
 
-		resp.JSON()
+		resp.YAML().Object().Value("num").Number().IsEqual(123)
 		resp.chain.assertNotFailed(t)
-		resp.chain.clearFailed()
-
-		assert.Equal(t,
-			map[string]interface{}{"key": "value"}, resp.JSON().Object().Raw())
 	})
 
-	t.Run("bad body", func(t *testing.T) {
+	t.Run("nested maps", func(t *testing.T) {
 		reporter := newMockReporter(t)
 
 		headers := map[string][]string{
-			"Content-Type": {"application/json; charset=utf-8"},
+			"Content-Type": {"application/yaml"},
 		}
 
-		body := "{"
+		body := "a:\n  b:\n    1: c\n"
 
 		httpResp := &http.Response{
 			StatusCode: http.StatusOK,
@@ -1163,21 +2444,50 @@ func TestResponse_JSON(t *testing.T) {
 
 		resp := NewResponse(reporter, httpResp)
 
-		resp.JSON()
-		resp.chain.assertFailed(t)
-		resp.chain.clearFailed()
+		assert.Equal(t,
+			map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": map[string]interface{}{
+						"1": "c",
+					},
+				},
+			},
+			resp.YAML().Object().Raw())
+		resp.chain.assertNotFailed(t)
+	})
 
-		assert.Nil(t, resp.JSON().Raw())
+	t.Run("media types", func(t *testing.T) {
+		for _, mediaType := range []string{
+			"application/yaml",
+			"application/x-yaml",
+			"text/yaml",
+			"text/x-yaml",
+		} {
+			t.Run(mediaType, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				httpResp := &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {mediaType}},
+					Body:       ioutil.NopCloser(bytes.NewBufferString("key: value")),
+				}
+
+				resp := NewResponse(reporter, httpResp)
+
+				resp.YAML()
+				resp.chain.assertNotFailed(t)
+			})
+		}
 	})
 
-	t.Run("empty charset", func(t *testing.T) {
+	t.Run("bad content type", func(t *testing.T) {
 		reporter := newMockReporter(t)
 
 		headers := map[string][]string{
 			"Content-Type": {"application/json"},
 		}
 
-		body := `{"key": "value"}`
+		body := "key: value"
 
 		httpResp := &http.Response{
 			StatusCode: http.StatusOK,
@@ -1187,22 +2497,49 @@ func TestResponse_JSON(t *testing.T) {
 
 		resp := NewResponse(reporter, httpResp)
 
-		resp.JSON()
-		resp.chain.assertNotFailed(t)
+		resp.YAML()
+		resp.chain.assertFailed(t)
 		resp.chain.clearFailed()
 
-		assert.Equal(t,
-			map[string]interface{}{"key": "value"}, resp.JSON().Object().Raw())
+		resp.YAML(ContentOpts{
+			MediaType: "application/json",
+		})
+		resp.chain.assertNotFailed(t)
 	})
 
-	t.Run("bad charset", func(t *testing.T) {
+	t.Run("bad content type, failure handler", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"text/plain"}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString("key: value")),
+		}
+
+		resp := NewResponseC(Config{
+			AssertionHandler: handler,
+		}, httpResp)
+
+		value := resp.YAML()
+		value.chain.assert(t, failure)
+
+		if assert.NotNil(t, handler.failure) {
+			assert.Equal(t, SeverityError, handler.failure.Severity)
+			assert.Equal(t, AssertEqual, handler.failure.Type)
+			assert.Equal(t, &AssertionValue{"text/plain"}, handler.failure.Actual)
+		}
+
+		assert.Nil(t, value.Raw())
+	})
+
+	t.Run("bad body", func(t *testing.T) {
 		reporter := newMockReporter(t)
 
 		headers := map[string][]string{
-			"Content-Type": {"application/json; charset=bad"},
+			"Content-Type": {"application/yaml"},
 		}
 
-		body := `{"key": "value"}`
+		body := "key: [value"
 
 		httpResp := &http.Response{
 			StatusCode: http.StatusOK,
@@ -1212,37 +2549,149 @@ func TestResponse_JSON(t *testing.T) {
 
 		resp := NewResponse(reporter, httpResp)
 
-		resp.JSON()
+		resp.YAML()
 		resp.chain.assertFailed(t)
 		resp.chain.clearFailed()
 
-		assert.Equal(t, nil, resp.JSON().Raw())
+		assert.Nil(t, resp.YAML().Raw())
 	})
+}
 
-	t.Run("read failure", func(t *testing.T) {
+func TestResponse_CSV(t *testing.T) {
+	newCSVResponse := func(
+		reporter Reporter, contentType, body string,
+	) *Response {
+		return NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {contentType},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+		})
+	}
+
+	t.Run("rows", func(t *testing.T) {
 		reporter := newMockReporter(t)
 
-		headers := map[string][]string{
-			"Content-Type": {"application/json; charset=utf-8"},
-		}
+		resp := newCSVResponse(reporter, "text/csv",
+			"name,age\nfoo,12\n\"bar, baz\",34\n")
 
-		body := newMockBody(`{"key": "value"}`)
-		body.readErr = errors.New("read error")
+		csv := resp.CSV()
+		csv.chain.assertNotFailed(t)
+		resp.chain.assertNotFailed(t)
 
-		httpResp := &http.Response{
-			StatusCode: http.StatusOK,
-			Header:     http.Header(headers),
-			Body:       body,
+		assert.Equal(t, []interface{}{
+			[]interface{}{"name", "age"},
+			[]interface{}{"foo", "12"},
+			[]interface{}{"bar, baz", "34"},
+		}, csv.Raw())
+	})
+
+	t.Run("header", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := newCSVResponse(reporter, "text/csv; charset=utf-8",
+			"name;age\r\nfoo;12\r\nbar;34\r\n")
+
+		csv := resp.CSV(CSVOpts{
+			Delimiter: ';',
+			Header:    true,
+		})
+		csv.chain.assertNotFailed(t)
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "foo", "age": "12"},
+			map[string]interface{}{"name": "bar", "age": "34"},
+		}, csv.Raw())
+
+		csv.Value(1).Object().HasValue("name", "bar")
+		csv.chain.assertNotFailed(t)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		for _, header := range []bool{false, true} {
+			reporter := newMockReporter(t)
+
+			resp := newCSVResponse(reporter, "text/csv", "")
+
+			csv := resp.CSV(CSVOpts{Header: header})
+			csv.chain.assertNotFailed(t)
+
+			assert.Equal(t, []interface{}{}, csv.Raw())
 		}
+	})
 
-		resp := NewResponse(reporter, httpResp)
+	t.Run("charset", func(t *testing.T) {
+		reporter := newMockReporter(t)
 
-		respJSON := resp.JSON()
-		assert.Nil(t, respJSON.Raw())
-		respJSON.chain.assertFailed(t)
+		resp := newCSVResponse(reporter, "text/csv; charset=iso-8859-1",
+			"name\ncaf\xe9\n")
+
+		csv := resp.CSV(CSVOpts{Header: true})
+		csv.chain.assertNotFailed(t)
+
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "café"},
+		}, csv.Raw())
+	})
+
+	t.Run("media type", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := newCSVResponse(reporter, "text/plain", "a,b\n")
+
+		resp.CSV()
 		resp.chain.assertFailed(t)
 		resp.chain.clearFailed()
+
+		resp.CSV(CSVOpts{MediaType: "text/plain"})
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("ragged rows", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := newCSVResponse(reporter, "text/csv", "a,b\nc,d\ne\n")
+
+		csv := resp.CSV()
+		csv.chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+
+		assert.Nil(t, csv.Raw())
+	})
+
+	t.Run("duplicate column", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := newCSVResponse(reporter, "text/csv", "a,a\nc,d\n")
+
+		resp.CSV()
+		resp.chain.assertNotFailed(t)
+
+		resp.CSV(CSVOpts{Header: true})
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestResponse_CSVFailures(t *testing.T) {
+	handler := &mockAssertionHandler{}
+
+	resp := NewResponseC(Config{
+		AssertionHandler: handler,
+	}, &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": {"text/csv"},
+		},
+		Body: ioutil.NopCloser(bytes.NewBufferString("a,b\nc,d\ne\n")),
 	})
+
+	resp.CSV()
+
+	require.NotNil(t, handler.failure)
+	require.Equal(t, 2, len(handler.failure.Errors))
+	assert.Contains(t, handler.failure.Errors[1].Error(), "line 3")
 }
 
 func TestResponse_JSONP(t *testing.T) {
@@ -1408,6 +2857,111 @@ func TestResponse_JSONP(t *testing.T) {
 		resp.chain.assertFailed(t)
 		resp.chain.clearFailed()
 	})
+
+	t.Run("multiline body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		headers := map[string][]string{
+			"Content-Type": {"application/javascript"},
+		}
+
+		body := "foo({\n  \"key\": \"value\"\n});\n"
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		assert.Equal(t,
+			map[string]interface{}{"key": "value"}, resp.JSONP("foo").Object().Raw())
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("legacy media types", func(t *testing.T) {
+		for _, contentType := range []string{
+			"text/javascript",
+			"application/x-javascript; charset=utf-8",
+		} {
+			t.Run(contentType, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				httpResp := &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {contentType}},
+					Body: ioutil.NopCloser(
+						bytes.NewBufferString(`foo({"key": "value"})`)),
+				}
+
+				resp := NewResponse(reporter, httpResp)
+
+				resp.JSONP("foo")
+				resp.chain.assertNotFailed(t)
+
+				resp.JSONP("foo", ContentOpts{MediaType: "application/javascript"})
+				resp.chain.assertFailed(t)
+			})
+		}
+
+		reporter := newMockReporter(t)
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body: ioutil.NopCloser(
+				bytes.NewBufferString(`foo({"key": "value"})`)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.JSONP("foo")
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("failure messages", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			body   string
+			errMsg string
+		}{
+			{"callback mismatch", `bar({"key": "value"})`,
+				`unexpected JSONP callback name "bar"`},
+			{"malformed wrapper", `foo{"key": "value"}`,
+				`expected: JSONP body in form of "foo(<valid json>)"`},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				handler := &mockAssertionHandler{}
+
+				httpResp := &http.Response{
+					StatusCode: http.StatusOK,
+					Header: http.Header{
+						"Content-Type": {"application/javascript"},
+					},
+					Body: ioutil.NopCloser(bytes.NewBufferString(tc.body)),
+				}
+
+				resp := NewResponseC(Config{
+					AssertionHandler: handler,
+				}, httpResp)
+
+				resp.JSONP("foo")
+				resp.chain.assertFailed(t)
+
+				require.NotNil(t, handler.failure)
+				assert.Equal(t, AssertValid, handler.failure.Type)
+
+				var msgs []string
+				for _, err := range handler.failure.Errors {
+					msgs = append(msgs, err.Error())
+				}
+				assert.Contains(t, msgs, tc.errMsg)
+			})
+		}
+	})
 }
 
 func TestResponse_ContentOpts(t *testing.T) {
@@ -1452,10 +3006,10 @@ func TestResponse_ContentOpts(t *testing.T) {
 		chainFunc func(*Response, ContentOpts) *chain,
 	) {
 		runTest(t, testCase{
-			respContentType:   "test-type; charset=test-charset",
+			respContentType:   "test-type; charset=iso-8859-1",
 			respBody:          respBody,
 			expectedMediaType: "test-type",
-			expectedCharset:   "test-charset",
+			expectedCharset:   "iso-8859-1",
 			match:             true,
 			chainFunc:         chainFunc,
 		})
@@ -1463,15 +3017,15 @@ func TestResponse_ContentOpts(t *testing.T) {
 			respContentType:   "test-type; charset=BAD",
 			respBody:          respBody,
 			expectedMediaType: "test-type",
-			expectedCharset:   "test-charset",
+			expectedCharset:   "iso-8859-1",
 			match:             false,
 			chainFunc:         chainFunc,
 		})
 		runTest(t, testCase{
-			respContentType:   "BAD; charset=test-charset",
+			respContentType:   "BAD; charset=iso-8859-1",
 			respBody:          respBody,
 			expectedMediaType: "test-type",
-			expectedCharset:   "test-charset",
+			expectedCharset:   "iso-8859-1",
 			match:             false,
 			chainFunc:         chainFunc,
 		})
@@ -1502,18 +3056,18 @@ func TestResponse_ContentOpts(t *testing.T) {
 			chainFunc:         chainFunc,
 		})
 		runTest(t, testCase{
-			respContentType:   defaultType + "; charset=test-charset",
+			respContentType:   defaultType + "; charset=iso-8859-1",
 			respBody:          respBody,
 			expectedMediaType: defaultType,
-			expectedCharset:   "test-charset",
+			expectedCharset:   "iso-8859-1",
 			match:             true,
 			chainFunc:         chainFunc,
 		})
 		runTest(t, testCase{
-			respContentType:   defaultType + "; charset=test-charset",
+			respContentType:   defaultType + "; charset=iso-8859-1",
 			respBody:          respBody,
 			expectedMediaType: "",
-			expectedCharset:   "test-charset",
+			expectedCharset:   "iso-8859-1",
 			match:             true,
 			chainFunc:         chainFunc,
 		})
@@ -1646,6 +3200,69 @@ func TestResponse_Usage(t *testing.T) {
 		resp.chain.assertFailed(t)
 	})
 
+	t.Run("YAML multiple arguments", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		headers := map[string][]string{
+			"Content-Type": {"application/yaml"},
+		}
+
+		body := `key: value`
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+		contentOpts1 := ContentOpts{
+			MediaType: "text/plain",
+		}
+		contentOpts2 := ContentOpts{
+			MediaType: "application/yaml",
+		}
+		resp.YAML(contentOpts1, contentOpts2)
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("CSV multiple arguments", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		headers := map[string][]string{
+			"Content-Type": {"text/csv"},
+		}
+
+		body := "a,b\n"
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+		resp.CSV(CSVOpts{}, CSVOpts{Header: true})
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("CSV invalid delimiter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		headers := map[string][]string{
+			"Content-Type": {"text/csv"},
+		}
+
+		body := "a,b\n"
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+		resp.CSV(CSVOpts{Delimiter: '\n'})
+		resp.chain.assertFailed(t)
+	})
+
 	t.Run("JSONP multiple arguments", func(t *testing.T) {
 		reporter := newMockReporter(t)
 
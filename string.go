@@ -125,6 +125,35 @@ func (s *String) Length() *Number {
 	return newNumber(opChain, float64(len(s.value)))
 }
 
+// Lines returns a new Array instance with string split into lines.
+//
+// String is split on "\n", and trailing "\r" is removed from every line.
+// Trailing newline doesn't produce an empty final line. Empty string
+// produces an empty array.
+//
+// Example:
+//
+//	str := NewString(t, "foo\r\nbar\n")
+//	str.Lines().IsEqual([]string{"foo", "bar"})
+func (s *String) Lines() *Array {
+	opChain := s.chain.enter("Lines()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	lines := []interface{}{}
+
+	if s.value != "" {
+		for _, line := range strings.Split(strings.TrimSuffix(s.value, "\n"), "\n") {
+			lines = append(lines, strings.TrimSuffix(line, "\r"))
+		}
+	}
+
+	return newArray(opChain, lines)
+}
+
 // IsEmpty succeeds if string is empty.
 //
 // Example:
@@ -1012,6 +1041,9 @@ func (s *String) MatchAll(re string) []Match {
 
 // IsASCII succeeds if all string characters belongs to ASCII.
 //
+// Empty string is considered ASCII. On failure, first non-ASCII character
+// and its byte offset are reported.
+//
 // Example:
 //
 //	str := NewString(t, "Hello")
@@ -1024,24 +1056,21 @@ func (s *String) IsASCII() *String {
 		return s
 	}
 
-	isASCII := true
-	for _, c := range s.value {
+	for i, c := range s.value {
 		if c > unicode.MaxASCII {
-			isASCII = false
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{s.value},
+				Errors: []error{
+					errors.New("expected: all string characters are ascii"),
+					fmt.Errorf("found non-ascii character %q at byte offset %d",
+						c, i),
+				},
+			})
 			break
 		}
 	}
 
-	if !isASCII {
-		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{s.value},
-			Errors: []error{
-				errors.New("expected: all string characters are ascii"),
-			},
-		})
-	}
-
 	return s
 }
 
@@ -1085,6 +1114,78 @@ func (s *String) NotIsASCII() *String {
 	return s.NotASCII()
 }
 
+// IsPrintable succeeds if all string characters are printable.
+//
+// Characters are printable if unicode.IsPrint reports so, or if they are
+// space characters (e.g. no-break space U+00A0). Control characters,
+// including tab and newline, are not printable.
+//
+// Empty string is considered printable. On failure, first non-printable
+// character and its byte offset are reported.
+//
+// Example:
+//
+//	str := NewString(t, "Hello, world!")
+//	str.IsPrintable()
+func (s *String) IsPrintable() *String {
+	opChain := s.chain.enter("IsPrintable()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	for i, c := range s.value {
+		if !isPrintable(c) {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{s.value},
+				Errors: []error{
+					errors.New("expected: all string characters are printable"),
+					fmt.Errorf("found non-printable character %q at byte offset %d",
+						c, i),
+				},
+			})
+			break
+		}
+	}
+
+	return s
+}
+
+// NotPrintable succeeds if at least one string character is not printable.
+//
+// See IsPrintable for details.
+//
+// Example:
+//
+//	str := NewString(t, "Hello\x00")
+//	str.NotPrintable()
+func (s *String) NotPrintable() *String {
+	opChain := s.chain.enter("NotPrintable()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	for _, c := range s.value {
+		if !isPrintable(c) {
+			return s
+		}
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:   AssertValid,
+		Actual: &AssertionValue{s.value},
+		Errors: []error{
+			errors.New("expected: at least one string character is not printable"),
+		},
+	})
+
+	return s
+}
+
 // AsNumber parses float from string and returns a new Number instance
 // with result.
 //
@@ -1195,12 +1296,15 @@ func (s *String) AsNumber(base ...int) *Number {
 // AsBoolean parses true/false value string and returns a new Boolean instance
 // with result.
 //
-// Accepts string values "true", "True", "false", "False".
+// Accepts string values "true", "True", "1", "false", "False", "0".
 //
 // Example:
 //
 //	str := NewString(t, "true")
 //	str.AsBoolean().IsTrue()
+//
+//	str := NewString(t, "0")
+//	str.AsBoolean().IsFalse()
 func (s *String) AsBoolean() *Boolean {
 	opChain := s.chain.enter("AsBoolean()")
 	defer opChain.leave()
@@ -1210,10 +1314,10 @@ func (s *String) AsBoolean() *Boolean {
 	}
 
 	switch s.value {
-	case "true", "True":
+	case "true", "True", "1":
 		return newBoolean(opChain, true)
 
-	case "false", "False":
+	case "false", "False", "0":
 		return newBoolean(opChain, false)
 	}
 
@@ -1259,30 +1363,56 @@ func (s *String) AsDateTime(format ...string) *DateTime {
 			formatList = append(formatList, datetimeFormat{layout: f})
 		}
 	} else {
-		formatList = []datetimeFormat{
-			{http.TimeFormat, "RFC1123+GMT"},
+		formatList = defaultDatetimeFormats
+	}
 
-			{time.RFC850, "RFC850"},
+	tm, ok := parseDateTime(opChain, s.value, formatList)
+	if !ok {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
 
-			{time.ANSIC, "ANSIC"},
-			{time.UnixDate, "Unix"},
-			{time.RubyDate, "Ruby"},
+	return newDateTime(opChain, tm)
+}
 
-			{time.RFC1123, "RFC1123"},
-			{time.RFC1123Z, "RFC1123Z"},
-			{time.RFC822, "RFC822"},
-			{time.RFC822Z, "RFC822Z"},
-			{time.RFC3339, "RFC3339"},
-			{time.RFC3339Nano, "RFC3339+nano"},
-		}
+type datetimeFormat struct {
+	layout string
+	name   string
+}
+
+func (f datetimeFormat) String() string {
+	if f.name != "" {
+		return fmt.Sprintf("%q (%s)", f.layout, f.name)
+	} else {
+		return fmt.Sprintf("%q", f.layout)
 	}
+}
+
+var defaultDatetimeFormats = []datetimeFormat{
+	{http.TimeFormat, "RFC1123+GMT"},
 
+	{time.RFC850, "RFC850"},
+
+	{time.ANSIC, "ANSIC"},
+	{time.UnixDate, "Unix"},
+	{time.RubyDate, "Ruby"},
+
+	{time.RFC1123, "RFC1123"},
+	{time.RFC1123Z, "RFC1123Z"},
+	{time.RFC822, "RFC822"},
+	{time.RFC822Z, "RFC822Z"},
+	{time.RFC3339, "RFC3339"},
+	{time.RFC3339Nano, "RFC3339+nano"},
+}
+
+func parseDateTime(
+	opChain *chain, value string, formatList []datetimeFormat,
+) (time.Time, bool) {
 	var (
 		tm  time.Time
 		err error
 	)
 	for _, f := range formatList {
-		tm, err = time.Parse(f.layout, s.value)
+		tm, err = time.Parse(f.layout, value)
 		if err == nil {
 			break
 		}
@@ -1292,7 +1422,7 @@ func (s *String) AsDateTime(format ...string) *DateTime {
 		if len(formatList) == 1 {
 			opChain.fail(AssertionFailure{
 				Type:     AssertMatchFormat,
-				Actual:   &AssertionValue{s.value},
+				Actual:   &AssertionValue{value},
 				Expected: &AssertionValue{formatList[0]},
 				Errors: []error{
 					errors.New("expected: string can be parsed to datetime" +
@@ -1306,7 +1436,7 @@ func (s *String) AsDateTime(format ...string) *DateTime {
 			}
 			opChain.fail(AssertionFailure{
 				Type:     AssertMatchFormat,
-				Actual:   &AssertionValue{s.value},
+				Actual:   &AssertionValue{value},
 				Expected: &AssertionValue{AssertionList(expectedFormats)},
 				Errors: []error{
 					errors.New("expected: string can be parsed to datetime" +
@@ -1314,23 +1444,10 @@ func (s *String) AsDateTime(format ...string) *DateTime {
 				},
 			})
 		}
-		return newDateTime(opChain, time.Unix(0, 0))
+		return time.Time{}, false
 	}
 
-	return newDateTime(opChain, tm)
-}
-
-type datetimeFormat struct {
-	layout string
-	name   string
-}
-
-func (f datetimeFormat) String() string {
-	if f.name != "" {
-		return fmt.Sprintf("%q (%s)", f.layout, f.name)
-	} else {
-		return fmt.Sprintf("%q", f.layout)
-	}
+	return tm, true
 }
 
 // Deprecated: use AsNumber instead.
@@ -1342,3 +1459,7 @@ func (s *String) Number() *Number {
 func (s *String) DateTime(layout ...string) *DateTime {
 	return s.AsDateTime(layout...)
 }
+
+func isPrintable(c rune) bool {
+	return unicode.IsPrint(c) || unicode.Is(unicode.Zs, c)
+}
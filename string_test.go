@@ -23,6 +23,7 @@ func TestString_FailedChain(t *testing.T) {
 	value.Decode(target)
 
 	value.Length().chain.assertFailed(t)
+	value.Lines().chain.assertFailed(t)
 
 	value.IsEmpty()
 	value.NotEmpty()
@@ -48,6 +49,8 @@ func TestString_FailedChain(t *testing.T) {
 	value.NotHasSuffixFold("")
 	value.IsASCII()
 	value.NotASCII()
+	value.IsPrintable()
+	value.NotPrintable()
 
 	value.Match("").chain.assertFailed(t)
 	value.NotMatch("")
@@ -173,6 +176,64 @@ func TestString_Getters(t *testing.T) {
 	assert.Equal(t, 3.0, num.Raw())
 }
 
+func TestString_Lines(t *testing.T) {
+	cases := []struct {
+		name  string
+		str   string
+		lines []interface{}
+	}{
+		{
+			name:  "empty string",
+			str:   "",
+			lines: []interface{}{},
+		},
+		{
+			name:  "single line",
+			str:   "foo",
+			lines: []interface{}{"foo"},
+		},
+		{
+			name:  "trailing newline",
+			str:   "foo\nbar\n",
+			lines: []interface{}{"foo", "bar"},
+		},
+		{
+			name:  "no trailing newline",
+			str:   "foo\nbar",
+			lines: []interface{}{"foo", "bar"},
+		},
+		{
+			name:  "crlf",
+			str:   "foo\r\nbar\r\n",
+			lines: []interface{}{"foo", "bar"},
+		},
+		{
+			name:  "empty lines",
+			str:   "\n\nfoo\n\n",
+			lines: []interface{}{"", "", "foo", ""},
+		},
+		{
+			name:  "only newline",
+			str:   "\n",
+			lines: []interface{}{""},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewString(reporter, tc.str)
+			lines := value.Lines()
+
+			value.chain.assertNotFailed(t)
+			lines.chain.assertNotFailed(t)
+
+			assert.Equal(t, tc.lines, lines.Raw())
+		})
+	}
+}
+
 func TestString_IsEmpty(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -707,6 +768,7 @@ func TestString_IsAscii(t *testing.T) {
 		str     string
 		isASCII bool
 	}{
+		{"", true},
 		{"Ascii", true},
 		{string(rune(127)), true},
 		{"Ascii is アスキー", false},
@@ -733,6 +795,42 @@ func TestString_IsAscii(t *testing.T) {
 	}
 }
 
+func TestString_IsPrintable(t *testing.T) {
+	cases := []struct {
+		name        string
+		str         string
+		isPrintable bool
+	}{
+		{"empty", "", true},
+		{"ascii", "Hello, world!", true},
+		{"unicode", "こんにちは", true},
+		{"no-break space", "foo\u00a0bar", true},
+		{"tab", "foo\tbar", false},
+		{"newline", "foo\n", false},
+		{"null", "\x00", false},
+		{"escape", "foo\x1bbar", false},
+		{"delete", "foo\x7f", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			if tc.isPrintable {
+				NewString(reporter, tc.str).IsPrintable().
+					chain.assertNotFailed(t)
+				NewString(reporter, tc.str).NotPrintable().
+					chain.assertFailed(t)
+			} else {
+				NewString(reporter, tc.str).IsPrintable().
+					chain.assertFailed(t)
+				NewString(reporter, tc.str).NotPrintable().
+					chain.assertNotFailed(t)
+			}
+		})
+	}
+}
+
 func TestString_AsNumber(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -846,9 +944,9 @@ func TestString_AsNumber(t *testing.T) {
 }
 
 func TestString_AsBoolean(t *testing.T) {
-	trueValues := []string{"true", "True"}
-	falseValues := []string{"false", "False"}
-	badValues := []string{"TRUE", "FALSE", "t", "f", "1", "0", "bad"}
+	trueValues := []string{"true", "True", "1"}
+	falseValues := []string{"false", "False", "0"}
+	badValues := []string{"TRUE", "FALSE", "t", "f", "01", "-1", "2", "", "bad"}
 
 	for _, str := range trueValues {
 		t.Run(str, func(t *testing.T) {
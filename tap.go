@@ -0,0 +1,129 @@
+package httpexpect
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// TAPFormatter implements Formatter that formats assertions as lines of
+// Test Anything Protocol (TAP) version 13.
+//
+// Every formatted assertion gets the next test number. Success is formatted
+// as "ok N - <assertion path>", and failure as "not ok N - <assertion path>",
+// followed by indented YAML diagnostic block with failure type, assertion
+// path, request method and URL, errors, and actual and expected values.
+//
+// Failures with SeverityLog are marked with TODO directive, so that TAP
+// consumers don't treat them as errors.
+//
+// TAPFormatter doesn't print TAP version line and plan; it's up to the
+// caller to print them if the consumer requires it.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL: "http://example.com",
+//		AssertionHandler: &httpexpect.DefaultAssertionHandler{
+//			Formatter: &httpexpect.TAPFormatter{},
+//			Reporter:  httpexpect.NewAssertReporter(t),
+//			Logger:    t,
+//		},
+//	})
+type TAPFormatter struct {
+	mu      sync.Mutex
+	counter int
+}
+
+type tapDiagnostic struct {
+	Type     string   `yaml:"type"`
+	Severity string   `yaml:"severity"`
+	Path     string   `yaml:"path,omitempty"`
+	Request  string   `yaml:"request,omitempty"`
+	Errors   []string `yaml:"errors,omitempty"`
+	Actual   string   `yaml:"actual,omitempty"`
+	Expected string   `yaml:"expected,omitempty"`
+}
+
+// FormatSuccess implements Formatter.FormatSuccess.
+func (f *TAPFormatter) FormatSuccess(ctx *AssertionContext) string {
+	return fmt.Sprintf("ok %d - %s", f.next(), tapDescription(ctx))
+}
+
+// FormatFailure implements Formatter.FormatFailure.
+func (f *TAPFormatter) FormatFailure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("not ok %d - %s", f.next(), tapDescription(ctx)))
+
+	if failure.Severity == SeverityLog {
+		sb.WriteString(" # TODO non-fatal failure")
+	}
+
+	diag := tapDiagnostic{
+		Type:     failure.Type.String(),
+		Severity: failure.Severity.String(),
+		Path:     strings.Join(ctx.Path, "."),
+	}
+
+	if ctx.Request != nil && ctx.Request.httpReq != nil {
+		diag.Request = fmt.Sprintf("%s %s",
+			ctx.Request.httpReq.Method, ctx.Request.httpReq.URL)
+	}
+
+	for _, err := range failure.Errors {
+		if refIsNil(err) {
+			continue
+		}
+		diag.Errors = append(diag.Errors, err.Error())
+	}
+
+	valueFormatter := &DefaultFormatter{}
+
+	if failure.Actual != nil {
+		diag.Actual = valueFormatter.formatValue(failure.Actual.Value)
+	}
+
+	if failure.Expected != nil {
+		diag.Expected = valueFormatter.formatValue(failure.Expected.Value)
+	}
+
+	b, err := yaml.Marshal(diag)
+	if err != nil {
+		return sb.String()
+	}
+
+	sb.WriteString("\n  ---\n")
+	for _, line := range strings.Split(strings.TrimRight(string(b), "\n"), "\n") {
+		sb.WriteString("  ")
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("  ...")
+
+	return sb.String()
+}
+
+func (f *TAPFormatter) next() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.counter++
+	return f.counter
+}
+
+func tapDescription(ctx *AssertionContext) string {
+	path := ctx.AliasedPath
+	if len(path) == 0 {
+		path = ctx.Path
+	}
+
+	desc := strings.Join(path, ".")
+
+	// '#' starts directive in TAP
+	return strings.ReplaceAll(desc, "#", "\\#")
+}
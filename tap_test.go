@@ -0,0 +1,125 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTAPFormatter_Success(t *testing.T) {
+	formatter := &TAPFormatter{}
+
+	ctx := &AssertionContext{
+		Path:        []string{`Request("GET")`, `Expect()`, `Status()`},
+		AliasedPath: []string{`Request("GET")`, `Expect()`, `Status()`},
+	}
+
+	assert.Equal(t, `ok 1 - Request("GET").Expect().Status()`,
+		formatter.FormatSuccess(ctx))
+	assert.Equal(t, `ok 2 - Request("GET").Expect().Status()`,
+		formatter.FormatSuccess(ctx))
+}
+
+func TestTAPFormatter_Failure(t *testing.T) {
+	t.Run("full", func(t *testing.T) {
+		formatter := &TAPFormatter{}
+
+		httpReq, err := http.NewRequest("GET", "http://example.com/path", nil)
+		require.NoError(t, err)
+
+		ctx := &AssertionContext{
+			Path:        []string{`Request("GET")`, `Expect()`, `Status()`},
+			AliasedPath: []string{`resp`, `Status()`},
+			Request:     &Request{httpReq: httpReq},
+		}
+
+		failure := &AssertionFailure{
+			Type:     AssertEqual,
+			Severity: SeverityError,
+			Actual:   &AssertionValue{404},
+			Expected: &AssertionValue{200},
+			Errors: []error{
+				errors.New("expected: status codes are equal"),
+			},
+		}
+
+		assert.Equal(t, `ok 1 - resp.Status()`, formatter.FormatSuccess(ctx))
+
+		assert.Equal(t,
+			`not ok 2 - resp.Status()
+  ---
+  type: AssertEqual
+  severity: SeverityError
+  path: Request("GET").Expect().Status()
+  request: GET http://example.com/path
+  errors:
+  - 'expected: status codes are equal'
+  actual: "404"
+  expected: "200"
+  ...`,
+			formatter.FormatFailure(ctx, failure))
+	})
+
+	t.Run("minimal", func(t *testing.T) {
+		formatter := &TAPFormatter{}
+
+		ctx := &AssertionContext{
+			Path:        []string{`Value()`},
+			AliasedPath: []string{`Value()`},
+		}
+
+		failure := &AssertionFailure{
+			Type:     AssertUsage,
+			Severity: SeverityLog,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		}
+
+		assert.Equal(t,
+			`not ok 1 - Value() # TODO non-fatal failure
+  ---
+  type: AssertUsage
+  severity: SeverityLog
+  path: Value()
+  errors:
+  - unexpected nil argument
+  ...`,
+			formatter.FormatFailure(ctx, failure))
+	})
+
+	t.Run("escaping", func(t *testing.T) {
+		formatter := &TAPFormatter{}
+
+		ctx := &AssertionContext{
+			Path:        []string{`Value()`, `IsEqual("#1")`},
+			AliasedPath: []string{`Value()`, `IsEqual("#1")`},
+		}
+
+		assert.Equal(t, `ok 1 - Value().IsEqual("\#1")`,
+			formatter.FormatSuccess(ctx))
+	})
+}
+
+func TestTAPFormatter_Handler(t *testing.T) {
+	reporter := newMockReporter(t)
+	logger := newMockLogger(t)
+
+	config := Config{
+		AssertionHandler: &DefaultAssertionHandler{
+			Formatter: &TAPFormatter{},
+			Reporter:  reporter,
+			Logger:    logger,
+		},
+	}
+
+	NewValueC(config, 123).Number().IsEqual(123)
+	assert.Contains(t, logger.lastMessage, "ok ")
+	assert.False(t, reporter.reported)
+
+	NewValueC(config, 123).Number().IsEqual(456)
+	assert.True(t, reporter.reported)
+}
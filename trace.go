@@ -0,0 +1,308 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// TraceInfo holds timestamps of request phases, collected using
+// net/http/httptrace when Request.WithTrace is used.
+//
+// Zero timestamp means that the phase didn't happen. For example, when
+// connection is reused, DNS lookup, connect, and TLS handshake are skipped
+// and ConnReused is true.
+//
+// If request was redirected or retried, timestamps are collected for the
+// last sent request.
+type TraceInfo struct {
+	// Time when client started to obtain connection
+	Start time.Time
+
+	DNSStart time.Time
+	DNSDone  time.Time
+
+	ConnectStart time.Time
+	ConnectDone  time.Time
+
+	TLSHandshakeStart time.Time
+	TLSHandshakeDone  time.Time
+
+	// Time when connection was obtained
+	GotConn time.Time
+
+	// Time when first byte of response headers was received
+	FirstByte time.Time
+
+	// Whether connection was reused from previous request
+	ConnReused bool
+}
+
+// Trace provides methods to inspect attached TraceInfo value.
+type Trace struct {
+	noCopy noCopy
+	chain  *chain
+	value  *TraceInfo
+}
+
+// NewTrace returns a new Trace instance.
+//
+// If reporter is nil, the function panics.
+// If value is nil, failure is reported.
+//
+// Example:
+//
+//	trace := NewTrace(t, &TraceInfo{...})
+//	trace.FirstByte().Lt(time.Second)
+func NewTrace(reporter Reporter, value *TraceInfo) *Trace {
+	return newTrace(newChainWithDefaults("Trace()", reporter), value)
+}
+
+// NewTraceC returns a new Trace instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is nil, failure is reported.
+//
+// See NewTrace for usage example.
+func NewTraceC(config Config, value *TraceInfo) *Trace {
+	return newTrace(newChainWithConfig("Trace()", config.withDefaults()), value)
+}
+
+func newTrace(parent *chain, val *TraceInfo) *Trace {
+	t := &Trace{chain: parent.clone(), value: nil}
+
+	opChain := t.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil trace"),
+			},
+		})
+	} else {
+		t.value = val
+	}
+
+	return t
+}
+
+// Raw returns underlying TraceInfo value attached to Trace.
+// This is the value originally passed to NewTrace.
+//
+// Example:
+//
+//	trace := NewTrace(t, info)
+//	assert.Equal(t, info, trace.Raw())
+func (t *Trace) Raw() *TraceInfo {
+	return t.value
+}
+
+// Alias is similar to Value.Alias.
+func (t *Trace) Alias(name string) *Trace {
+	opChain := t.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	t.chain.setAlias(name)
+	return t
+}
+
+// DNS returns a new Duration instance with duration of DNS lookup.
+//
+// If DNS lookup didn't happen, e.g. because connection was reused or
+// host is an IP address, the returned Duration is not set.
+//
+// Example:
+//
+//	trace := NewTrace(t, &TraceInfo{...})
+//	trace.DNS().Lt(100 * time.Millisecond)
+func (t *Trace) DNS() *Duration {
+	opChain := t.chain.enter("DNS()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	return newDuration(opChain, traceInterval(t.value.DNSStart, t.value.DNSDone))
+}
+
+// Connect returns a new Duration instance with duration of establishing
+// new connection.
+//
+// If connection was reused, the returned Duration is not set.
+//
+// Example:
+//
+//	trace := NewTrace(t, &TraceInfo{...})
+//	trace.Connect().Lt(100 * time.Millisecond)
+func (t *Trace) Connect() *Duration {
+	opChain := t.chain.enter("Connect()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	return newDuration(opChain,
+		traceInterval(t.value.ConnectStart, t.value.ConnectDone))
+}
+
+// TLSHandshake returns a new Duration instance with duration of TLS handshake.
+//
+// If connection was reused or TLS wasn't used, the returned Duration is
+// not set.
+//
+// Example:
+//
+//	trace := NewTrace(t, &TraceInfo{...})
+//	trace.TLSHandshake().Lt(100 * time.Millisecond)
+func (t *Trace) TLSHandshake() *Duration {
+	opChain := t.chain.enter("TLSHandshake()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	return newDuration(opChain,
+		traceInterval(t.value.TLSHandshakeStart, t.value.TLSHandshakeDone))
+}
+
+// FirstByte returns a new Duration instance with time interval from
+// the moment when client started to obtain connection until first byte
+// of response was received.
+//
+// Example:
+//
+//	trace := NewTrace(t, &TraceInfo{...})
+//	trace.FirstByte().Lt(time.Second)
+func (t *Trace) FirstByte() *Duration {
+	opChain := t.chain.enter("FirstByte()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	return newDuration(opChain, traceInterval(t.value.Start, t.value.FirstByte))
+}
+
+// ConnReused returns a new Boolean instance that is true if request was
+// sent over connection reused from previous request.
+//
+// Example:
+//
+//	trace := NewTrace(t, &TraceInfo{...})
+//	trace.ConnReused().IsTrue()
+func (t *Trace) ConnReused() *Boolean {
+	opChain := t.chain.enter("ConnReused()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newBoolean(opChain, false)
+	}
+
+	return newBoolean(opChain, t.value.ConnReused)
+}
+
+func traceInterval(start, end time.Time) *time.Duration {
+	if start.IsZero() || end.IsZero() {
+		return nil
+	}
+
+	d := end.Sub(start)
+	return &d
+}
+
+// Collects TraceInfo using httptrace hooks
+// Hooks may be called from different goroutines
+type traceRecorder struct {
+	mu   sync.Mutex
+	info TraceInfo
+}
+
+func (tr *traceRecorder) update(fn func(info *TraceInfo)) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	fn(&tr.info)
+}
+
+func (tr *traceRecorder) snapshot() *TraceInfo {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	info := tr.info
+	return &info
+}
+
+func (tr *traceRecorder) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GetConn: func(string) {
+			now := time.Now()
+			tr.update(func(info *TraceInfo) {
+				// new request (retry or redirect), start over
+				*info = TraceInfo{Start: now}
+			})
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			now := time.Now()
+			tr.update(func(info *TraceInfo) {
+				info.DNSStart = now
+			})
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			now := time.Now()
+			tr.update(func(info *TraceInfo) {
+				info.DNSDone = now
+			})
+		},
+		ConnectStart: func(string, string) {
+			now := time.Now()
+			tr.update(func(info *TraceInfo) {
+				// with multiple addresses, connect may be attempted several times
+				if info.ConnectStart.IsZero() {
+					info.ConnectStart = now
+				}
+			})
+		},
+		ConnectDone: func(_, _ string, err error) {
+			now := time.Now()
+			tr.update(func(info *TraceInfo) {
+				if err == nil {
+					info.ConnectDone = now
+				}
+			})
+		},
+		TLSHandshakeStart: func() {
+			now := time.Now()
+			tr.update(func(info *TraceInfo) {
+				info.TLSHandshakeStart = now
+			})
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			now := time.Now()
+			tr.update(func(info *TraceInfo) {
+				info.TLSHandshakeDone = now
+			})
+		},
+		GotConn: func(connInfo httptrace.GotConnInfo) {
+			now := time.Now()
+			tr.update(func(info *TraceInfo) {
+				info.GotConn = now
+				info.ConnReused = connInfo.Reused
+			})
+		},
+		GotFirstResponseByte: func() {
+			now := time.Now()
+			tr.update(func(info *TraceInfo) {
+				info.FirstByte = now
+			})
+		},
+	}
+}
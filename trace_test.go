@@ -0,0 +1,147 @@
+package httpexpect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrace_FailedChain(t *testing.T) {
+	check := func(value *Trace, isNil bool) {
+		value.chain.assert(t, failure)
+
+		if isNil {
+			assert.Nil(t, value.Raw())
+		} else {
+			assert.NotNil(t, value.Raw())
+		}
+
+		value.Alias("foo")
+
+		value.DNS().chain.assert(t, failure)
+		value.Connect().chain.assert(t, failure)
+		value.TLSHandshake().chain.assert(t, failure)
+		value.FirstByte().chain.assert(t, failure)
+		value.ConnReused().chain.assert(t, failure)
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newFailedChain(t)
+		value := newTrace(chain, &TraceInfo{})
+
+		check(value, false)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newTrace(chain, nil)
+
+		check(value, true)
+	})
+
+	t.Run("failed chain, nil value", func(t *testing.T) {
+		chain := newFailedChain(t)
+		value := newTrace(chain, nil)
+
+		check(value, true)
+	})
+}
+
+func TestTrace_Constructors(t *testing.T) {
+	start := time.Unix(1000, 0)
+
+	info := &TraceInfo{
+		Start:     start,
+		FirstByte: start.Add(time.Second),
+	}
+
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewTrace(reporter, info)
+		value.FirstByte().IsEqual(time.Second)
+		value.chain.assert(t, success)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewTraceC(Config{
+			Reporter: reporter,
+		}, info)
+		value.FirstByte().IsEqual(time.Second)
+		value.chain.assert(t, success)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newTrace(chain, info)
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestTrace_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewTrace(reporter, &TraceInfo{})
+	assert.Equal(t, []string{"Trace()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"Trace()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"Trace()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+}
+
+func TestTrace_Getters(t *testing.T) {
+	start := time.Unix(1000, 0)
+
+	t.Run("new connection", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		info := &TraceInfo{
+			Start:             start,
+			DNSStart:          start.Add(1 * time.Millisecond),
+			DNSDone:           start.Add(3 * time.Millisecond),
+			ConnectStart:      start.Add(4 * time.Millisecond),
+			ConnectDone:       start.Add(7 * time.Millisecond),
+			TLSHandshakeStart: start.Add(8 * time.Millisecond),
+			TLSHandshakeDone:  start.Add(12 * time.Millisecond),
+			GotConn:           start.Add(13 * time.Millisecond),
+			FirstByte:         start.Add(20 * time.Millisecond),
+		}
+
+		value := NewTrace(reporter, info)
+
+		assert.Same(t, info, value.Raw())
+
+		value.DNS().IsEqual(2 * time.Millisecond)
+		value.Connect().IsEqual(3 * time.Millisecond)
+		value.TLSHandshake().IsEqual(4 * time.Millisecond)
+		value.FirstByte().IsEqual(20 * time.Millisecond)
+		value.ConnReused().IsFalse()
+
+		value.chain.assert(t, success)
+	})
+
+	t.Run("reused connection", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewTrace(reporter, &TraceInfo{
+			Start:      start,
+			GotConn:    start.Add(1 * time.Millisecond),
+			FirstByte:  start.Add(5 * time.Millisecond),
+			ConnReused: true,
+		})
+
+		value.DNS().NotSet()
+		value.Connect().NotSet()
+		value.TLSHandshake().NotSet()
+		value.FirstByte().IsEqual(5 * time.Millisecond)
+		value.ConnReused().IsTrue()
+
+		value.chain.assert(t, success)
+
+		value.TLSHandshake().Lt(time.Second).
+			chain.assert(t, failure)
+	})
+}
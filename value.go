@@ -2,7 +2,10 @@ package httpexpect
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 )
 
 // Value provides methods to inspect attached interface{} object
@@ -75,6 +78,157 @@ func (v *Value) Raw() interface{} {
 	return v.value
 }
 
+// RawString returns underlying value as string.
+//
+// If underlying value is not a string, failure is reported and
+// empty string is returned.
+//
+// Example:
+//
+//	value := NewValue(t, "foo")
+//	assert.Equal(t, "foo", value.RawString())
+func (v *Value) RawString() string {
+	opChain := v.chain.enter("RawString()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return ""
+	}
+
+	data, ok := v.value.(string)
+
+	if !ok {
+		v.failRawType(opChain, "string")
+		return ""
+	}
+
+	return data
+}
+
+// RawNumber returns underlying value as float64.
+//
+// If underlying value is not a number, failure is reported and
+// zero is returned.
+//
+// Example:
+//
+//	value := NewValue(t, 123)
+//	assert.Equal(t, 123.0, value.RawNumber())
+func (v *Value) RawNumber() float64 {
+	opChain := v.chain.enter("RawNumber()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return 0
+	}
+
+	data, ok := v.value.(float64)
+
+	if !ok {
+		v.failRawType(opChain, "number")
+		return 0
+	}
+
+	return data
+}
+
+// RawBool returns underlying value as bool.
+//
+// If underlying value is not a boolean, failure is reported and
+// false is returned.
+//
+// Example:
+//
+//	value := NewValue(t, true)
+//	assert.True(t, value.RawBool())
+func (v *Value) RawBool() bool {
+	opChain := v.chain.enter("RawBool()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return false
+	}
+
+	data, ok := v.value.(bool)
+
+	if !ok {
+		v.failRawType(opChain, "boolean")
+		return false
+	}
+
+	return data
+}
+
+// RawMap returns underlying value as map[string]interface{}.
+//
+// If underlying value is not an object, failure is reported and
+// nil is returned.
+//
+// Example:
+//
+//	value := NewValue(t, map[string]interface{}{"foo": 123})
+//	assert.Equal(t, 1, len(value.RawMap()))
+func (v *Value) RawMap() map[string]interface{} {
+	opChain := v.chain.enter("RawMap()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return nil
+	}
+
+	data, ok := v.value.(map[string]interface{})
+
+	if !ok {
+		v.failRawType(opChain, "object")
+		return nil
+	}
+
+	return data
+}
+
+// RawSlice returns underlying value as []interface{}.
+//
+// If underlying value is not an array, failure is reported and
+// nil is returned.
+//
+// Example:
+//
+//	value := NewValue(t, []interface{}{"foo", 123})
+//	assert.Equal(t, 2, len(value.RawSlice()))
+func (v *Value) RawSlice() []interface{} {
+	opChain := v.chain.enter("RawSlice()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return nil
+	}
+
+	data, ok := v.value.([]interface{})
+
+	if !ok {
+		v.failRawType(opChain, "array")
+		return nil
+	}
+
+	return data
+}
+
+func (v *Value) failRawType(opChain *chain, expected string) {
+	actual, ok := valueTypeName(v.value)
+	if !ok {
+		actual = fmt.Sprintf("%T", v.value)
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:   AssertValid,
+		Actual: &AssertionValue{v.value},
+		Errors: []error{
+			fmt.Errorf("expected: value is %s", expected),
+			fmt.Errorf("expected %s value, but got %s value", expected, actual),
+		},
+	})
+}
+
 // Decode unmarshals the underlying value attached to the Object to a target variable
 // target should be pointer to any type.
 //
@@ -149,6 +303,10 @@ func (v *Value) Alias(name string) *Value {
 // only a subset of JSONPath, yet useful for simple queries. It doesn't
 // support filters and requires double quotes for strings.
 //
+// If a key is missing or an index is out of range, failure is reported
+// naming the path segment that couldn't be resolved, and a Value with
+// nil is returned.
+//
 // Example 1:
 //
 //	json := `{"users": [{"name": "john"}, {"name": "bob"}]}`
@@ -183,6 +341,14 @@ func (v *Value) Path(path string) *Value {
 //   - type convertible to string containing valid schema
 //   - type convertible to string containing valid http:// or file:// URI,
 //     pointing to reachable and valid schema
+//   - type convertible to string containing path to existing file with
+//     valid schema
+//
+// Each schema violation is reported as a separate error, containing path
+// to the offending field and the name of the violated rule.
+//
+// If schema itself is invalid or can't be loaded, failure of type
+// AssertUsage is reported instead.
 //
 // Example 1:
 //
@@ -383,12 +549,121 @@ func (v *Value) Boolean() *Boolean {
 	return newBoolean(opChain, data)
 }
 
+// DateTime parses underlying string value and returns a new DateTime
+// instance with result.
+//
+// If layout is given, DateTime() tries every given layout in order, and
+// then falls back to the list of predefined common formats (see
+// String.AsDateTime). The first matching layout wins.
+//
+// If underlying value is not a string, or it can't be parsed with any
+// layout, failure is reported and empty (but non-nil) value is returned.
+//
+// Example:
+//
+//	value := NewValue(t, "02/01/2006 15:04")
+//	value.DateTime("02/01/2006 15:04").Lt(time.Now())
+func (v *Value) DateTime(layout ...string) *DateTime {
+	opChain := v.chain.enter("DateTime()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	data, ok := v.value.(string)
+
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				errors.New("expected: value is string"),
+			},
+		})
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	var formatList []datetimeFormat
+	for _, l := range layout {
+		formatList = append(formatList, datetimeFormat{layout: l})
+	}
+	formatList = append(formatList, defaultDatetimeFormats...)
+
+	tm, ok := parseDateTime(opChain, data, formatList)
+	if !ok {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	return newDateTime(opChain, tm)
+}
+
+// Type returns a new String instance with name of underlying value type.
+//
+// Returned name is one of: "object", "array", "string", "number",
+// "boolean", "null".
+//
+// If value is already failed, e.g. it was obtained using Object.Value with
+// absent key, returned String is failed too and holds empty string, so
+// missing value is never confused with a value of any type.
+//
+// Example:
+//
+//	value := NewValue(t, []interface{}{1, 2, 3})
+//	value.Type().IsEqual("array")
+func (v *Value) Type() *String {
+	opChain := v.chain.enter("Type()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	typeName, ok := valueTypeName(v.value)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				fmt.Errorf("unexpected value type %T", v.value),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, typeName)
+}
+
+func valueTypeName(value interface{}) (string, bool) {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object", true
+	case []interface{}:
+		return "array", true
+	case string:
+		return "string", true
+	case float64:
+		return "number", true
+	case bool:
+		return "boolean", true
+	case nil:
+		return "null", true
+	}
+	return "", false
+}
+
 // IsNull succeeds if value is nil.
 //
 // Note that non-nil interface{} that points to nil value (e.g. nil slice or map)
 // is also treated as null value. Empty (non-nil) slice or map, empty string, and
 // zero number are not treated as null value.
 //
+// IsNull checks the value itself, not presence of the key it was obtained
+// from. If value was obtained using Object.Value with absent key, failure
+// "key is absent" is already reported by Object.Value, and IsNull doesn't
+// report anything. So absent key and key with null value are never
+// confused.
+//
 // Example:
 //
 //	value := NewValue(t, nil)
@@ -423,6 +698,9 @@ func (v *Value) IsNull() *Value {
 // is also treated as null value. Empty (non-nil) slice or map, empty string, and
 // zero number are not treated as null value.
 //
+// Like IsNull, NotNull doesn't report anything for value obtained using
+// Object.Value with absent key, since the absence is already reported.
+//
 // Example:
 //
 //	value := NewValue(t, "")
@@ -925,3 +1203,225 @@ func (v *Value) NotInList(values ...interface{}) *Value {
 
 	return v
 }
+
+// Contains succeeds if value contains given element. The meaning of
+// "contains" depends on the underlying value type:
+//   - for string, element should be a string, and value should contain
+//     it as a sub-string (see String.Contains)
+//   - for array, value should contain an element equal to given one
+//     (see Array.ContainsAll)
+//   - for object, element should be map or struct, and value should
+//     contain it as a subset (see Object.ContainsSubset)
+//
+// For other types (number, boolean, null), failure is reported.
+// Before comparison, all values are converted to canonical form.
+//
+// Example:
+//
+//	value := NewValue(t, "foobar")
+//	value.Contains("foo")
+//
+//	value := NewValue(t, []interface{}{"foo", 123})
+//	value.Contains(123)
+//
+//	value := NewValue(t, map[string]interface{}{"foo": 123, "bar": 456})
+//	value.Contains(map[string]interface{}{"foo": 123})
+func (v *Value) Contains(element interface{}) *Value {
+	opChain := v.chain.enter("Contains()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	switch value := v.value.(type) {
+	case string:
+		substr, ok := element.(string)
+		if !ok {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf(
+						"unexpected element type %T for string value, expected string",
+						element),
+				},
+			})
+			return v
+		}
+
+		if !strings.Contains(value, substr) {
+			opChain.fail(AssertionFailure{
+				Type:     AssertContainsSubset,
+				Actual:   &AssertionValue{value},
+				Expected: &AssertionValue{substr},
+				Errors: []error{
+					errors.New("expected: string value contains sub-string"),
+				},
+			})
+		}
+
+	case []interface{}:
+		expected, ok := canonValue(opChain, element)
+		if !ok {
+			return v
+		}
+
+		if countElement(value, expected) == 0 {
+			opChain.fail(AssertionFailure{
+				Type:     AssertContainsElement,
+				Actual:   &AssertionValue{value},
+				Expected: &AssertionValue{expected},
+				Errors: []error{
+					errors.New("expected: array value contains element"),
+				},
+			})
+		}
+
+	case map[string]interface{}:
+		ok, mismatch := containsSubset(opChain, value, element)
+		if opChain.failed() {
+			return v
+		}
+
+		if !ok {
+			errs := []error{
+				errors.New("expected: object value contains sub-object"),
+			}
+			if mismatch != nil {
+				errs = append(errs, mismatch)
+			}
+
+			opChain.fail(AssertionFailure{
+				Type:     AssertContainsSubset,
+				Actual:   &AssertionValue{value},
+				Expected: &AssertionValue{element},
+				Errors:   errs,
+			})
+		}
+
+	default:
+		typeName, ok := valueTypeName(v.value)
+		if !ok {
+			typeName = fmt.Sprintf("%T", v.value)
+		}
+
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					"can't check whether %s value contains element,"+
+						" expected string, array, or object value",
+					typeName),
+			},
+		})
+	}
+
+	return v
+}
+
+// IsEmpty succeeds if value is empty.
+//
+// Value is considered empty if it is:
+//   - null
+//   - empty string
+//   - array with zero elements
+//   - object with zero keys
+//   - zero number
+//   - false boolean
+//
+// Example:
+//
+//	value := NewValue(t, "")
+//	value.IsEmpty()
+//
+//	value := NewValue(t, []interface{}{})
+//	value.IsEmpty()
+func (v *Value) IsEmpty() *Value {
+	opChain := v.chain.enter("IsEmpty()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	if empty, reason := valueEmptiness(v.value); !empty {
+		opChain.fail(AssertionFailure{
+			Type:   AssertEmpty,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				errors.New("expected: value is empty"),
+				errors.New(reason),
+			},
+		})
+	}
+
+	return v
+}
+
+// NotEmpty succeeds if value is not empty.
+//
+// See IsEmpty for the list of values considered empty.
+//
+// Example:
+//
+//	value := NewValue(t, "foo")
+//	value.NotEmpty()
+//
+//	value := NewValue(t, []interface{}{"foo"})
+//	value.NotEmpty()
+func (v *Value) NotEmpty() *Value {
+	opChain := v.chain.enter("NotEmpty()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	if empty, reason := valueEmptiness(v.value); empty {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				errors.New("expected: value is non-empty"),
+				errors.New(reason),
+			},
+		})
+	}
+
+	return v
+}
+
+// valueEmptiness reports whether value is empty and explains why.
+func valueEmptiness(value interface{}) (bool, string) {
+	switch value := value.(type) {
+	case nil:
+		return true, "null value is empty"
+	case string:
+		if value == "" {
+			return true, "string value has zero length"
+		}
+		return false, fmt.Sprintf("string value has length %d", len(value))
+	case []interface{}:
+		if len(value) == 0 {
+			return true, "array value has zero elements"
+		}
+		return false, fmt.Sprintf("array value has %d element(s)", len(value))
+	case map[string]interface{}:
+		if len(value) == 0 {
+			return true, "object value has zero keys"
+		}
+		return false, fmt.Sprintf("object value has %d key(s)", len(value))
+	case float64:
+		if value == 0 {
+			return true, "number value is zero"
+		}
+		return false, fmt.Sprintf("number value is %v, not zero", value)
+	case bool:
+		if !value {
+			return true, "boolean value is false"
+		}
+		return false, "boolean value is true"
+	default:
+		return false, fmt.Sprintf("value of type %T is never empty", value)
+	}
+}
@@ -2,9 +2,14 @@ package httpexpect
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,6 +33,14 @@ func TestValue_FailedChain(t *testing.T) {
 	value.String().chain.assert(t, failure)
 	value.Number().chain.assert(t, failure)
 	value.Boolean().chain.assert(t, failure)
+	value.DateTime().chain.assert(t, failure)
+	value.Type().chain.assert(t, failure)
+
+	assert.Equal(t, "", value.RawString())
+	assert.Equal(t, 0.0, value.RawNumber())
+	assert.Equal(t, false, value.RawBool())
+	assert.Nil(t, value.RawMap())
+	assert.Nil(t, value.RawSlice())
 
 	value.IsNull()
 	value.NotNull()
@@ -45,6 +58,9 @@ func TestValue_FailedChain(t *testing.T) {
 	value.NotEqual(nil)
 	value.InList(nil)
 	value.NotInList(nil)
+	value.Contains(nil)
+	value.IsEmpty()
+	value.NotEmpty()
 }
 
 func TestValue_Constructors(t *testing.T) {
@@ -254,6 +270,127 @@ func TestValue_Getters(t *testing.T) {
 	})
 }
 
+func TestValue_NullAndAbsent(t *testing.T) {
+	data := map[string]interface{}{
+		"null": nil,
+		"zero": 0.0,
+	}
+
+	t.Run("null key", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewObject(reporter, data).Value("null").IsNull().
+			chain.assert(t, success)
+		NewObject(reporter, data).Value("null").NotNull().
+			chain.assert(t, failure)
+	})
+
+	t.Run("non-null key", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewObject(reporter, data).Value("zero").IsNull().
+			chain.assert(t, failure)
+		NewObject(reporter, data).Value("zero").NotNull().
+			chain.assert(t, success)
+	})
+
+	t.Run("absent key", func(t *testing.T) {
+		for _, check := range []func(*Value) *Value{
+			(*Value).IsNull,
+			(*Value).NotNull,
+		} {
+			handler := &mockAssertionHandler{}
+
+			object := NewObjectC(Config{
+				AssertionHandler: handler,
+			}, data)
+
+			value := object.Value("absent")
+			value.chain.assert(t, failure)
+
+			require.NotNil(t, handler.failure)
+			assert.Equal(t, AssertContainsKey, handler.failure.Type)
+			assert.Contains(t, handler.failure.Errors[1].Error(), `"absent" is absent`)
+
+			handler.failure = nil
+
+			// absence is already reported, null check doesn't add another failure
+			check(value)
+			assert.Nil(t, handler.failure)
+		}
+	})
+}
+
+func TestValue_RawTyped(t *testing.T) {
+	t.Run("matching types", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, "foo")
+		assert.Equal(t, "foo", value.RawString())
+		value.chain.assert(t, success)
+
+		value = NewValue(reporter, 123)
+		assert.Equal(t, 123.0, value.RawNumber())
+		value.chain.assert(t, success)
+
+		value = NewValue(reporter, true)
+		assert.Equal(t, true, value.RawBool())
+		value.chain.assert(t, success)
+
+		value = NewValue(reporter, map[string]interface{}{"foo": 123})
+		assert.Equal(t, map[string]interface{}{"foo": 123.0}, value.RawMap())
+		value.chain.assert(t, success)
+
+		value = NewValue(reporter, []interface{}{"foo", 123})
+		assert.Equal(t, []interface{}{"foo", 123.0}, value.RawSlice())
+		value.chain.assert(t, success)
+	})
+
+	t.Run("mismatching types", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, 123)
+		assert.Equal(t, "", value.RawString())
+		value.chain.assert(t, failure)
+
+		value = NewValue(reporter, "foo")
+		assert.Equal(t, 0.0, value.RawNumber())
+		value.chain.assert(t, failure)
+
+		value = NewValue(reporter, nil)
+		assert.Equal(t, false, value.RawBool())
+		value.chain.assert(t, failure)
+
+		value = NewValue(reporter, []interface{}{})
+		assert.Nil(t, value.RawMap())
+		value.chain.assert(t, failure)
+
+		value = NewValue(reporter, map[string]interface{}{})
+		assert.Nil(t, value.RawSlice())
+		value.chain.assert(t, failure)
+	})
+
+	t.Run("failure message", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		NewValueC(Config{AssertionHandler: handler}, 123).RawString()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertValid, handler.failure.Type)
+		assert.Equal(t, &AssertionValue{123.0}, handler.failure.Actual)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("expected string value, but got number value"))
+
+		handler = &mockAssertionHandler{}
+
+		NewValueC(Config{AssertionHandler: handler}, nil).RawMap()
+
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("expected object value, but got null value"))
+	})
+}
+
 func TestValue_GetObject(t *testing.T) {
 	type myMap map[string]interface{}
 
@@ -435,6 +572,142 @@ func TestValue_GetBoolean(t *testing.T) {
 	}
 }
 
+func TestValue_GetDateTime(t *testing.T) {
+	cases := []struct {
+		name         string
+		data         interface{}
+		layouts      []string
+		result       chainResult
+		expectedTime time.Time
+	}{
+		{
+			name:         "default format",
+			data:         "2006-01-02T15:04:05Z",
+			result:       success,
+			expectedTime: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:         "custom layout",
+			data:         "02/01/2006 15:04",
+			layouts:      []string{"02/01/2006 15:04"},
+			result:       success,
+			expectedTime: time.Date(2006, 1, 2, 15, 4, 0, 0, time.UTC),
+		},
+		{
+			name:         "first matching layout wins",
+			data:         "02/01/2006 15:04",
+			layouts:      []string{"2006-01-02", "01/02/2006 15:04", "02/01/2006 15:04"},
+			result:       success,
+			expectedTime: time.Date(2006, 2, 1, 15, 4, 0, 0, time.UTC),
+		},
+		{
+			name:         "fallback to default format",
+			data:         "2006-01-02T15:04:05Z",
+			layouts:      []string{"02/01/2006 15:04"},
+			result:       success,
+			expectedTime: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:    "no matching layout",
+			data:    "bad",
+			layouts: []string{"02/01/2006 15:04"},
+			result:  failure,
+		},
+		{
+			name:   "not a string",
+			data:   123,
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewValue(reporter, tc.data)
+			inner := value.DateTime(tc.layouts...)
+
+			value.chain.assert(t, tc.result)
+			inner.chain.assert(t, tc.result)
+
+			if tc.result {
+				assert.True(t, tc.expectedTime.Equal(inner.Raw()))
+			}
+		})
+	}
+
+	t.Run("failure lists layouts", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		value := NewValueC(Config{
+			AssertionHandler: handler,
+		}, "bad")
+
+		value.DateTime("02/01/2006 15:04")
+		value.chain.assert(t, failure)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertMatchFormat, handler.failure.Type)
+		assert.Equal(t, &AssertionValue{"bad"}, handler.failure.Actual)
+
+		formats, ok := handler.failure.Expected.Value.(AssertionList)
+		require.True(t, ok)
+		assert.Equal(t, 1+len(defaultDatetimeFormats), len(formats))
+		assert.Equal(t, `"02/01/2006 15:04"`, fmt.Sprint(formats[0]))
+	})
+}
+
+func TestValue_Type(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    interface{}
+		typeName string
+	}{
+		{"object", map[string]interface{}{"foo": 123}, "object"},
+		{"empty object", map[string]interface{}{}, "object"},
+		{"array", []interface{}{"foo", 123}, "array"},
+		{"empty array", []interface{}{}, "array"},
+		{"string", "foo", "string"},
+		{"empty string", "", "string"},
+		{"number", 123, "number"},
+		{"float", 1.5, "number"},
+		{"boolean", true, "boolean"},
+		{"null", nil, "null"},
+		{"nil slice", []interface{}(nil), "null"},
+		{"struct", struct{ Foo int }{123}, "object"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewValue(reporter, tc.value)
+
+			typeName := value.Type()
+			typeName.chain.assert(t, success)
+			assert.Equal(t, tc.typeName, typeName.Raw())
+
+			typeName.IsEqual(tc.typeName)
+			typeName.chain.assert(t, success)
+			value.chain.assert(t, success)
+		})
+	}
+
+	t.Run("absent key", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		object := NewObject(reporter, map[string]interface{}{"foo": nil})
+
+		typeName := object.Value("foo").Type()
+		typeName.chain.assert(t, success)
+		assert.Equal(t, "null", typeName.Raw())
+
+		typeName = object.Value("bar").Type()
+		typeName.chain.assert(t, failure)
+		assert.Equal(t, "", typeName.Raw())
+	})
+}
+
 func TestValue_IsObject(t *testing.T) {
 	cases := []struct {
 		name       string
@@ -575,6 +848,24 @@ func TestValue_IsEqual(t *testing.T) {
 
 	NewValue(reporter, data1).IsEqual(func() {}).chain.assert(t, failure)
 	NewValue(reporter, data1).NotEqual(func() {}).chain.assert(t, failure)
+
+	data3 := map[string]interface{}{
+		"foo": []interface{}{1.0, 2.0},
+		"bar": map[string]interface{}{"baz": 3.0},
+	}
+
+	NewValue(reporter, data3).IsEqual(map[string]interface{}{
+		"foo": []int{1, 2},
+		"bar": map[string]int64{"baz": 3},
+	}).chain.assert(t, success)
+
+	NewValue(reporter, data3).NotEqual(map[string]interface{}{
+		"foo": []int{1, 2},
+		"bar": map[string]int64{"baz": 3},
+	}).chain.assert(t, failure)
+
+	NewValue(reporter, int64(123)).IsEqual(123.0).chain.assert(t, success)
+	NewValue(reporter, 123.0).IsEqual(uint8(123)).chain.assert(t, success)
 }
 
 func TestValue_InList(t *testing.T) {
@@ -616,6 +907,190 @@ func TestValue_InList(t *testing.T) {
 	NewValue(reporter, data1).NotInList(data2, func() {}).chain.assert(t, failure)
 }
 
+func TestValue_Contains(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   interface{}
+		element interface{}
+		result  chainResult
+	}{
+		{"string", "foobar", "oba", success},
+		{"string, empty element", "foobar", "", success},
+		{"string, no sub-string", "foobar", "baz", failure},
+		{"string, non-string element", "123", 123, failure},
+		{"array", []interface{}{"foo", 123.0}, 123, success},
+		{"array, nested", []interface{}{[]interface{}{"foo"}}, []string{"foo"}, success},
+		{"array, no element", []interface{}{"foo", 123.0}, "bar", failure},
+		{"array, invalid element", []interface{}{"foo"}, func() {}, failure},
+		{"object", map[string]interface{}{"foo": 123.0, "bar": "baz"},
+			map[string]interface{}{"foo": 123}, success},
+		{"object, struct element", map[string]interface{}{"foo": 123.0, "bar": "baz"},
+			struct {
+				Foo int `json:"foo"`
+			}{123}, success},
+		{"object, missing key", map[string]interface{}{"foo": 123.0},
+			map[string]interface{}{"bar": 123}, failure},
+		{"object, mismatched value", map[string]interface{}{"foo": 123.0},
+			map[string]interface{}{"foo": 456}, failure},
+		{"object, non-map element", map[string]interface{}{"foo": 123.0},
+			"foo", failure},
+		{"number", 123, 1, failure},
+		{"boolean", true, true, failure},
+		{"null", nil, nil, failure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewValue(reporter, tc.value).Contains(tc.element).
+				chain.assert(t, tc.result)
+		})
+	}
+
+	t.Run("failure types", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			value    interface{}
+			element  interface{}
+			failType AssertionType
+			errMsg   string
+		}{
+			{"string", "foo", "bar",
+				AssertContainsSubset, "string value"},
+			{"array", []interface{}{"foo"}, "bar",
+				AssertContainsElement, "array value"},
+			{"object", map[string]interface{}{"foo": "bar"},
+				map[string]interface{}{"foo": "baz"},
+				AssertContainsSubset, "object value"},
+			{"number", 123, 123,
+				AssertUsage, "number value"},
+			{"boolean", false, false,
+				AssertUsage, "boolean value"},
+			{"null", nil, nil,
+				AssertUsage, "null value"},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				handler := &mockAssertionHandler{}
+
+				NewValueC(Config{
+					AssertionHandler: handler,
+				}, tc.value).Contains(tc.element)
+
+				require.NotNil(t, handler.failure)
+				assert.Equal(t, tc.failType, handler.failure.Type)
+				require.NotEmpty(t, handler.failure.Errors)
+				assert.Contains(t, handler.failure.Errors[0].Error(), tc.errMsg)
+			})
+		}
+	})
+}
+
+func TestValue_IsEmpty(t *testing.T) {
+	cases := []struct {
+		name      string
+		value     interface{}
+		wantEmpty chainResult
+		wantError string
+	}{
+		{
+			name:      "null",
+			value:     nil,
+			wantEmpty: success,
+			wantError: "null value is empty",
+		},
+		{
+			name:      "empty string",
+			value:     "",
+			wantEmpty: success,
+			wantError: "string value has zero length",
+		},
+		{
+			name:      "string",
+			value:     "foo",
+			wantEmpty: failure,
+			wantError: "string value has length 3",
+		},
+		{
+			name:      "empty array",
+			value:     []interface{}{},
+			wantEmpty: success,
+			wantError: "array value has zero elements",
+		},
+		{
+			name:      "array",
+			value:     []interface{}{"foo", 123},
+			wantEmpty: failure,
+			wantError: "array value has 2 element(s)",
+		},
+		{
+			name:      "empty object",
+			value:     map[string]interface{}{},
+			wantEmpty: success,
+			wantError: "object value has zero keys",
+		},
+		{
+			name:      "object",
+			value:     map[string]interface{}{"foo": 123},
+			wantEmpty: failure,
+			wantError: "object value has 1 key(s)",
+		},
+		{
+			name:      "zero number",
+			value:     0,
+			wantEmpty: success,
+			wantError: "number value is zero",
+		},
+		{
+			name:      "number",
+			value:     1.5,
+			wantEmpty: failure,
+			wantError: "number value is 1.5, not zero",
+		},
+		{
+			name:      "false",
+			value:     false,
+			wantEmpty: success,
+			wantError: "boolean value is false",
+		},
+		{
+			name:      "true",
+			value:     true,
+			wantEmpty: failure,
+			wantError: "boolean value is true",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewValue(reporter, tc.value).IsEmpty().
+				chain.assert(t, tc.wantEmpty)
+
+			NewValue(reporter, tc.value).NotEmpty().
+				chain.assert(t, !tc.wantEmpty)
+
+			handler := &mockAssertionHandler{}
+			value := NewValueC(Config{AssertionHandler: handler}, tc.value)
+
+			if tc.wantEmpty {
+				value.NotEmpty()
+				require.NotNil(t, handler.failure)
+				assert.Equal(t, AssertNotEmpty, handler.failure.Type)
+			} else {
+				value.IsEmpty()
+				require.NotNil(t, handler.failure)
+				assert.Equal(t, AssertEmpty, handler.failure.Type)
+			}
+
+			assert.Contains(t, handler.failure.Errors, errors.New(tc.wantError))
+		})
+	}
+}
+
 func TestValue_PathTypes(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -932,6 +1407,66 @@ func TestValue_PathExpressions(t *testing.T) {
 	})
 }
 
+func TestValue_PathFailures(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{
+				"name": "foo",
+			},
+		},
+	}
+
+	cases := []struct {
+		path    string
+		segment string
+	}{
+		{"$.bad", ".bad"},
+		{"$.bad.name", ".bad"},
+		{"$.items[2].name", "[2]"},
+		{"$.items[0].bad", ".bad"},
+		{"$.items.name", ".name"},
+		{"$.items[0].name[1]", "[1]"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			handler := &mockAssertionHandler{}
+
+			value := NewValueC(Config{
+				AssertionHandler: handler,
+			}, data)
+
+			bad := value.Path(tc.path)
+			bad.chain.assert(t, failure)
+			assert.Nil(t, bad.Raw())
+
+			require.NotNil(t, handler.failure)
+			assert.Equal(t, AssertMatchPath, handler.failure.Type)
+			require.Equal(t, 3, len(handler.failure.Errors))
+			assert.Equal(t,
+				fmt.Sprintf("failed to resolve path segment %q", tc.segment),
+				handler.failure.Errors[1].Error())
+		})
+	}
+
+	t.Run("object", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		object := NewObjectC(Config{
+			AssertionHandler: handler,
+		}, data)
+
+		object.Path("$.items[0].name").String().IsEqual("foo")
+		object.chain.assert(t, success)
+
+		object.Path("$.items[5]").chain.assert(t, failure)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, `failed to resolve path segment "[5]"`,
+			handler.failure.Errors[1].Error())
+	})
+}
+
 func TestValue_Schema(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -985,6 +1520,114 @@ func TestValue_Schema(t *testing.T) {
 	NewValue(reporter, data1).Schema(url).chain.assert(t, success)
 	NewValue(reporter, data2).Schema(url).chain.assert(t, failure)
 
+	NewValue(reporter, data1).Schema(tmp.Name()).chain.assert(t, success)
+	NewValue(reporter, data2).Schema(tmp.Name()).chain.assert(t, failure)
+
 	NewValue(reporter, data1).Schema("file:///bad/path").chain.assert(t, failure)
 	NewValue(reporter, data1).Schema("{ bad json").chain.assert(t, failure)
 }
+
+func TestValue_SchemaFailures(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"properties": {
+			"foo": {
+				"type": "string"
+			},
+			"bar": {
+				"type": "integer"
+			}
+		},
+		"required": ["foo"]
+	}`
+
+	t.Run("mismatch", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		value := NewValueC(Config{
+			AssertionHandler: handler,
+		}, map[string]interface{}{
+			"bar": "b",
+		})
+
+		value.Schema(schema)
+		value.chain.assert(t, failure)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertMatchSchema, handler.failure.Type)
+		assert.Equal(t, []error{
+			errors.New("expected: value matches given json schema"),
+			errors.New("(root): foo is required (rule: required)"),
+			errors.New("bar: Invalid type. Expected: integer, given: string" +
+				" (rule: invalid_type)"),
+		}, handler.failure.Errors)
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			schema interface{}
+		}{
+			{"bad json", "{ bad json"},
+			{"bad type", `{"type": 123}`},
+			{"bad path", "file:///bad/path"},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				handler := &mockAssertionHandler{}
+
+				value := NewValueC(Config{
+					AssertionHandler: handler,
+				}, map[string]interface{}{
+					"foo": "a",
+				})
+
+				value.Schema(tc.schema)
+				value.chain.assert(t, failure)
+
+				require.NotNil(t, handler.failure)
+				assert.Equal(t, AssertUsage, handler.failure.Type)
+			})
+		}
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults()).enter("test")
+
+		jsonSchema(chain, map[string]interface{}{"foo": func() {}}, schema)
+		chain.leave()
+
+		chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertValid, handler.failure.Type)
+	})
+
+	t.Run("schema file path", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "with space#and%")
+		require.NoError(t, os.Mkdir(dir, 0o700))
+
+		path := filepath.Join(dir, "schema.json")
+		require.NoError(t, ioutil.WriteFile(path, []byte(schema), 0o600))
+
+		uri, ok := schemaFileURI(path)
+		require.True(t, ok)
+		assert.True(t, strings.HasPrefix(uri, "file:///"))
+		assert.NotContains(t, uri, " ")
+
+		reporter := newMockReporter(t)
+
+		NewValue(reporter, map[string]interface{}{"foo": "a"}).
+			Schema(path).
+			chain.assert(t, success)
+
+		NewValue(reporter, map[string]interface{}{"bar": 1}).
+			Schema(path).
+			chain.assert(t, failure)
+	})
+}
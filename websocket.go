@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -14,6 +15,10 @@ var (
 	infiniteTime = time.Time{}
 )
 
+// Default timeout for awaiting server close message in CloseWithCode,
+// used when read timeout is not set.
+const defaultCloseTimeout = 5 * time.Second
+
 // WebsocketConn is used by Websocket to communicate with actual WebSocket connection.
 type WebsocketConn interface {
 	ReadMessage() (messageType int, p []byte, err error)
@@ -188,7 +193,47 @@ func (ws *Websocket) Expect() *WebsocketMessage {
 		return newEmptyWebsocketMessage(opChain)
 	}
 
-	m := ws.readMessage(opChain)
+	m := ws.readMessage(opChain, ws.readTimeout)
+	if m == nil {
+		return newEmptyWebsocketMessage(opChain)
+	}
+
+	return m
+}
+
+// ExpectWithin is like Expect, but uses given read timeout instead of
+// the one set by WithReadTimeout.
+//
+// The timeout is applied only to this read; subsequent Expect calls use
+// the configured read timeout again.
+//
+// If no message arrives within timeout, failure is reported. Note that
+// after a read timeout, underlying connection may become unusable.
+//
+// Example:
+//
+//	conn.WriteText("start slow job")
+//	msg := conn.ExpectWithin(10 * time.Second)
+//	msg.JSON().Object().HasValue("status", "done")
+func (ws *Websocket) ExpectWithin(timeout time.Duration) *WebsocketMessage {
+	opChain := ws.chain.enter("ExpectWithin()")
+	defer opChain.leave()
+
+	if ws.checkUnusable(opChain, "ExpectWithin()") {
+		return newEmptyWebsocketMessage(opChain)
+	}
+
+	if timeout <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-positive timeout %v", timeout),
+			},
+		})
+		return newEmptyWebsocketMessage(opChain)
+	}
+
+	m := ws.readMessage(opChain, timeout)
 	if m == nil {
 		return newEmptyWebsocketMessage(opChain)
 	}
@@ -407,6 +452,117 @@ func (ws *Websocket) CloseWithText(s string, code ...int) *Websocket {
 	return ws
 }
 
+// CloseWithCode performs closing handshake: it sends a close message with
+// given code and reason, waits for the server to reply with a close message,
+// and then closes the underlying WebSocket connection.
+//
+// Server reply is awaited within read timeout set by WithReadTimeout,
+// or within 5 seconds if it's not set. Non-close messages received before
+// the close message are skipped.
+//
+// If the server doesn't reply with close message in time, or closes
+// connection without sending it, failure is reported. If the server replies
+// with close message having a different code, failure is reported too.
+//
+// WebSocket close codes are defined in RFC 6455, section 11.7.
+// See also https://godoc.org/github.com/gorilla/websocket#pkg-constants
+//
+// Example:
+//
+//	conn := resp.Connection()
+//	conn.WithReadTimeout(time.Second)
+//	conn.CloseWithCode(websocket.CloseGoingAway, "bye!")
+func (ws *Websocket) CloseWithCode(code int, reason string) *Websocket {
+	opChain := ws.chain.enter("CloseWithCode()")
+	defer opChain.leave()
+
+	if ws.checkUnusable(opChain, "CloseWithCode()") {
+		return ws
+	}
+
+	ws.writeMessage(opChain, websocket.CloseMessage, []byte(reason), code)
+
+	if opChain.failed() {
+		return ws
+	}
+
+	ws.awaitClose(opChain, code)
+
+	ws.isClosed = true
+
+	if err := ws.conn.Close(); err != nil && !opChain.failed() {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("got close error when disconnecting websocket"),
+				err,
+			},
+		})
+	}
+
+	return ws
+}
+
+func (ws *Websocket) awaitClose(opChain *chain, code int) {
+	timeout := ws.readTimeout
+	if timeout == noDuration {
+		timeout = defaultCloseTimeout
+	}
+
+	if !ws.setReadDeadline(opChain, timeout) {
+		return
+	}
+
+	for {
+		typ, content, err := ws.conn.ReadMessage()
+
+		if err == nil {
+			ws.printRead(typ, content, 0)
+			continue
+		}
+
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("no close message received from websocket within %v",
+						timeout),
+					err,
+				},
+			})
+			return
+		}
+
+		closeErr, ok := err.(*websocket.CloseError)
+		if !ok || closeErr.Code == websocket.CloseAbnormalClosure {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New(
+						"websocket connection closed without close message from server"),
+					err,
+				},
+			})
+			return
+		}
+
+		ws.printRead(websocket.CloseMessage, []byte(closeErr.Text), closeErr.Code)
+
+		if closeErr.Code != code {
+			opChain.fail(AssertionFailure{
+				Type:     AssertEqual,
+				Actual:   &AssertionValue{wsCloseCode(closeErr.Code)},
+				Expected: &AssertionValue{wsCloseCode(code)},
+				Errors: []error{
+					errors.New("expected: close codes are equal"),
+				},
+			})
+		}
+
+		return
+	}
+}
+
 // WriteMessage writes to the underlying WebSocket connection a message
 // of given type with given content.
 // Additionally, WebSocket close code may be specified for close messages.
@@ -435,6 +591,11 @@ func (ws *Websocket) WriteMessage(typ int, content []byte, closeCode ...int) *We
 }
 
 // WriteBytesBinary is a shorthand for c.WriteMessage(websocket.BinaryMessage, b).
+//
+// Example:
+//
+//	conn := resp.Connection()
+//	conn.WriteBytesBinary([]byte{0x01, 0x02})
 func (ws *Websocket) WriteBytesBinary(b []byte) *Websocket {
 	opChain := ws.chain.enter("WriteBytesBinary()")
 	defer opChain.leave()
@@ -449,6 +610,11 @@ func (ws *Websocket) WriteBytesBinary(b []byte) *Websocket {
 }
 
 // WriteBytesText is a shorthand for c.WriteMessage(websocket.TextMessage, b).
+//
+// Example:
+//
+//	conn := resp.Connection()
+//	conn.WriteBytesText([]byte("hello"))
 func (ws *Websocket) WriteBytesText(b []byte) *Websocket {
 	opChain := ws.chain.enter("WriteBytesText()")
 	defer opChain.leave()
@@ -464,6 +630,11 @@ func (ws *Websocket) WriteBytesText(b []byte) *Websocket {
 
 // WriteText is a shorthand for
 // c.WriteMessage(websocket.TextMessage, []byte(s)).
+//
+// Example:
+//
+//	conn := resp.Connection()
+//	conn.WriteText("hello")
 func (ws *Websocket) WriteText(s string) *Websocket {
 	opChain := ws.chain.enter("WriteText()")
 	defer opChain.leave()
@@ -472,11 +643,20 @@ func (ws *Websocket) WriteText(s string) *Websocket {
 		return ws
 	}
 
-	return ws.WriteMessage(websocket.TextMessage, []byte(s))
+	ws.writeMessage(opChain, websocket.TextMessage, []byte(s))
+
+	return ws
 }
 
 // WriteJSON writes to the underlying WebSocket connection given object,
-// marshaled using json.Marshal().
+// marshaled using json.Marshal(), as a text message.
+//
+// If object can't be marshaled, failure is reported and nothing is written.
+//
+// Example:
+//
+//	conn := resp.Connection()
+//	conn.WriteJSON(map[string]string{"type": "subscribe"})
 func (ws *Websocket) WriteJSON(object interface{}) *Websocket {
 	opChain := ws.chain.enter("WriteJSON()")
 	defer opChain.leave()
@@ -531,10 +711,12 @@ func (ws *Websocket) checkUnusable(opChain *chain, where string) bool {
 	return false
 }
 
-func (ws *Websocket) readMessage(opChain *chain) *WebsocketMessage {
+func (ws *Websocket) readMessage(
+	opChain *chain, timeout time.Duration,
+) *WebsocketMessage {
 	wm := newEmptyWebsocketMessage(opChain)
 
-	if !ws.setReadDeadline(opChain) {
+	if !ws.setReadDeadline(opChain, timeout) {
 		return nil
 	}
 
@@ -542,6 +724,18 @@ func (ws *Websocket) readMessage(opChain *chain) *WebsocketMessage {
 	wm.typ, wm.content, err = ws.conn.ReadMessage()
 
 	if err != nil {
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("no message received from websocket within %v",
+						timeout),
+					err,
+				},
+			})
+			return nil
+		}
+
 		closeErr, ok := err.(*websocket.CloseError)
 		if !ok {
 			opChain.fail(AssertionFailure{
@@ -618,10 +812,10 @@ func (ws *Websocket) writeMessage(
 	}
 }
 
-func (ws *Websocket) setReadDeadline(opChain *chain) bool {
+func (ws *Websocket) setReadDeadline(opChain *chain, timeout time.Duration) bool {
 	deadline := infiniteTime
-	if ws.readTimeout != noDuration {
-		deadline = time.Now().Add(ws.readTimeout)
+	if timeout != noDuration {
+		deadline = time.Now().Add(timeout)
 	}
 
 	if err := ws.conn.SetReadDeadline(deadline); err != nil {
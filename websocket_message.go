@@ -458,6 +458,38 @@ func (wm *WebsocketMessage) checkNotCode(opChain *chain, codes ...int) {
 	}
 }
 
+// Reason returns a new String instance with WebSocket close reason.
+//
+// Reason fails if WebSocket message type is not "8 - Connection Close Frame".
+//
+// Example:
+//
+//	msg := conn.Expect().CloseMessage()
+//	msg.Code(websocket.CloseGoingAway)
+//	msg.Reason().IsEqual("server shutdown")
+func (wm *WebsocketMessage) Reason() *String {
+	opChain := wm.chain.enter("Reason()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	if wm.typ != websocket.CloseMessage {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{wsMessageType(wm.typ)},
+			Expected: &AssertionValue{wsMessageType(websocket.CloseMessage)},
+			Errors: []error{
+				errors.New("expected: close message"),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, string(wm.content))
+}
+
 // Body returns a new String instance with WebSocket message content.
 //
 // Example:
@@ -509,7 +541,9 @@ func (wm *WebsocketMessage) NoContent() *WebsocketMessage {
 
 // JSON returns a new Value instance with JSON contents of WebSocket message.
 //
-// JSON succeeds if JSON may be decoded from message content.
+// JSON succeeds if JSON may be decoded from message content. Both text and
+// binary messages are accepted. On failure, a snippet of content around
+// the offending position is reported.
 //
 // Example:
 //
@@ -534,6 +568,7 @@ func (wm *WebsocketMessage) JSON() *Value {
 			Errors: []error{
 				errors.New("failed to decode json"),
 				err,
+				fmt.Errorf("near: %q", jsonErrorSnippet(wm.content, err)),
 			},
 		})
 		return newValue(opChain, nil)
@@ -542,6 +577,35 @@ func (wm *WebsocketMessage) JSON() *Value {
 	return newValue(opChain, value)
 }
 
+// NotJSON succeeds if WebSocket message content is not a valid JSON.
+//
+// Example:
+//
+//	msg := conn.Expect()
+//	msg.NotJSON()
+func (wm *WebsocketMessage) NotJSON() *WebsocketMessage {
+	opChain := wm.chain.enter("NotJSON()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return wm
+	}
+
+	if json.Valid(wm.content) {
+		opChain.fail(AssertionFailure{
+			Type: AssertNotValid,
+			Actual: &AssertionValue{
+				string(wm.content),
+			},
+			Errors: []error{
+				errors.New("expected: message content is not a valid json"),
+			},
+		})
+	}
+
+	return wm
+}
+
 type wsMessageType int
 
 func (wmt wsMessageType) String() string {
@@ -606,3 +670,28 @@ func (wcc wsCloseCode) String() string {
 
 	return fmt.Sprintf("%s(%d)", s, wcc)
 }
+
+// jsonErrorSnippet returns a short part of content around the position
+// where decoding failed.
+func jsonErrorSnippet(content []byte, err error) string {
+	const radius = 16
+
+	pos := len(content)
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		pos = int(syntaxErr.Offset)
+	}
+
+	begin := pos - radius
+	if begin < 0 {
+		begin = 0
+	}
+
+	end := pos + radius
+	if end > len(content) {
+		end = len(content)
+	}
+
+	return string(content[begin:end])
+}
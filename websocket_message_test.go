@@ -26,10 +26,12 @@ func TestWebsocketMessage_Failed(t *testing.T) {
 	msg.Code(0)
 	msg.NotCode(0)
 	msg.NoContent()
+	msg.NotJSON()
 	msg.Alias("foo")
 
 	msg.Body().chain.assertFailed(t)
 	msg.JSON().chain.assertFailed(t)
+	msg.Reason().chain.assertFailed(t)
 }
 
 func TestWebsocketMessage_Constructors(t *testing.T) {
@@ -136,6 +138,46 @@ func TestWebsocketMessage_CloseMessage(t *testing.T) {
 	msg.chain.clearFailed()
 }
 
+func TestWebsocketMessage_Reason(t *testing.T) {
+	t.Run("close message", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		msg := NewWebsocketMessage(reporter, websocket.CloseMessage,
+			[]byte("going away"), websocket.CloseGoingAway)
+
+		reason := msg.Reason()
+		reason.chain.assertNotFailed(t)
+		msg.chain.assertNotFailed(t)
+
+		assert.Equal(t, "going away", reason.Raw())
+	})
+
+	t.Run("empty reason", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		msg := NewWebsocketMessage(reporter, websocket.CloseMessage, nil,
+			websocket.CloseNormalClosure)
+
+		reason := msg.Reason()
+		reason.chain.assertNotFailed(t)
+		msg.chain.assertNotFailed(t)
+
+		assert.Equal(t, "", reason.Raw())
+	})
+
+	t.Run("text message", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		msg := NewWebsocketMessage(reporter, websocket.TextMessage, []byte("foo"))
+
+		reason := msg.Reason()
+		reason.chain.assertFailed(t)
+		msg.chain.assertFailed(t)
+
+		assert.Equal(t, "", reason.Raw())
+	})
+}
+
 func TestWebsocketMessage_TextMessage(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -448,6 +490,58 @@ func TestWebsocketMessage_JSON(t *testing.T) {
 
 		msg.chain.assertFailed(t)
 	})
+
+	t.Run("binary", func(t *testing.T) {
+		body := []byte(`{"foo":"bar"}`)
+
+		msg := NewWebsocketMessage(reporter, websocket.BinaryMessage, body)
+
+		j := msg.JSON()
+		j.chain.assertNotFailed(t)
+
+		require.Equal(t, "bar", j.Object().Value("foo").Raw())
+	})
+
+	t.Run("snippet", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		body := []byte(`{"foo":"bar", "baz": qux, "long": "aaaaaaaaaaaaaaaaaaaaaaaa"}`)
+
+		msg := NewWebsocketMessageC(Config{
+			AssertionHandler: handler,
+		}, websocket.TextMessage, body)
+
+		msg.JSON().chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		require.Equal(t, 3, len(handler.failure.Errors))
+		assert.Equal(t, `near: ":\"bar\", \"baz\": qux, \"long\": \"aaa"`,
+			handler.failure.Errors[2].Error())
+	})
+
+	t.Run("not json", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			typ     int
+			body    string
+			notJSON chainResult
+		}{
+			{"text object", websocket.TextMessage, `{"foo":"bar"}`, failure},
+			{"binary array", websocket.BinaryMessage, `[1, 2]`, failure},
+			{"invalid", websocket.TextMessage, `{`, success},
+			{"empty", websocket.TextMessage, ``, success},
+			{"plain text", websocket.BinaryMessage, `hello`, success},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				msg := NewWebsocketMessage(newMockReporter(t), tc.typ, []byte(tc.body))
+
+				msg.NotJSON()
+				msg.chain.assert(t, tc.notJSON)
+			})
+		}
+	})
 }
 
 func TestWebsocketMessage_Usage(t *testing.T) {
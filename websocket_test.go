@@ -7,6 +7,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func noWsPreSteps(ws *Websocket) {}
@@ -30,6 +31,7 @@ func TestWebsocket_FailedChain(t *testing.T) {
 
 	ws.Subprotocol().chain.assertFailed(t)
 	ws.Expect().chain.assertFailed(t)
+	ws.ExpectWithin(time.Second).chain.assertFailed(t)
 
 	ws.WriteMessage(websocket.TextMessage, []byte("a"))
 	ws.WriteBytesBinary([]byte("a"))
@@ -41,6 +43,7 @@ func TestWebsocket_FailedChain(t *testing.T) {
 	ws.CloseWithBytes([]byte("a"))
 	ws.CloseWithJSON(map[string]string{"a": "b"})
 	ws.CloseWithText("a")
+	ws.CloseWithCode(websocket.CloseNormalClosure, "a")
 
 	ws.Disconnect()
 	ws.Close()
@@ -217,6 +220,72 @@ func TestWebsocket_Expect(t *testing.T) {
 	}
 }
 
+func TestWebsocket_ExpectWithin(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		conn := &mockWebsocketConn{}
+
+		ws := NewWebsocketC(Config{Reporter: reporter}, conn)
+
+		ws.ExpectWithin(time.Hour).chain.assertNotFailed(t)
+		ws.chain.assertNotFailed(t)
+
+		assert.True(t, conn.readDeadline.After(time.Now().Add(time.Minute)))
+	})
+
+	t.Run("applies to one read", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		conn := &mockWebsocketConn{}
+
+		ws := NewWebsocketC(Config{Reporter: reporter}, conn).
+			WithReadTimeout(time.Second)
+
+		ws.ExpectWithin(time.Hour)
+		assert.True(t, conn.readDeadline.After(time.Now().Add(time.Minute)))
+
+		ws.Expect()
+		assert.True(t, conn.readDeadline.Before(time.Now().Add(time.Minute)))
+
+		ws.chain.assertNotFailed(t)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		conn := &mockWebsocketConn{
+			readMsgErr: &mockNetError{isTimeout: true},
+		}
+
+		ws := NewWebsocketC(Config{AssertionHandler: handler}, conn)
+
+		ws.ExpectWithin(time.Second).chain.assertFailed(t)
+		ws.chain.assertFailed(t)
+
+		if assert.NotNil(t, handler.failure) {
+			assert.Equal(t, "no message received from websocket within 1s",
+				handler.failure.Errors[0].Error())
+		}
+	})
+
+	t.Run("non-positive timeout", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		ws := NewWebsocketC(Config{Reporter: reporter}, &mockWebsocketConn{})
+
+		ws.ExpectWithin(0).chain.assertFailed(t)
+		ws.chain.assertFailed(t)
+	})
+
+	t.Run("closed connection", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		ws := NewWebsocketC(Config{Reporter: reporter}, &mockWebsocketConn{})
+		ws.Disconnect()
+
+		ws.ExpectWithin(time.Second).chain.assertFailed(t)
+		ws.chain.assertFailed(t)
+	})
+}
+
 func TestWebsocket_Close(t *testing.T) {
 	type args struct {
 		wsConn     WebsocketConn
@@ -534,6 +603,169 @@ func TestWebsocket_CloseWithJSON(t *testing.T) {
 	}
 }
 
+func TestWebsocket_CloseWithCode(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		config := Config{
+			AssertionHandler: handler,
+		}.withDefaults()
+
+		ws := newWebsocket(newChainWithConfig("test", config), config,
+			&mockWebsocketConn{
+				readMsgErr: &websocket.CloseError{
+					Code: websocket.CloseGoingAway,
+				},
+			})
+
+		ws.CloseWithCode(websocket.CloseGoingAway, "bye")
+		ws.chain.assertNotFailed(t)
+
+		assert.Nil(t, handler.failure)
+		assert.True(t, ws.isClosed)
+
+		ws.WriteText("foo")
+		ws.chain.assertFailed(t)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		config := Config{
+			AssertionHandler: handler,
+		}.withDefaults()
+
+		ws := newWebsocket(newChainWithConfig("test", config), config,
+			&mockWebsocketConn{
+				readMsgErr: &websocket.CloseError{
+					Code: websocket.CloseNormalClosure,
+				},
+			})
+
+		ws.CloseWithCode(websocket.CloseGoingAway, "bye")
+		ws.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertEqual, handler.failure.Type)
+		assert.Equal(t, wsCloseCode(websocket.CloseNormalClosure),
+			handler.failure.Actual.Value)
+		assert.Equal(t, wsCloseCode(websocket.CloseGoingAway),
+			handler.failure.Expected.Value)
+		assert.True(t, ws.isClosed)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		config := Config{
+			AssertionHandler: handler,
+		}.withDefaults()
+
+		ws := newWebsocket(newChainWithConfig("test", config), config,
+			&mockWebsocketConn{
+				readMsgErr: &mockNetError{isTimeout: true},
+			})
+
+		ws.WithReadTimeout(time.Second)
+		ws.CloseWithCode(websocket.CloseGoingAway, "bye")
+		ws.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		assert.Contains(t, handler.failure.Errors[0].Error(),
+			"no close message received")
+		assert.True(t, ws.isClosed)
+	})
+
+	t.Run("default timeout", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		config := Config{
+			AssertionHandler: handler,
+		}.withDefaults()
+
+		conn := &mockWebsocketConn{
+			readMsgErr: &mockNetError{isTimeout: true},
+		}
+
+		ws := newWebsocket(newChainWithConfig("test", config), config, conn)
+
+		before := time.Now()
+		ws.CloseWithCode(websocket.CloseGoingAway, "bye")
+		ws.chain.assertFailed(t)
+
+		assert.False(t, conn.readDeadline.Before(before.Add(defaultCloseTimeout)))
+		assert.False(t, conn.readDeadline.After(time.Now().Add(defaultCloseTimeout)))
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		assert.Contains(t, handler.failure.Errors[0].Error(),
+			defaultCloseTimeout.String())
+		assert.True(t, ws.isClosed)
+	})
+
+	t.Run("closed without close message", func(t *testing.T) {
+		for _, err := range []error{
+			&websocket.CloseError{Code: websocket.CloseAbnormalClosure},
+			errors.New("unexpected EOF"),
+		} {
+			handler := &mockAssertionHandler{}
+			config := Config{
+				AssertionHandler: handler,
+			}.withDefaults()
+
+			ws := newWebsocket(newChainWithConfig("test", config), config,
+				&mockWebsocketConn{
+					readMsgErr: err,
+				})
+
+			ws.CloseWithCode(websocket.CloseGoingAway, "bye")
+			ws.chain.assertFailed(t)
+
+			require.NotNil(t, handler.failure)
+			assert.Equal(t, AssertOperation, handler.failure.Type)
+			assert.Contains(t, handler.failure.Errors[0].Error(),
+				"without close message")
+		}
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		ws := newWebsocket(newMockChain(t), newMockConfig(reporter),
+			&mockWebsocketConn{
+				writeMsgErr: errors.New("write error"),
+			})
+
+		ws.CloseWithCode(websocket.CloseGoingAway, "bye")
+		ws.chain.assertFailed(t)
+		assert.False(t, ws.isClosed)
+	})
+
+	t.Run("close error", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		ws := newWebsocket(newMockChain(t), newMockConfig(reporter),
+			&mockWebsocketConn{
+				readMsgErr: &websocket.CloseError{
+					Code: websocket.CloseGoingAway,
+				},
+				closeError: errors.New("close error"),
+			})
+
+		ws.CloseWithCode(websocket.CloseGoingAway, "bye")
+		ws.chain.assertFailed(t)
+	})
+
+	t.Run("unusable", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		ws := newWebsocket(newMockChain(t), newMockConfig(reporter), nil)
+		ws.CloseWithCode(websocket.CloseGoingAway, "bye")
+		ws.chain.assertFailed(t)
+
+		ws = newWebsocket(newMockChain(t), newMockConfig(reporter),
+			&mockWebsocketConn{})
+		ws.Disconnect()
+		ws.CloseWithCode(websocket.CloseGoingAway, "bye")
+		ws.chain.assertFailed(t)
+	})
+}
+
 func TestWebsocket_WriteMessage(t *testing.T) {
 	type args struct {
 		wsConn     WebsocketConn
@@ -993,7 +1225,7 @@ func TestWebsocket_SetReadDeadline(t *testing.T) {
 				WithReadTimeout(time.Second)
 
 			opChain := ws.chain.enter("test")
-			ws.setReadDeadline(opChain)
+			ws.setReadDeadline(opChain, ws.readTimeout)
 			opChain.leave()
 
 			if tc.assertOk {
@@ -1140,3 +1372,78 @@ func TestWebsocket_PrintWrite(t *testing.T) {
 		t.Errorf("Websocket.printWrite() failed to write to printer")
 	}
 }
+
+func TestWebsocket_WritePrinter(t *testing.T) {
+	cases := []struct {
+		name        string
+		writeFn     func(ws *Websocket)
+		wantFailed  bool
+		wantType    int
+		wantContent string
+	}{
+		{
+			name: "WriteBytesBinary",
+			writeFn: func(ws *Websocket) {
+				ws.WriteBytesBinary([]byte("foo"))
+			},
+			wantType:    websocket.BinaryMessage,
+			wantContent: "foo",
+		},
+		{
+			name: "WriteBytesText",
+			writeFn: func(ws *Websocket) {
+				ws.WriteBytesText([]byte("foo"))
+			},
+			wantType:    websocket.TextMessage,
+			wantContent: "foo",
+		},
+		{
+			name: "WriteText",
+			writeFn: func(ws *Websocket) {
+				ws.WriteText("foo")
+			},
+			wantType:    websocket.TextMessage,
+			wantContent: "foo",
+		},
+		{
+			name: "WriteJSON",
+			writeFn: func(ws *Websocket) {
+				ws.WriteJSON(map[string]string{"foo": "bar"})
+			},
+			wantType:    websocket.TextMessage,
+			wantContent: `{"foo":"bar"}`,
+		},
+		{
+			name: "WriteJSON marshal failure",
+			writeFn: func(ws *Websocket) {
+				ws.WriteJSON(make(chan int))
+			},
+			wantFailed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+			printer := newMockWsPrinter()
+			config := Config{
+				Reporter: reporter,
+				Printers: []Printer{printer},
+			}.withDefaults()
+
+			ws := newWebsocket(newMockChain(t), config, &mockWebsocketConn{})
+
+			tc.writeFn(ws)
+
+			if tc.wantFailed {
+				ws.chain.assertFailed(t)
+				assert.False(t, printer.isWrittenTo)
+			} else {
+				ws.chain.assertNotFailed(t)
+				assert.True(t, printer.isWrittenTo)
+				assert.Equal(t, tc.wantType, printer.writeType)
+				assert.Equal(t, tc.wantContent, string(printer.writeContent))
+			}
+		})
+	}
+}